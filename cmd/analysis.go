@@ -3,7 +3,10 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"strings"
 	"time"
@@ -13,9 +16,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/CanobbioE/algo-trading/pkg/ai"
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
 	"github.com/CanobbioE/algo-trading/pkg/config"
 	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/notify"
 	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/report"
 	"github.com/CanobbioE/algo-trading/pkg/signals"
 	"github.com/CanobbioE/algo-trading/pkg/strategies"
 	"github.com/CanobbioE/algo-trading/pkg/utilities"
@@ -23,21 +29,32 @@ import (
 
 type analysisScope struct {
 	p                printer.Printer
+	screen           printer.Printer
 	cfg              *config.Config
 	output           *strings.Builder
+	out              *outputOptions
+	writer           *report.Writer
 	assistant        *ai.Assistant
+	notifier         *notify.Dispatcher
+	calendar         *calendar.Calendar
+	sentiment        signals.Operation
 	ticker           string
 	timeFrame        string
 	mode             string
 	cfgFile          string
 	assistantCfgFile string
+	chart            string
+	chartStyle       printer.ChartStyle
 	refreshRate      time.Duration
 	lifespan         time.Duration
+	chartWidth       int
+	chartHeight      int
 }
 
 func init() {
-	s := &analysisScope{output: &strings.Builder{}}
+	s := &analysisScope{output: &strings.Builder{}, out: &outputOptions{}}
 	s.p = printer.NewCompositePrinter(&printer.Standard{}, printer.NewStringsPrinter(s.output))
+	s.screen = &printer.Standard{}
 	analysisCmd := &cobra.Command{
 		Use:     "analyse",
 		Short:   "Analyse a single stock",
@@ -52,28 +69,58 @@ func init() {
 	analysisCmd.Flags().StringVarP(&s.mode, "mode", "m", "onetime", "How the command will run: continue or onetime")
 	//nolint:lll
 	analysisCmd.Flags().DurationVarP(&s.refreshRate, "refresh", "r", 10*time.Minute, "Analyses refresh rate in continuos mode")
-	analysisCmd.Flags().DurationVarP(&s.lifespan, "life", "l", 1*time.Hour, "How long the continuous mode should run for")
+	analysisCmd.Flags().DurationVarP(&s.lifespan, "life", "l", 1*time.Hour,
+		"How long the continuous mode should run for, 0 to run until interrupted")
 
 	analysisCmd.Flags().StringVarP(&s.assistantCfgFile, "assistant", "a", "", "Path to config file for the AI assistant")
 
+	analysisCmd.Flags().StringVar(&s.chart, "chart", "",
+		"Draw a chart of the prices before the analysis: candles or line")
+	analysisCmd.Flags().Lookup("chart").NoOptDefVal = string(printer.Candlesticks)
+	analysisCmd.Flags().IntVar(&s.chartWidth, "chart-width", 0, "Width of the chart, 0 to fit the terminal")
+	analysisCmd.Flags().IntVar(&s.chartHeight, "chart-height", printer.DefaultChartHeight,
+		"Height of the price panel of the chart")
+
+	s.out.addFlags(analysisCmd)
+
 	utilities.Must(analysisCmd.MarkFlagRequired("ticker"))
 	utilities.Must(analysisCmd.MarkFlagRequired("config"))
 	rootCmd.AddCommand(analysisCmd)
 }
 
 func (s *analysisScope) preRunE(cmd *cobra.Command, _ []string) error {
-	file, err := os.Open(s.cfgFile)
-	if err != nil {
-		return fmt.Errorf("failed to open configuration file: %w", err)
+	if err := s.out.parse(); err != nil {
+		return err
+	}
+	if s.out.stderr() {
+		s.p = printer.NewCompositePrinter(printer.NewStandard(os.Stderr), printer.NewStringsPrinter(s.output))
+	}
+	if s.chart != "" {
+		if s.out.format != report.FormatText {
+			return errors.New("--chart cannot be used with a machine-readable --output format")
+		}
+		var err error
+		if s.chartStyle, err = printer.ParseChartStyle(s.chart); err != nil {
+			return err
+		}
+		if s.chartWidth == 0 {
+			s.chartWidth = printer.TerminalWidth(os.Stdout)
+		}
+	}
+
+	var err error
+	if s.cfg, err = cfgOpts.load(s.cfgFile); err != nil {
+		return err
 	}
-	defer func() {
-		_ = file.Close()
-	}()
 
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&s.cfg)
+	s.notifier, err = notify.NewDispatcher(s.cfg.Notifiers...)
 	if err != nil {
-		return fmt.Errorf("failed to decode configuration: %w", err)
+		return fmt.Errorf("failed to create notifiers: %w", err)
+	}
+	if s.cfg.Calendar != nil {
+		if s.calendar, err = s.cfg.Calendar.Load(); err != nil {
+			return fmt.Errorf("failed to load trading calendar: %w", err)
+		}
 	}
 
 	if s.assistantCfgFile == "" {
@@ -89,7 +136,7 @@ func (s *analysisScope) preRunE(cmd *cobra.Command, _ []string) error {
 	}()
 
 	var aiCfg ai.Config
-	decoder = json.NewDecoder(assistantFile)
+	decoder := json.NewDecoder(assistantFile)
 	err = decoder.Decode(&aiCfg)
 	if err != nil {
 		return fmt.Errorf("failed to decode AI assistant configuration: %w", err)
@@ -103,8 +150,18 @@ func (s *analysisScope) preRunE(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func (s *analysisScope) runE(cmd *cobra.Command, _ []string) error {
-	cli := carnost.NewClient()
+func (s *analysisScope) runE(cmd *cobra.Command, _ []string) (err error) {
+	cli, err := clientOpts.newClient()
+	if err != nil {
+		return err
+	}
+	var closer io.Closer
+	if s.writer, closer, err = s.out.open(); err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, closer.Close())
+	}()
 	ctx := cmd.Context()
 
 	switch s.mode {
@@ -112,7 +169,7 @@ func (s *analysisScope) runE(cmd *cobra.Command, _ []string) error {
 		return s.analyse(ctx, cli)
 	case "continuous", "continue", "cont":
 		if s.refreshRate < 5*time.Second {
-			s.p.PrintColored(printer.Yellow, "Refresh rate of %s is too low, defaulting to 5s\n", s.refreshRate)
+			slog.Warn("refresh rate too low, defaulting to 5s", "refresh", s.refreshRate)
 			s.refreshRate = 5 * time.Second
 		}
 
@@ -121,13 +178,18 @@ func (s *analysisScope) runE(cmd *cobra.Command, _ []string) error {
 		time.Sleep(1 * time.Second)
 		s.p.Reset()
 		s.p = s.p.CleanLine()
-		watchList := monitor.NewWatchList(s.p, s.refreshRate)
-		go watchList.StartMonitoring(func() error {
+		s.screen = s.screen.CleanLine()
+		watchList := monitor.NewWatchList(s.refreshRate)
+		if s.calendar != nil {
+			watchList.SetSessions(s.calendar.Hours(s.ticker))
+		}
+		ctx, cancel := withLifespan(ctx, s.lifespan)
+		defer cancel()
+		summary := watchList.StartMonitoring(ctx, func(ctx context.Context) error {
 			return s.analyse(ctx, cli)
 		})
-
-		time.Sleep(s.lifespan)
-		watchList.Stop()
+		printSummary(s.p, summary)
+		return summaryError(summary)
 	}
 
 	return nil
@@ -142,44 +204,179 @@ func (s *analysisScope) analyse(ctx context.Context, cli api.Client) error {
 	if len(data) == 0 {
 		return fmt.Errorf("no data for ticker %s", s.ticker)
 	}
+	if s.calendar != nil && s.cfg.Calendar.ClosedBarsOnly {
+		data = monitor.ClosedBars(s.calendar, s.ticker, data, barInterval(data), time.Now())
+	}
 
 	m := make(map[signals.Operation]int, len(s.cfg.Strategies))
+	operations := make(map[string]signals.Operation, len(s.cfg.Strategies))
 
 	for _, strat := range s.cfg.Strategies {
 		operation := strat.Strategy.Execute(data)
 		m[operation]++
+		operations[strat.Name()] = operation
 	}
 
-	s.p.Reset()
-	strategies.NewAnalysisInput(s.p.CleanLine(), s.cfg.Strategies...).GenerateAnalysis()
-	s.p.Printf("Considering %d strategies, the overall sentiment is:\n", len(s.cfg.Strategies))
-	s.printSentiment(signals.Buy, m)
-	s.printSentiment(signals.Sell, m)
-	s.printSentiment(signals.Setup, m)
-	s.printSentiment(signals.NoOp, m)
+	p := s.p
+	if s.writer != nil {
+		// the human-readable analysis is only needed as the input of the assistant
+		s.output.Reset()
+		p = printer.NewStringsPrinter(s.output)
+	}
+	p.Reset()
+	if s.chartStyle != "" {
+		s.printChart(data)
+	}
+	analysis := strategies.NewAnalysisInput(p.CleanLine(), s.cfg.Strategies...)
+	analysis.GenerateAnalysis()
+	p.Printf("Considering %d strategies, the overall sentiment is:\n", len(s.cfg.Strategies))
+	s.printSentiment(p, signals.Buy, m)
+	s.printSentiment(p, signals.Sell, m)
+	s.printSentiment(p, signals.Setup, m)
+	s.printSentiment(p, signals.NoOp, m)
+	s.checkSentiment(ctx, m)
+
+	var aiSuggestion string
+	if s.assistant != nil {
+		assistantInput := printer.CleanOutput(s.output)
+		p.Println("======================")
+		slog.Info("asking the AI assistant", "ticker", strings.ToUpper(s.ticker))
+
+		aiSuggestion, err = s.assistant.Analyse(ctx, assistantInput, s.cfg.Filters.MaxRisk.String(), s.ticker)
+		if err != nil {
+			return err
+		}
 
-	if s.assistant == nil {
+		if s.writer == nil {
+			s.p.Reset()
+			s.p.Println("======================")
+			s.p.Println(aiSuggestion)
+		}
+	}
+
+	if s.writer == nil {
 		return nil
 	}
+	return s.writer.WriteAnalysis(&report.Analysis{
+		Timestamp:  time.Now(),
+		Indicators: analysis.Indicators(),
+		Signals:    operations,
+		Sentiment:  m,
+		Symbol:     strings.ToUpper(s.ticker),
+		Timeframe:  s.timeFrame,
+		Assistant:  aiSuggestion,
+		Strategies: len(s.cfg.Strategies),
+	})
+}
+
+// barInterval guesses how long the bars of data last, as the time frames are ranges of bars
+// rather than their duration: it's the shortest gap between the latest bars, which skips
+// the closed days, or a day if the bars have no timestamps.
+func barInterval(data []*api.OHLCV) time.Duration {
+	const latest = 10
+	var interval time.Duration
+	for i := max(1, len(data)-latest); i < len(data); i++ {
+		if gap := data[i].Timestamp.Sub(data[i-1].Timestamp); gap > 0 && (interval == 0 || gap < interval) {
+			interval = gap
+		}
+	}
+	if interval == 0 {
+		return 24 * time.Hour
+	}
+	return interval
+}
+
+// printChart draws data with the indicators of the strategies to the terminal only,
+// so that the chart is not part of the input of the assistant.
+func (s *analysisScope) printChart(data []*api.OHLCV) {
+	// the strategies are left with the analysis of the whole data
+	points := strategies.Series(data, s.cfg.Strategies...)
+	chart := &printer.Chart{
+		Style:    s.chartStyle,
+		Bars:     data,
+		Overlays: chartOverlays(points),
+		Width:    s.chartWidth,
+		Height:   s.chartHeight,
+	}
+	s.screen.Printf("%s\n", strings.ToUpper(s.ticker))
+	chart.Print(s.screen)
+	s.screen.Println("")
+}
 
-	assistantInput := printer.CleanOutput(s.output)
-	s.p.Println("======================")
+// chartOverlays returns the SMA, VWAP, Bollinger bands and breakout levels at each point.
+func chartOverlays(points []*strategies.Point) []*printer.Overlay {
+	indicators := []struct {
+		value   func(in *strategies.Indicators) float64
+		overlay *printer.Overlay
+	}{
+		{
+			value:   func(in *strategies.Indicators) float64 { return in.SMA },
+			overlay: &printer.Overlay{Name: "SMA", Mark: '·', Color: printer.Yellow},
+		},
+		{
+			value:   func(in *strategies.Indicators) float64 { return in.VWAP },
+			overlay: &printer.Overlay{Name: "VWAP", Mark: '~', Color: printer.Blue},
+		},
+		{
+			value:   func(in *strategies.Indicators) float64 { return in.BollingerUpper },
+			overlay: &printer.Overlay{Name: "Bollinger bands", Mark: ':', Color: printer.White},
+		},
+		{
+			value:   func(in *strategies.Indicators) float64 { return in.BollingerLower },
+			overlay: &printer.Overlay{Name: "Bollinger bands", Mark: ':', Color: printer.White},
+		},
+		{
+			value:   func(in *strategies.Indicators) float64 { return in.Resistance },
+			overlay: &printer.Overlay{Name: "Resistance", Mark: '-', Color: printer.Red},
+		},
+		{
+			value:   func(in *strategies.Indicators) float64 { return in.Support },
+			overlay: &printer.Overlay{Name: "Support", Mark: '-', Color: printer.Green},
+		},
+	}
 
-	s.p.Println("Asking the AI assistant to do its job...")
+	overlays := make([]*printer.Overlay, 0, len(indicators))
+	for _, indicator := range indicators {
+		indicator.overlay.Values = make([]float64, len(points))
+		for i, point := range points {
+			indicator.overlay.Values[i] = indicator.value(point.Indicators)
+		}
+		overlays = append(overlays, indicator.overlay)
+	}
+	return overlays
+}
 
-	aiSuggestion, err := s.assistant.Analyse(ctx, assistantInput, s.cfg.Filters.MaxRisk.String(), s.ticker)
-	if err != nil {
-		return err
+// checkSentiment notifies when the prevailing operation changes from the one of the previous analysis.
+func (s *analysisScope) checkSentiment(ctx context.Context, m map[signals.Operation]int) {
+	var sentiment signals.Operation
+	for _, op := range []signals.Operation{signals.Buy, signals.Sell, signals.Setup, signals.NoOp} {
+		if sentiment == "" || m[op] > m[sentiment] {
+			sentiment = op
+		}
 	}
 
-	s.p.Reset()
-	s.p.Println("======================")
-	s.p.Println(aiSuggestion)
+	previous := s.sentiment
+	s.sentiment = sentiment
+	if previous == "" || previous == sentiment || s.notifier.Len() == 0 {
+		return
+	}
 
-	return nil
+	// let the alert be delivered even if the analysis is being stopped
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	defer cancel()
+	err := s.notifier.Notify(ctx, &notify.Alert{
+		Timestamp: time.Now(),
+		Title:     "SENTIMENT CHANGE",
+		Message: fmt.Sprintf("The overall sentiment for %s changed from %s to %s (%d of %d strategies)",
+			strings.ToUpper(s.ticker), strings.ToUpper(string(previous)), strings.ToUpper(string(sentiment)),
+			m[sentiment], len(s.cfg.Strategies)),
+	})
+	if err != nil {
+		slog.Error("failed to send alerts", "error", err)
+	}
 }
 
-func (s *analysisScope) printSentiment(k signals.Operation, m map[signals.Operation]int) {
+func (s *analysisScope) printSentiment(p printer.Printer, k signals.Operation, m map[signals.Operation]int) {
 	var c printer.Color
 	v, ok := m[k]
 	if !ok {
@@ -196,5 +393,5 @@ func (s *analysisScope) printSentiment(k signals.Operation, m map[signals.Operat
 		c = printer.White
 	}
 	key := printer.WrapInColor(strings.ToUpper(string(k)), c)
-	s.p.Printf(key+":\t%2.f%%\n", float32(v)/float32(len(s.cfg.Strategies))*100)
+	p.Printf(key+":\t%2.f%%\n", float32(v)/float32(len(s.cfg.Strategies))*100)
 }
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+	"github.com/CanobbioE/algo-trading/pkg/synthetic"
+)
+
+type clientOptions struct {
+	recordDir     string
+	replayDir     string
+	syntheticSpec string
+}
+
+// newClient returns the market data client selected by the root flags.
+func (o *clientOptions) newClient() (api.Client, error) {
+	if o.replayDir != "" {
+		return replay.NewPlayer(o.replayDir)
+	}
+	if o.syntheticSpec != "" {
+		return newSyntheticClient(o.syntheticSpec)
+	}
+
+	var cli api.Client = carnost.NewClient()
+	if o.recordDir != "" {
+		cli = replay.NewRecorder(cli, o.recordDir)
+	}
+
+	return cli, nil
+}
+
+func newSyntheticClient(specFile string) (*synthetic.Client, error) {
+	file, err := os.Open(specFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open synthetic data specification: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var spec synthetic.Spec
+	if err = json.NewDecoder(file).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode synthetic data specification: %w", err)
+	}
+
+	return synthetic.NewClient(spec.Series...), nil
+}
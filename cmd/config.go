@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+)
+
+// configOptions configures how the configuration files are read.
+type configOptions struct {
+	format  string
+	profile string
+	strict  bool
+}
+
+// loader returns the config.Loader reading the files in the format of the flag or of their extension.
+func (o *configOptions) loader() (*config.Loader, error) {
+	f, err := config.ParseFormat(o.format)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Loader{Format: f, Profile: o.profile, Strict: o.strict}, nil
+}
+
+// load reads and validates the configuration file at path.
+func (o *configOptions) load(path string) (*config.Config, error) {
+	l, err := o.loader()
+	if err != nil {
+		return nil, err
+	}
+	return l.Load(path)
+}
+
+// fingerprint returns a short fingerprint of the configuration file at path, of the files it extends and
+// includes and of the profile, to tell configurations apart.
+func (o *configOptions) fingerprint(path string) (string, error) {
+	l, err := o.loader()
+	if err != nil {
+		return "", err
+	}
+	doc, err := l.Render(path)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, file := range doc.Files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+	}
+	if o.profile != "" {
+		h.Write([]byte("\x00" + o.profile))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12], nil
+}
+
+// identity returns a stable identity of the configuration file at path and of the profile: unlike the fingerprint,
+// it survives the edits of the files, so that the state a command keeps across runs does too.
+func (o *configOptions) identity(path string) (string, error) {
+	id, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if o.profile != "" {
+		id += "#" + o.profile
+	}
+	return id, nil
+}
+
+type configValidateScope struct {
+	p      printer.Printer
+	strict bool
+}
+
+func (s *configValidateScope) runE(_ *cobra.Command, args []string) error {
+	l, err := cfgOpts.loader()
+	if err != nil {
+		return err
+	}
+	l.Strict = s.strict
+
+	invalid := 0
+	for _, path := range args {
+		if _, err = l.Load(path); err == nil {
+			s.p.PrintColored(printer.Green, "%s: OK\n", path)
+			continue
+		}
+		invalid++
+		var verr *config.ValidationError
+		if !errors.As(err, &verr) {
+			s.p.PrintColored(printer.Red, "%s: %v\n", path, err)
+			continue
+		}
+		s.p.PrintColored(printer.Red, "%s: invalid\n", path)
+		for _, p := range verr.Problems {
+			s.p.Printf("  - %s\n", p)
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d configurations are invalid", invalid, len(args))
+	}
+	return nil
+}
+
+type configRenderScope struct {
+	format string
+}
+
+func (s *configRenderScope) runE(_ *cobra.Command, args []string) error {
+	f, err := config.ParseFormat(s.format)
+	if err != nil {
+		return err
+	}
+	l, err := cfgOpts.loader()
+	if err != nil {
+		return err
+	}
+	doc, err := l.Render(args[0])
+	if err != nil {
+		return err
+	}
+	return config.Encode(os.Stdout, f, doc.Values)
+}
+
+func init() {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with configuration files",
+		Long:  "Work with configuration files.",
+	}
+
+	s := &configValidateScope{
+		p: &printer.Standard{},
+	}
+	validateCmd := &cobra.Command{
+		Use:   "validate FILE...",
+		Short: "Validate configuration files",
+		Long: "Validate configuration files, reporting every problem found in each of them with the path of " +
+			"the field it concerns.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: s.runE,
+	}
+	validateCmd.Flags().BoolVar(&s.strict, "strict", true, "Report the unknown fields as problems")
+
+	r := &configRenderScope{}
+	renderCmd := &cobra.Command{
+		Use:   "render FILE",
+		Short: "Print a configuration file fully resolved",
+		Long: "Print a configuration file fully resolved: merged over the files it extends, with the universes " +
+			"it includes and the selected profile applied, without validating it.",
+		Args: cobra.ExactArgs(1),
+		RunE: r.runE,
+	}
+	renderCmd.Flags().StringVarP(&r.format, "output", "o", string(config.FormatJSON),
+		"Format of the output: json, yaml or toml")
+
+	configCmd.AddCommand(validateCmd, renderCmd)
+	rootCmd.AddCommand(configCmd)
+}
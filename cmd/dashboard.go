@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+	"github.com/CanobbioE/algo-trading/pkg/tui"
+)
+
+// sparklineBars is how many of the latest closes the dashboard draws.
+const sparklineBars = 60
+
+// dashboardSource feeds the dashboard with the scans of the monitor, which still saves the history
+// and raises the alerts matching the rules of the configuration.
+type dashboardSource struct {
+	*monitorScope
+	client  api.Client
+	scanner *monitor.MarketScanner
+	// mu serializes the scans and the analyses, which share the strategies.
+	mu sync.Mutex
+}
+
+// Scan runs a market scan, returning every score.
+func (d *dashboardSource) Scan(ctx context.Context) ([]*monitor.StockScore, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	started := time.Now()
+	all, err := d.scanner.ScanAll(ctx)
+	d.observeScan(started, d.scanner, all, err)
+	if err != nil {
+		return nil, err
+	}
+	scores := d.scanner.Filter(all)
+	d.history.save(started, all, scores)
+	d.publishScan(started, all, scores)
+	d.checkAlerts(ctx, all)
+	return all, nil
+}
+
+// Detail analyses a single stock.
+func (d *dashboardSource) Detail(ctx context.Context, symbol string) (*tui.Detail, error) {
+	data, err := d.client.GetOHLCV(ctx, symbol, &carnost.WithTimeframe{TimeFrame: carnost.Daily})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data for ticker %s", symbol)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var out strings.Builder
+	analysis, _ := strategies.Analyse(printer.NewStringsPrinter(&out), data, d.cfg.Strategies...)
+	analysis.GenerateAnalysis()
+
+	closes := make([]float64, 0, sparklineBars)
+	for _, bar := range data[max(0, len(data)-sparklineBars):] {
+		closes = append(closes, bar.Close)
+	}
+	return &tui.Detail{Report: printer.CleanOutput(&out), Closes: closes}, nil
+}
+
+// runDashboard monitors the market from an interactive dashboard.
+func (s *monitorScope) runDashboard(ctx context.Context, cli api.Client) error {
+	ctx, cancel := withLifespan(ctx, s.lifespan)
+	defer cancel()
+
+	stdout, logger := s.p, slog.Default()
+	// the messages and the logs of the monitor would garble the dashboard, which shows them in its log pane
+	log := &tui.Log{}
+	s.p = printer.NewStandard(log)
+	if logOpts.toTerminal() {
+		slog.SetDefault(logOpts.newLogger(log))
+	}
+	source := &dashboardSource{monitorScope: s, client: cli, scanner: s.newScanner(cli)}
+	opts := &tui.Options{Filters: s.cfg.Filters, Log: log, Refresh: s.refreshRate}
+	if s.calendar != nil {
+		opts.Sessions = s.calendar.Hours(s.cfg.StockUniverse...)
+	}
+	summary, err := tui.Run(ctx, source, opts)
+	s.p = stdout
+	slog.SetDefault(logger)
+
+	s.shutdown()
+	if err != nil {
+		return fmt.Errorf("failed to run the dashboard: %w", err)
+	}
+	printSummary(s.p, summary)
+	s.p.Printf("Alerts raised: %d\n", s.sent)
+	return summaryError(summary)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/synthetic"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+type generateScope struct {
+	p         printer.Printer
+	spec      *synthetic.Spec
+	specFile  string
+	outputDir string
+}
+
+func (s *generateScope) preRunE(_ *cobra.Command, _ []string) error {
+	file, err := os.Open(s.specFile)
+	if err != nil {
+		return fmt.Errorf("failed to open specification file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&s.spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode specification: %w", err)
+	}
+
+	return nil
+}
+
+func (s *generateScope) runE(_ *cobra.Command, _ []string) error {
+	if err := os.MkdirAll(s.outputDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, series := range s.spec.Series {
+		path := filepath.Join(s.outputDir, strings.ToUpper(series.Symbol)+".csv")
+		if err := writeSeries(path, series); err != nil {
+			return err
+		}
+		s.p.Printf("Wrote %d bars for %s to %s\n", series.Bars, series.Symbol, path)
+	}
+
+	return nil
+}
+
+func writeSeries(path string, series *synthetic.Series) error {
+	file, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if err = synthetic.WriteCSV(file, series.Generate()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	s := &generateScope{
+		p: &printer.Standard{},
+	}
+	generateCmd := &cobra.Command{
+		Use:     "generate-data",
+		Short:   "Generate synthetic market data",
+		Long:    "Generate synthetic OHLCV series as CSV files, one per symbol.",
+		PreRunE: s.preRunE,
+		RunE:    s.runE,
+	}
+
+	generateCmd.Flags().StringVarP(&s.specFile, "spec", "s", "", "Path to the synthetic data specification file")
+	generateCmd.Flags().StringVarP(&s.outputDir, "output", "o", ".", "Directory the CSV files are written to")
+
+	utilities.Must(generateCmd.MarkFlagRequired("spec"))
+	rootCmd.AddCommand(generateCmd)
+}
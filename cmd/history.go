@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/history"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+// historyRecorder persists the scans of a command, if enabled.
+type historyRecorder struct {
+	// live is the run of the live session, which every closed bar adds its score to
+	live       *history.Run
+	command    string
+	path       string
+	configHash string
+	// configID keys the state, which must survive the edits of the configuration
+	configID string
+}
+
+// save stores every scanned score, flagging the ones meeting the filter criteria.
+func (h *historyRecorder) save(started time.Time, all, matched []*monitor.StockScore) {
+	if h.path == "" {
+		return
+	}
+
+	run := &history.Run{
+		StartedAt:  started,
+		Command:    h.command,
+		ConfigHash: h.configHash,
+		Duration:   time.Since(started),
+		Scanned:    len(all),
+		Matched:    len(matched),
+	}
+	records := make([]*history.Record, 0, len(all))
+	for _, score := range all {
+		records = append(records, history.NewRecord(run, score, slices.Contains(matched, score)))
+	}
+
+	if err := history.NewStore(h.path).SaveRun(run, records); err != nil {
+		slog.Error("failed to save scan history", "path", h.path, "error", err)
+	}
+}
+
+// saveLive adds the score computed on a closed bar to the run of the live session started at started,
+// so that a session is listed as a single run instead of a run per bar.
+func (h *historyRecorder) saveLive(started, closed time.Time, score *monitor.StockScore, meetsCriteria bool) {
+	if h.path == "" {
+		return
+	}
+
+	if h.live == nil {
+		h.live = &history.Run{StartedAt: started, Command: h.command, ConfigHash: h.configHash}
+	}
+	h.live.Duration = closed.Sub(started)
+	h.live.Scanned++
+	if meetsCriteria {
+		h.live.Matched++
+	}
+	record := history.NewRecord(h.live, score, meetsCriteria)
+	record.Timestamp = closed
+
+	if err := history.NewStore(h.path).SaveRun(h.live, []*history.Record{record}); err != nil {
+		slog.Error("failed to save live score", "symbol", score.Symbol, "path", h.path, "error", err)
+	}
+}
+
+// loadState restores the state saved under name for the current configuration file, if any.
+func (h *historyRecorder) loadState(name string, v any) (bool, error) {
+	if h.path == "" {
+		return false, nil
+	}
+	return history.NewStore(h.path).LoadState(name+":"+h.configID, v)
+}
+
+// saveState persists the state under name for the current configuration file, if enabled.
+func (h *historyRecorder) saveState(name string, v any) {
+	if h.path == "" {
+		return
+	}
+	if err := history.NewStore(h.path).SaveState(name+":"+h.configID, v); err != nil {
+		slog.Error("failed to save state", "name", name, "path", h.path, "error", err)
+	}
+}
+
+// saveAlert appends a delivered alert to the log, if enabled.
+func (h *historyRecorder) saveAlert(n *alert.Notification) {
+	if h.path == "" {
+		return
+	}
+	if err := history.NewStore(h.path).SaveAlert(n); err != nil {
+		slog.Error("failed to save alert", "rule", n.Rule, "path", h.path, "error", err)
+	}
+}
+
+type historyScope struct {
+	p      printer.Printer
+	store  *history.Store
+	path   string
+	ticker string
+	since  time.Duration
+	limit  int
+	runID  uint64
+}
+
+func (s *historyScope) preRunE(_ *cobra.Command, _ []string) error {
+	s.store = history.NewStore(s.path)
+	return nil
+}
+
+func (s *historyScope) runsE(_ *cobra.Command, _ []string) error {
+	runs, err := s.store.Runs(s.limit)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		s.p.PrintColored(printer.Yellow, "No scan recorded in %s\n", s.path)
+		return nil
+	}
+
+	s.p.Printf("%-6s %-20s %-8s %-12s %8s %8s %10s\n",
+		"ID", "STARTED", "COMMAND", "CONFIG", "SCANNED", "MATCHED", "DURATION")
+	for _, r := range runs {
+		s.p.Printf("%-6d %-20s %-8s %-12s %8d %8d %10s\n",
+			r.ID, r.StartedAt.Local().Format(time.DateTime), r.Command, r.ConfigHash,
+			r.Scanned, r.Matched, r.Duration.Round(time.Millisecond))
+	}
+	return nil
+}
+
+func (s *historyScope) timelineE(_ *cobra.Command, _ []string) error {
+	var from time.Time
+	if s.since > 0 {
+		from = time.Now().Add(-s.since)
+	}
+	records, err := s.store.Timeline(s.ticker, from, time.Time{})
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		s.p.PrintColored(printer.Yellow, "No score recorded for %s\n", strings.ToUpper(s.ticker))
+		return nil
+	}
+
+	s.p.Printf("=== SCORE TIMELINE: %s ===\n", strings.ToUpper(s.ticker))
+	s.printRecords(records, func(r *history.Record) string {
+		return r.Timestamp.Local().Format(time.DateTime)
+	})
+	return nil
+}
+
+func (s *historyScope) runE(_ *cobra.Command, _ []string) error {
+	records, err := s.store.RunRecords(s.runID)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		s.p.PrintColored(printer.Yellow, "No score recorded for run %d\n", s.runID)
+		return nil
+	}
+
+	s.p.Printf("=== RUN #%d (%s) ===\n", s.runID, records[0].Timestamp.Local().Format(time.DateTime))
+	s.printRecords(records, func(r *history.Record) string {
+		return r.Symbol
+	})
+	return nil
+}
+
+func (s *historyScope) printRecords(records []*history.Record, label func(*history.Record) string) {
+	s.p.Printf("%-20s %10s %8s %8s %7s %7s %s\n",
+		"", "PRICE", "SCORE", "CONF", "RISK", "OPP", "SIGNALS")
+	for _, r := range records {
+		line := fmt.Sprintf("%-20s %10.2f %8.2f %7.1f%% %7s %7s %s\n",
+			label(r), r.LastPrice, r.WeightedScore, r.Confidence*100,
+			r.Risk.String(), r.Opportunity.String(), formatSignals(r))
+		if r.MeetsCriteria {
+			s.p.PrintColored(printer.Green, "%s", line)
+			continue
+		}
+		s.p.Printf("%s", line)
+	}
+}
+
+func formatSignals(r *history.Record) string {
+	names := make([]string, 0, len(r.Signals))
+	for name := range r.Signals {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, name+"="+strings.ToUpper(string(r.Signals[name])))
+	}
+	return strings.Join(out, " ")
+}
+
+func init() {
+	s := &historyScope{
+		p: &printer.Standard{},
+	}
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Query the scan history",
+		Long:  "Query the scan runs and scores persisted by the scan and monitor commands.",
+	}
+	historyCmd.PersistentFlags().StringVar(&s.path, "history-db", history.DefaultPath(), "Path to the history database")
+
+	runsCmd := &cobra.Command{
+		Use:     "runs",
+		Short:   "List the latest scan runs",
+		Long:    "List the latest scan runs, most recent first.",
+		PreRunE: s.preRunE,
+		RunE:    s.runsE,
+	}
+	runsCmd.Flags().IntVarP(&s.limit, "number", "n", 20, "How many runs to list, 0 for all")
+
+	timelineCmd := &cobra.Command{
+		Use:     "timeline",
+		Short:   "Show how the score of a stock evolved",
+		Long:    "Show how the score of a stock evolved across scan runs, oldest first.",
+		PreRunE: s.preRunE,
+		RunE:    s.timelineE,
+	}
+	timelineCmd.Flags().StringVarP(&s.ticker, "ticker", "t", "", "Stock ticker to use")
+	timelineCmd.Flags().DurationVar(&s.since, "since", 0, "Only show scores newer than this, 0 for all")
+	utilities.Must(timelineCmd.MarkFlagRequired("ticker"))
+
+	runCmd := &cobra.Command{
+		Use:     "run",
+		Short:   "Show the scores of a scan run",
+		Long:    "Show the scores of every stock in a scan run.",
+		PreRunE: s.preRunE,
+		RunE:    s.runE,
+	}
+	runCmd.Flags().Uint64Var(&s.runID, "id", 0, "ID of the run to show")
+	utilities.Must(runCmd.MarkFlagRequired("id"))
+
+	historyCmd.AddCommand(runsCmd, timelineCmd, runCmd)
+	rootCmd.AddCommand(historyCmd)
+}
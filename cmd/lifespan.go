@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+)
+
+// notifyTimeout bounds the delivery of an alert, which is allowed to complete after the command is interrupted.
+const notifyTimeout = 30 * time.Second
+
+// withLifespan bounds ctx to lifespan, a non-positive lifespan runs until the command is interrupted.
+func withLifespan(ctx context.Context, lifespan time.Duration) (context.Context, context.CancelFunc) {
+	if lifespan <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, lifespan)
+}
+
+// printSummary reports what a monitoring session did.
+func printSummary(p printer.Printer, summary *monitor.Summary) {
+	p.PrintColored(printer.Blue, "\n=== SESSION SUMMARY ===\n")
+	p.Printf("Duration: %s\n", summary.Duration.Round(time.Second))
+	p.Printf("Scans: %d (%d failed, %d skipped while the market was closed)\n",
+		summary.Runs, summary.Failures, summary.Skipped)
+	if summary.Interrupted {
+		p.PrintColored(printer.Yellow, "The last scan was interrupted\n")
+	}
+}
+
+// summaryError fails a session where every scan failed.
+func summaryError(summary *monitor.Summary) error {
+	if summary.Runs > 0 && summary.Failures == summary.Runs {
+		return fmt.Errorf("all %d scans failed", summary.Runs)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+)
+
+// logOptions configures the logger of the diagnostics, which are written apart from the output of the commands.
+type logOptions struct {
+	file      *os.File
+	levelName string
+	format    logging.Format
+	name      string
+	path      string
+	level     slog.Level
+}
+
+func (o *logOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.levelName, "log-level", "info",
+		"Minimum level of the logs: debug, info, warn or error")
+	cmd.PersistentFlags().StringVar(&o.name, "log-format", string(logging.FormatText),
+		"Format of the logs: text or json")
+	cmd.PersistentFlags().StringVar(&o.path, "log-file", "",
+		"Path to the file the logs are appended to, instead of stderr")
+}
+
+// setup validates the flags and makes the configured logger the default one.
+func (o *logOptions) setup() error {
+	var err error
+	if o.level, err = logging.ParseLevel(o.levelName); err != nil {
+		return err
+	}
+	if o.format, err = logging.ParseFormat(o.name); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stderr
+	if o.path != "" {
+		if o.file, err = os.OpenFile(o.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600); err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		w = o.file
+	}
+	slog.SetDefault(o.newLogger(w))
+	return nil
+}
+
+// newLogger creates a logger writing to w with the configured format and level.
+func (o *logOptions) newLogger(w io.Writer) *slog.Logger {
+	return logging.New(w, o.format, o.level)
+}
+
+// toTerminal reports whether the logs are written to the terminal.
+func (o *logOptions) toTerminal() bool {
+	return o.path == ""
+}
+
+// close closes the log file, if any.
+func (o *logOptions) close() error {
+	if o.file == nil {
+		return nil
+	}
+	return o.file.Close()
+}
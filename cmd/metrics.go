@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/cache"
+	"github.com/CanobbioE/algo-trading/pkg/metrics"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// listenShutdownTimeout is how long the servers started by listen have to complete the requests in progress
+// when stopping.
+const listenShutdownTimeout = 5 * time.Second
+
+// metricsOptions configures the metrics endpoint and the cache of the market data.
+type metricsOptions struct {
+	addr     string
+	cacheTTL time.Duration
+}
+
+func (o *metricsOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.addr, "metrics-addr", "",
+		"Address to serve Prometheus metrics on at /metrics, e.g. :9090, empty to disable")
+	cmd.Flags().DurationVar(&o.cacheTTL, "cache-ttl", 0,
+		"How long the market data fetched is reused for by the following scans, 0 to disable")
+}
+
+// serve starts serving the metrics, if enabled, returning the function stopping the server.
+func (o *metricsOptions) serve(m *metrics.Metrics) (func() error, error) {
+	if o.addr == "" {
+		return func() error { return nil }, nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.Handler())
+	return listen(o.addr, mux, "metrics")
+}
+
+// listen serves handler on addr in the background, returning the function stopping the server.
+func listen(addr string, handler http.Handler, name string) (func() error, error) {
+	// listen first, so that a busy address fails the command rather than the background server
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for %s: %w", name, err)
+	}
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("failed to serve "+name, "error", err)
+		}
+	}()
+	slog.Info("serving "+name, "addr", listener.Addr().String())
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), listenShutdownTimeout)
+		defer cancel()
+		return server.Shutdown(ctx)
+	}, nil
+}
+
+// instrument decorates cli with the metrics and the cache, when enabled.
+func (s *monitorScope) instrument(cli api.Client) (api.Client, func() error, error) {
+	if s.metricsOpts.addr != "" {
+		s.metrics = metrics.New()
+	}
+	stop, err := s.metricsOpts.serve(s.metrics)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// only the requests reaching the provider are timed
+	cli = s.metrics.Client(cli)
+	if s.metricsOpts.cacheTTL > 0 {
+		cached := cache.NewClient(cli, s.metricsOpts.cacheTTL)
+		s.metrics.RegisterCache(cached)
+		cli = cached
+	}
+	return cli, stop, nil
+}
+
+// observeScan records a scan of scanner started at started, which scored all.
+func (s *monitorScope) observeScan(started time.Time, scanner *monitor.MarketScanner, all []*monitor.StockScore,
+	err error,
+) {
+	s.metrics.ObserveScan(time.Since(started), all, scanner.Failed(), err)
+}
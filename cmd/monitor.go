@@ -1,88 +1,412 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/CanobbioE/stock-market-clients/carnost"
+	"github.com/CanobbioE/stock-market-clients/api"
 	"github.com/spf13/cobra"
 
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
 	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/history"
+	"github.com/CanobbioE/algo-trading/pkg/metrics"
 	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/notify"
 	"github.com/CanobbioE/algo-trading/pkg/printer"
-	"github.com/CanobbioE/algo-trading/pkg/utilities"
+	"github.com/CanobbioE/algo-trading/pkg/push"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+	"github.com/CanobbioE/algo-trading/pkg/stream"
 )
 
 type monitorScope struct {
-	p           printer.Printer
-	cfg         *config.Config
-	cfgFile     string
-	refreshRate time.Duration
-	lifespan    time.Duration
+	p            printer.Printer
+	cfg          *config.Config
+	history      *historyRecorder
+	notifier     *notify.Dispatcher
+	alerts       *alert.Engine
+	tracker      *alert.Tracker
+	output       *outputOptions
+	writer       *report.Writer
+	calendar     *calendar.Calendar
+	metrics      *metrics.Metrics
+	metricsOpts  *metricsOptions
+	push         *push.Hub
+	pushOpts     *pushOptions
+	previous     *monitor.Snapshot
+	cfgFile      string
+	scheduleFile string
+	streamURL    string
+	diffFormat   string
+	jobs         []*scheduledMonitor
+	refreshRate  time.Duration
+	lifespan     time.Duration
+	sent         int
+	next         int
+	tui          bool
 }
 
-func (s *monitorScope) preRunE(_ *cobra.Command, _ []string) error {
-	file, err := os.Open(s.cfgFile)
+func (s *monitorScope) preRunE(cmd *cobra.Command, _ []string) error {
+	switch s.diffFormat {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("invalid diff format %q, must be one of text or json", s.diffFormat)
+	}
+	if err := s.output.parse(); err != nil {
+		return err
+	}
+	if err := s.pushOpts.validate(); err != nil {
+		return err
+	}
+	if s.output.stderr() {
+		s.p = printer.NewStandard(os.Stderr)
+	}
+	if s.tui && s.output.format != report.FormatText {
+		return errors.New("--tui cannot be used with a machine-readable --output format")
+	}
+	if s.scheduleFile != "" {
+		// scheduled jobs run until interrupted, unless told otherwise
+		if !cmd.Flags().Changed("life") {
+			s.lifespan = 0
+		}
+		return s.loadSchedule()
+	}
+	return s.load()
+}
+
+// load reads the configuration file and restores the state of the previous runs.
+func (s *monitorScope) load() error {
+	cfg, err := cfgOpts.load(s.cfgFile)
+	if err != nil {
+		return err
+	}
+
+	s.cfg = cfg
+	s.notifier, err = notify.NewDispatcher(cfg.Notifiers...)
+	if err != nil {
+		return fmt.Errorf("failed to create notifiers: %w", err)
+	}
+	s.alerts = alert.NewEngine(cfg.Filters, cfg.Alerts...)
+	if cfg.Calendar != nil {
+		if s.calendar, err = cfg.Calendar.Load(); err != nil {
+			return fmt.Errorf("failed to load trading calendar: %w", err)
+		}
+	}
+	s.history.configHash, err = cfgOpts.fingerprint(s.cfgFile)
+	if err != nil {
+		return err
+	}
+	s.history.configID, err = cfgOpts.identity(s.cfgFile)
+	if err != nil {
+		return err
+	}
+
+	// resume the alerts state, so that a restart doesn't repeat the alerts already sent,
+	// forgetting the rules that were removed from the configuration since
+	var state alert.State
+	if _, err = s.history.loadState("alerts", &state); err != nil {
+		return fmt.Errorf("failed to load alerts state: %w", err)
+	}
+	state.Retain(s.alerts.Rules())
+	s.tracker = alert.NewTracker(cfg.Alerting, &state)
+	return nil
+}
+
+func (s *monitorScope) runE(cmd *cobra.Command, _ []string) (err error) {
+	if s.scheduleFile != "" && s.next > 0 {
+		s.printUpcoming()
+		return nil
+	}
+	cli, err := clientOpts.newClient()
+	if err != nil {
+		return err
+	}
+	cli, stop, err := s.instrument(cli)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, stop())
+	}()
+	hub, stopPush, err := s.pushOpts.serve()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, stopPush())
+	}()
+	s.push = hub
+	writer, closer, err := s.output.open()
 	if err != nil {
 		return err
 	}
 	defer func() {
-		_ = file.Close()
+		err = errors.Join(err, closer.Close())
 	}()
+	s.writer = writer
+	if s.scheduleFile != "" {
+		return s.runScheduled(cmd.Context(), cli)
+	}
+	if s.tui {
+		return s.runDashboard(cmd.Context(), cli)
+	}
+	scanner := s.newScanner(cli)
+	if s.streamURL != "" {
+		return s.runLive(cmd.Context(), scanner)
+	}
+
+	watchList := monitor.NewWatchList(s.refreshRate)
+	if s.calendar != nil {
+		watchList.SetSessions(s.calendar.Hours(s.cfg.StockUniverse...))
+	}
+	ctx, cancel := withLifespan(cmd.Context(), s.lifespan)
+	defer cancel()
+
+	slog.Info("starting market monitoring", "refresh", s.refreshRate)
+	summary := watchList.StartMonitoring(ctx, func(ctx context.Context) error {
+		return s.scan(ctx, scanner)
+	})
+
+	s.shutdown()
+	printSummary(s.p, summary)
+	s.p.Printf("Alerts raised: %d\n", s.sent)
+	return summaryError(summary)
+}
+
+func (s *monitorScope) newScanner(cli api.Client) *monitor.MarketScanner {
+	scanner := monitor.NewMarketScanner(s.cfg.Strategies, s.cfg.StockUniverse, s.cfg.Filters, cli, s.p)
+	if s.calendar != nil && s.cfg.Calendar.ClosedBarsOnly {
+		scanner.SetCalendar(s.calendar)
+	}
+	return scanner
+}
 
-	decoder := json.NewDecoder(file)
-	var cfg config.Config
-	err = decoder.Decode(&cfg)
+// scan runs a market scan, reporting the opportunities found and raising the alerts.
+func (s *monitorScope) scan(ctx context.Context, scanner *monitor.MarketScanner) error {
+	started := time.Now()
+	all, err := scanner.ScanAll(ctx)
+	s.observeScan(started, scanner, all, err)
 	if err != nil {
 		return err
 	}
+	scores := scanner.Filter(all)
+	s.history.save(started, all, scores)
+	s.publishScan(started, all, scores)
 
-	s.cfg = &cfg
+	current := &monitor.Snapshot{Timestamp: started, All: all, Ranked: scores, Failed: scanner.Failed()}
+	defer func() {
+		s.previous = current
+	}()
+	if s.writer != nil {
+		if err = s.writer.WriteScan(started, scores); err != nil {
+			return err
+		}
+	}
+	if s.previous != nil && s.diffFormat != "" {
+		if err = s.reportDiff(scanner, monitor.Diff(s.previous, current)); err != nil {
+			return err
+		}
+	} else {
+		s.report(scanner, scores)
+	}
+	// the rules are evaluated on every stock, a stop-loss must fire for the ones failing the filters too
+	s.checkAlerts(ctx, all)
 	return nil
 }
 
-func (s *monitorScope) runE(cmd *cobra.Command, _ []string) error {
-	scanner := monitor.NewMarketScanner(s.cfg.Strategies, s.cfg.StockUniverse, s.cfg.Filters, carnost.NewClient(), s.p)
+// report prints the opportunities found by a scan.
+func (s *monitorScope) report(scanner *monitor.MarketScanner, scores []*monitor.StockScore) {
+	if len(scores) == 0 {
+		s.p.PrintColored(printer.Red, "No stocks meet current criteria\n")
+		return
+	}
+	s.p.Printf("Found %d opportunities\n", len(scores))
+	if s.writer == nil {
+		// show top 5 stock picks (todo: make it configurable)
+		scanner.GenerateReport(scores, 5)
+	}
+}
+
+// shutdown persists what is still pending once the monitoring stops,
+// so that held and digested alerts are delivered by the next run.
+func (s *monitorScope) shutdown() {
+	s.history.saveState("alerts", s.tracker.State())
+}
 
-	watchList := monitor.NewWatchList(s.p, s.refreshRate)
-	s.p.PrintColored(printer.Blue, "Starting market monitoring (updates every %v)\n", s.refreshRate)
-	go watchList.StartMonitoring(func() error {
-		scores, err := scanner.ScanMarket(cmd.Context())
+// reportDiff prints what changed since the previous scan, in the requested format.
+func (s *monitorScope) reportDiff(scanner *monitor.MarketScanner, d *monitor.ScanDiff) error {
+	switch s.diffFormat {
+	case "json":
+		data, err := json.Marshal(d)
 		if err != nil {
 			return err
 		}
+		s.p.Println(string(data))
+	default:
+		scanner.GenerateDiffReport(d)
+	}
+	return nil
+}
 
-		if len(scores) == 0 {
-			s.p.PrintColored(printer.Red, "No stocks meet current criteria\n")
+// runLive re-evaluates a symbol every time the stream delivers one of its bars.
+func (s *monitorScope) runLive(ctx context.Context, scanner *monitor.MarketScanner) error {
+	ctx, cancel := withLifespan(ctx, s.lifespan)
+	defer cancel()
+
+	started := time.Now()
+	slog.Info("starting live market monitoring", "stream", s.streamURL)
+	lm := monitor.NewLiveMonitor(scanner, stream.NewWebsocket(s.streamURL))
+	err := lm.Run(ctx, func(score *monitor.LiveScore) error {
+		if score.Final {
+			s.history.saveLive(started, time.Now(), score.StockScore, score.MeetsCriteria)
+		}
+		s.metrics.ObserveScore(score.StockScore)
+		s.push.PublishScore(time.Now(), score.StockScore, score.MeetsCriteria)
+		defer s.checkAlerts(ctx, []*monitor.StockScore{score.StockScore})
+		if !score.MeetsCriteria {
 			return nil
 		}
-		s.p.Printf("Found %d opportunities\n", len(scores))
-		// show top 5 stock picks (todo: make it configurable)
-		scanner.GenerateReport(scores, 5)
-		s.checkAlerts(scores)
+		if s.writer != nil {
+			if err := s.writer.WriteScan(time.Now(), []*monitor.StockScore{score.StockScore}); err != nil {
+				return err
+			}
+		}
+		status := "forming"
+		if score.Final {
+			status = "closed"
+		}
+		s.p.Printf("%s %s (%s bar): Price €%.2f, Weighted Score %.2f, Confidence %.1f%%\n",
+			time.Now().Format(time.TimeOnly), score.Symbol, status,
+			score.LastPrice, score.WeightedScore, score.Confidence*100)
 		return nil
 	})
+	s.shutdown()
+	s.p.PrintColored(printer.Blue, "\n=== SESSION SUMMARY ===\n")
+	s.p.Printf("Duration: %s\n", time.Since(started).Round(time.Second))
+	s.p.Printf("Alerts raised: %d\n", s.sent)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
 
-	time.Sleep(s.lifespan)
-	watchList.Stop()
-	return nil
+// checkAlerts sends alerts for the scores matching the configured alert rules,
+// unless they are throttled, held for quiet hours or collected for the digest.
+func (s *monitorScope) checkAlerts(ctx context.Context, scores []*monitor.StockScore) {
+	now := time.Now()
+	deliver, queued := s.tracker.Process(now, scores, s.alerts.Evaluate(scores))
+	s.sent += len(deliver)
+	for _, n := range queued {
+		s.p.PrintColored(printer.White, "%s queued: %s\n", alertTitle(n), symbols(n.Scores))
+	}
+	for _, n := range deliver {
+		s.delivered(n)
+		for _, score := range n.Scores {
+			s.p.PrintColored(severityColor(n.Severity), "%s: %s (Score: %.2f, Confidence: %.1f%%)\n",
+				alertTitle(n), score.Symbol, score.WeightedScore, score.Confidence*100)
+		}
+		s.notify(ctx, &notify.Alert{
+			Timestamp: n.Timestamp,
+			Title:     alertTitle(n),
+			Severity:  n.Severity.String(),
+			Scores:    n.Scores,
+		}, n.Notifiers...)
+	}
+
+	if due := s.tracker.Flush(now); len(due) > 0 {
+		slog.Info("delivering queued alerts", "count", len(due))
+		for _, n := range due {
+			s.delivered(n)
+		}
+		s.notify(ctx, digest(now, due), digestNotifiers(due)...)
+	}
+	s.history.saveState("alerts", s.tracker.State())
+}
+
+// notify delivers a, letting the delivery complete even if the monitoring is being stopped.
+func (s *monitorScope) notify(ctx context.Context, a *notify.Alert, notifiers ...string) {
+	if s.notifier.Len() == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	defer cancel()
+	if err := s.notifier.NotifyTo(ctx, a, notifiers...); err != nil {
+		slog.Error("failed to send alerts", "error", err)
+	}
 }
 
-// checkAlerts sends alerts for exceptional opportunities.
-func (s *monitorScope) checkAlerts(scores []*monitor.StockScore) {
-	// TODO: send me an email
+func alertTitle(n *alert.Notification) string {
+	title := strings.ToUpper(n.Rule) + " ALERT"
+	if n.Escalated {
+		title += " (ESCALATED)"
+	}
+	return title
+}
+
+func symbols(scores []*monitor.StockScore) string {
+	out := make([]string, 0, len(scores))
 	for _, score := range scores {
-		if score.Opportunity >= monitor.OpportunityMedium && score.Risk <= monitor.RiskMedium {
-			s.p.PrintColored(printer.Green, "HIGH OPPORTUNITY ALERT: %s (Score: %.2f, Confidence: %.1f%%)\n",
-				score.Symbol, score.WeightedScore, score.Confidence*100)
+		out = append(out, score.Symbol)
+	}
+	return strings.Join(out, ", ")
+}
+
+// digest summarizes queued notifications into a single alert.
+func digest(now time.Time, notifications []*alert.Notification) *notify.Alert {
+	lines := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		lines = append(lines, fmt.Sprintf("%s [%s] %s: %s",
+			n.Timestamp.Format(time.DateTime), n.Severity, alertTitle(n), symbols(n.Scores)))
+	}
+	return &notify.Alert{
+		Timestamp: now,
+		Title:     "ALERTS DIGEST",
+		Message:   strings.Join(lines, "\n"),
+	}
+}
+
+// digestNotifiers returns the notifiers targeted by any of the notifications, none meaning all of them.
+func digestNotifiers(notifications []*alert.Notification) []string {
+	var out []string
+	for _, n := range notifications {
+		if len(n.Notifiers) == 0 {
+			return nil
 		}
+		for _, name := range n.Notifiers {
+			if !slices.Contains(out, name) {
+				out = append(out, name)
+			}
+		}
+	}
+	return out
+}
+
+func severityColor(severity alert.Severity) printer.Color {
+	switch severity {
+	case alert.SeverityCritical:
+		return printer.Red
+	case alert.SeverityWarning:
+		return printer.Yellow
+	default:
+		return printer.Green
 	}
 }
 
 func init() {
 	s := &monitorScope{
-		p: &printer.Standard{},
+		p:           &printer.Standard{},
+		history:     &historyRecorder{command: "monitor"},
+		output:      &outputOptions{},
+		metricsOpts: &metricsOptions{},
+		pushOpts:    &pushOptions{},
 	}
 	monitorCmd := &cobra.Command{
 		Use:     "monitor",
@@ -94,8 +418,31 @@ func init() {
 
 	monitorCmd.Flags().StringVarP(&s.cfgFile, "config", "c", "", "Path to config file")
 	monitorCmd.Flags().DurationVarP(&s.refreshRate, "refresh", "r", 10*time.Minute, "Scan refresh rate")
-	monitorCmd.Flags().DurationVarP(&s.lifespan, "life", "l", 1*time.Hour, "How long the monitor should run for")
+	monitorCmd.Flags().DurationVarP(&s.lifespan, "life", "l", 1*time.Hour,
+		"How long the monitor should run for, 0 to run until interrupted")
+	monitorCmd.Flags().StringVar(&s.history.path, "history-db", history.DefaultPath(),
+		"Path to the database the scan history and the delivered alerts are saved to, empty to disable")
+	monitorCmd.Flags().StringVarP(&s.diffFormat, "diff", "d", "",
+		"After the first scan, only report what changed: text or json")
+	monitorCmd.Flags().StringVarP(&s.streamURL, "stream", "s", "",
+		"Websocket URL of a live bar feed, stocks are re-evaluated as new bars arrive")
+
+	monitorCmd.Flags().StringVar(&s.scheduleFile, "schedule", "",
+		"Path to a schedule file, to run scans of different configurations at given times instead of every --refresh")
+	monitorCmd.Flags().IntVar(&s.next, "next", 0, "Print the next runs of every scheduled job and exit")
+
+	monitorCmd.Flags().BoolVar(&s.tui, "tui", false,
+		"Show an interactive dashboard of the scans, instead of printing their reports")
+
+	s.output.addFlags(monitorCmd)
+	s.metricsOpts.addFlags(monitorCmd)
+	s.pushOpts.addFlags(monitorCmd)
 
-	utilities.Must(monitorCmd.MarkFlagRequired("config"))
+	monitorCmd.MarkFlagsOneRequired("config", "schedule")
+	monitorCmd.MarkFlagsMutuallyExclusive("config", "schedule")
+	monitorCmd.MarkFlagsMutuallyExclusive("stream", "schedule")
+	monitorCmd.MarkFlagsMutuallyExclusive("tui", "schedule")
+	monitorCmd.MarkFlagsMutuallyExclusive("tui", "stream")
+	monitorCmd.MarkFlagsMutuallyExclusive("tui", "diff")
 	rootCmd.AddCommand(monitorCmd)
 }
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/report"
+)
+
+// outputOptions configures the machine-readable output of a command.
+type outputOptions struct {
+	format report.Format
+	name   string
+	path   string
+}
+
+func (o *outputOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.name, "output", "o", string(report.FormatText),
+		"Output format: text, json, ndjson, csv or markdown")
+	cmd.Flags().StringVar(&o.path, "output-file", "", "Path to the file the output is written to, instead of stdout")
+}
+
+// parse validates the requested format.
+func (o *outputOptions) parse() error {
+	var err error
+	if o.format, err = report.ParseFormat(o.name); err != nil {
+		return err
+	}
+	if o.format == report.FormatText && o.path != "" {
+		return errors.New("--output-file requires a machine-readable --output format")
+	}
+	return nil
+}
+
+// stderr reports whether the human-readable messages should go to stderr,
+// because the machine-readable output is written to stdout.
+func (o *outputOptions) stderr() bool {
+	return o.format != report.FormatText && o.path == ""
+}
+
+// open returns the writer of the machine-readable output, nil for the text format,
+// and the file it writes to, if any, which the caller must close.
+func (o *outputOptions) open() (*report.Writer, io.Closer, error) {
+	if o.format == report.FormatText {
+		return nil, io.NopCloser(nil), nil
+	}
+	if o.path == "" {
+		return report.NewWriter(os.Stdout, o.format), io.NopCloser(nil), nil
+	}
+	file, err := os.Create(o.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return report.NewWriter(file, o.format), file, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/push"
+)
+
+// pushOptions configures the websocket endpoint the results of the monitor are pushed from.
+type pushOptions struct {
+	addr      string
+	heartbeat time.Duration
+}
+
+func (o *pushOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.addr, "push-addr", "",
+		"Address to push the scans, scores and alerts to websocket clients on at /ws, e.g. :8081, empty to disable")
+	cmd.Flags().DurationVar(&o.heartbeat, "push-heartbeat", push.DefaultHeartbeat,
+		"How often the websocket clients receive a heartbeat when nothing else is pushed")
+}
+
+// validate rejects the heartbeats the clients couldn't be kept alive with.
+func (o *pushOptions) validate() error {
+	if o.heartbeat <= 0 {
+		return errors.New("--push-heartbeat must be positive")
+	}
+	return nil
+}
+
+// serve starts pushing the events, if enabled, returning the hub and the function stopping it.
+func (o *pushOptions) serve() (*push.Hub, func() error, error) {
+	if o.addr == "" {
+		return nil, func() error { return nil }, nil
+	}
+	hub := push.NewHub(push.DefaultBacklog, o.heartbeat)
+	mux := http.NewServeMux()
+	mux.Handle("GET /ws", hub)
+	stop, err := listen(o.addr, mux, "websocket push")
+	if err != nil {
+		return nil, nil, err
+	}
+	return hub, func() error {
+		// the clients are hijacked connections, which the server doesn't close
+		hub.Close()
+		return stop()
+	}, nil
+}
+
+// publishScan pushes the score of every stock scanned and then the stocks meeting the filters.
+func (s *monitorScope) publishScan(started time.Time, all, ranked []*monitor.StockScore) {
+	for _, score := range all {
+		s.push.PublishScore(started, score, slices.Contains(ranked, score))
+	}
+	s.push.PublishScan(started, ranked)
+}
+
+// delivered records an alert being delivered.
+func (s *monitorScope) delivered(n *alert.Notification) {
+	s.metrics.ObserveAlert(n.Rule, n.Severity.String())
+	s.push.PublishAlert(n)
+	s.history.saveAlert(n)
+}
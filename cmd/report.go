@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+	"github.com/spf13/cobra"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+type reportScope struct {
+	p         printer.Printer
+	cfg       *config.Config
+	cfgFile   string
+	ticker    string
+	timeFrame string
+	path      string
+	top       int
+	bars      int
+}
+
+func init() {
+	s := &reportScope{p: &printer.Standard{}}
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Render a scan or the analysis of a single stock as an HTML report",
+		Long: "Render a scan, or the analysis of a single stock when a ticker is given, as a standalone HTML file " +
+			"with a ranking table and candlestick charts.",
+		PreRunE: s.preRunE,
+		RunE:    s.runE,
+	}
+
+	reportCmd.Flags().StringVarP(&s.cfgFile, "config", "c", "", "Path to config file")
+	reportCmd.Flags().StringVarP(&s.ticker, "ticker", "t", "", "Stock ticker to analyse, instead of scanning the market")
+	reportCmd.Flags().StringVarP(&s.timeFrame, "timeframe", "f", "1d", "Time frame to use with --ticker")
+	reportCmd.Flags().StringVar(&s.path, "output-file", "report.html", "Path to the file the report is written to")
+	reportCmd.Flags().IntVarP(&s.top, "top", "n", 10, "How many of the best opportunities of a scan are charted")
+	reportCmd.Flags().IntVar(&s.bars, "bars", 120, "How many of the latest bars are charted, 0 for all of them")
+
+	utilities.Must(reportCmd.MarkFlagRequired("config"))
+	rootCmd.AddCommand(reportCmd)
+}
+
+func (s *reportScope) preRunE(_ *cobra.Command, _ []string) error {
+	if s.top < 0 || s.bars < 0 {
+		return errors.New("--top and --bars cannot be negative")
+	}
+
+	var err error
+	s.cfg, err = cfgOpts.load(s.cfgFile)
+	return err
+}
+
+func (s *reportScope) runE(cmd *cobra.Command, _ []string) (err error) {
+	cli, err := clientOpts.newClient()
+	if err != nil {
+		return err
+	}
+
+	var page *report.Page
+	if s.ticker != "" {
+		page, err = s.analysis(cmd.Context(), cli)
+	} else {
+		page, err = s.scan(cmd.Context(), cli)
+	}
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+	if err = report.WriteHTML(file, page); err != nil {
+		return err
+	}
+	s.p.PrintColored(printer.Green, "Report written to %s\n", s.path)
+	return nil
+}
+
+// scan charts the best opportunities of a scan of the market.
+func (s *reportScope) scan(ctx context.Context, cli api.Client) (*report.Page, error) {
+	scanner := monitor.NewMarketScanner(s.cfg.Strategies, s.cfg.StockUniverse, s.cfg.Filters, cli, s.p)
+	scanner.SetKeepBars(true)
+	started := time.Now()
+	all, err := scanner.ScanAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scores := scanner.Filter(all)
+
+	page := &report.Page{
+		Timestamp: started,
+		Title:     "Market scan",
+		Scores:    report.NewScan(started, scores).Scores,
+	}
+	// chart the very bars the stocks were scored on
+	for _, score := range scores[:min(s.top, len(scores))] {
+		slog.Info("charting stock", "symbol", score.Symbol)
+		data, ok := scanner.Bars(score.Symbol)
+		if !ok {
+			return nil, fmt.Errorf("no data for ticker %s", score.Symbol)
+		}
+		page.Charts = append(page.Charts, report.NewChart(score.Symbol, data, s.bars, s.cfg.Strategies...))
+	}
+	return page, nil
+}
+
+// analysis charts a single stock, with the indicators and the sentiment of its latest bar.
+func (s *reportScope) analysis(ctx context.Context, cli api.Client) (*report.Page, error) {
+	data, err := cli.GetOHLCV(ctx, s.ticker, &carnost.WithTimeframe{TimeFrame: carnost.TimeFrame(s.timeFrame)})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data for ticker %s", s.ticker)
+	}
+
+	return report.NewAnalysisPage(strings.ToUpper(s.ticker), s.timeFrame, data, s.bars, s.cfg.Strategies...), nil
+}
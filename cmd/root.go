@@ -1,24 +1,104 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/spf13/cobra"
 
 	"github.com/CanobbioE/algo-trading/pkg/printer"
 )
 
+// Exit codes returned by Execute.
+const (
+	exitOK    = 0
+	exitError = 1
+	// exitSignal is added to the number of the signal that stopped the command, as shells do.
+	exitSignal = 128
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "algo-trading",
 	Short: "Algo trading main command",
 	Long:  "Algo trading main command",
+	PersistentPreRunE: func(*cobra.Command, []string) error {
+		return logOpts.setup()
+	},
+}
+
+var (
+	clientOpts = &clientOptions{}
+	logOpts    = &logOptions{}
+	cfgOpts    = &configOptions{}
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&clientOpts.recordDir, "record", "",
+		"Record every market data response into the given fixture directory")
+	rootCmd.PersistentFlags().StringVar(&clientOpts.replayDir, "replay", "",
+		"Serve market data from the given fixture directory instead of the live provider")
+	rootCmd.PersistentFlags().StringVar(&clientOpts.syntheticSpec, "synthetic", "",
+		"Serve synthetic market data generated from the given specification file")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay", "synthetic")
+	rootCmd.PersistentFlags().StringVar(&cfgOpts.format, "config-format", "auto",
+		"Format of the config files: auto, json, yaml or toml, auto detects it from the file extension")
+	rootCmd.PersistentFlags().StringVar(&cfgOpts.profile, "profile", "",
+		"Name of the profile of the config files to apply")
+	rootCmd.PersistentFlags().BoolVar(&cfgOpts.strict, "strict-config", false,
+		"Reject the config files with unknown fields, instead of warning about them")
+	logOpts.addFlags(rootCmd)
+}
+
+// signalError is the cause of the cancellation of the command context when a signal is received.
+type signalError struct {
+	signal os.Signal
 }
 
-// Execute the root command.
-func Execute() {
-	err := rootCmd.Execute()
-	p := &printer.Standard{}
+func (e *signalError) Error() string {
+	return "received " + e.signal.String()
+}
+
+// Execute the root command, returning the process exit code.
+// On SIGINT or SIGTERM the command context is cancelled, letting long-running commands shut down gracefully,
+// a second signal terminates the process immediately.
+func Execute() int {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	go func() {
+		cancel(&signalError{signal: <-sigs})
+		os.Exit(exitCode(<-sigs))
+	}()
+
+	err := errors.Join(rootCmd.ExecuteContext(ctx), logOpts.close())
+	// stdout might be carrying the machine-readable output of the command
+	p := printer.NewStandard(os.Stderr)
+
+	var sigErr *signalError
+	if errors.As(context.Cause(ctx), &sigErr) {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			p.PrintColored(printer.Red, err.Error()+"\n")
+		}
+		p.PrintColored(printer.Yellow, "\nINTERRUPTED (%s)\n", sigErr.signal)
+		return exitCode(sigErr.signal)
+	}
 	if err != nil {
 		p.PrintColored(printer.Red, err.Error()+"\n")
-		return
+		return exitError
 	}
 	p.PrintColored(printer.Green, "\nDONE\n")
+	return exitOK
+}
+
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return exitSignal + int(s)
+	}
+	return exitError
 }
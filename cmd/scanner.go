@@ -1,13 +1,14 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"os"
+	"time"
 
-	"github.com/CanobbioE/stock-market-clients/carnost"
 	"github.com/spf13/cobra"
 
 	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/history"
 	"github.com/CanobbioE/algo-trading/pkg/monitor"
 	"github.com/CanobbioE/algo-trading/pkg/printer"
 	"github.com/CanobbioE/algo-trading/pkg/utilities"
@@ -16,38 +17,53 @@ import (
 type scanScope struct {
 	p       printer.Printer
 	cfg     *config.Config
+	history *historyRecorder
+	output  *outputOptions
 	cfgFile string
 }
 
 func (s *scanScope) preRunE(_ *cobra.Command, _ []string) error {
-	file, err := os.Open(s.cfgFile)
-	if err != nil {
+	if err := s.output.parse(); err != nil {
 		return err
 	}
-	defer func() {
-		_ = file.Close()
-	}()
+	if s.output.stderr() {
+		s.p = printer.NewStandard(os.Stderr)
+	}
 
-	decoder := json.NewDecoder(file)
-	var cfg config.Config
-	err = decoder.Decode(&cfg)
-	if err != nil {
+	var err error
+	if s.cfg, err = cfgOpts.load(s.cfgFile); err != nil {
 		return err
 	}
-
-	s.cfg = &cfg
-	return nil
+	s.history.configHash, err = cfgOpts.fingerprint(s.cfgFile)
+	return err
 }
 
-func (s *scanScope) runE(cmd *cobra.Command, _ []string) error {
-	scanner := monitor.NewMarketScanner(s.cfg.Strategies, s.cfg.StockUniverse, s.cfg.Filters, carnost.NewClient(), s.p)
+func (s *scanScope) runE(cmd *cobra.Command, _ []string) (err error) {
+	cli, err := clientOpts.newClient()
+	if err != nil {
+		return err
+	}
+	writer, closer, err := s.output.open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, closer.Close())
+	}()
+	scanner := monitor.NewMarketScanner(s.cfg.Strategies, s.cfg.StockUniverse, s.cfg.Filters, cli, s.p)
 
 	s.p.Printf("=== ONE-TIME MARKET SCAN ===\n")
-	scores, err := scanner.ScanMarket(cmd.Context())
+	started := time.Now()
+	all, err := scanner.ScanAll(cmd.Context())
 	if err != nil {
 		return err
 	}
+	scores := scanner.Filter(all)
+	s.history.save(started, all, scores)
 
+	if writer != nil {
+		return writer.WriteScan(started, scores)
+	}
 	// todo make 10 configurable
 	scanner.GenerateReport(scores, 10)
 	return nil
@@ -55,7 +71,9 @@ func (s *scanScope) runE(cmd *cobra.Command, _ []string) error {
 
 func init() {
 	s := &scanScope{
-		p: &printer.Standard{},
+		p:       &printer.Standard{},
+		history: &historyRecorder{command: "scan"},
+		output:  &outputOptions{},
 	}
 	scanCmd := &cobra.Command{
 		Use:     "scan",
@@ -66,6 +84,10 @@ func init() {
 	}
 
 	scanCmd.Flags().StringVarP(&s.cfgFile, "config", "c", "", "Path to config file")
+	scanCmd.Flags().StringVar(&s.history.path, "history-db", history.DefaultPath(),
+		"Path to the database the scan history is saved to, empty to disable")
+
+	s.output.addFlags(scanCmd)
 
 	utilities.Must(scanCmd.MarkFlagRequired("config"))
 	rootCmd.AddCommand(scanCmd)
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/schedule"
+)
+
+// scheduledMonitor is a monitor job, with its own configuration and state.
+type scheduledMonitor struct {
+	*monitorScope
+	job *schedule.Job
+}
+
+// loadSchedule reads the schedule file and the configuration of every job.
+func (s *monitorScope) loadSchedule() error {
+	file, err := os.Open(s.scheduleFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var cfg schedule.Config
+	if err = config.Decode(file, config.FormatOf(s.scheduleFile), &cfg); err != nil {
+		return fmt.Errorf("failed to decode schedule: %w", err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	for _, jobCfg := range cfg.Jobs {
+		m := &monitorScope{
+			p:          s.p,
+			history:    &historyRecorder{command: s.history.command, path: s.history.path},
+			cfgFile:    jobCfg.Config,
+			diffFormat: s.diffFormat,
+		}
+		if err = m.load(); err != nil {
+			return fmt.Errorf("failed to load the configuration of job %q: %w", jobCfg.Name, err)
+		}
+
+		loc, err := cfg.Location(jobCfg)
+		if err != nil {
+			return err
+		}
+		exchange, err := m.exchange(jobCfg)
+		if err != nil {
+			return err
+		}
+		sched, err := schedule.Parse(jobCfg.Schedule, loc, exchange)
+		if err != nil {
+			return fmt.Errorf("job %q: %w", jobCfg.Name, err)
+		}
+
+		job := &schedule.Job{Schedule: sched, Name: jobCfg.Name, Missed: cfg.MissedPolicy(jobCfg)}
+		if _, err = m.history.loadState(lastRunState(job), &job.LastRun); err != nil {
+			return fmt.Errorf("failed to load the last run of job %q: %w", jobCfg.Name, err)
+		}
+		s.jobs = append(s.jobs, &scheduledMonitor{monitorScope: m, job: job})
+	}
+	return nil
+}
+
+// exchange returns the exchange the @open and @close schedules of the job refer to, if any.
+func (s *monitorScope) exchange(jobCfg *schedule.JobConfig) (*calendar.Exchange, error) {
+	cal := s.calendar
+	if cal == nil {
+		var err error
+		if cal, err = calendar.Default(); err != nil {
+			return nil, fmt.Errorf("failed to load trading calendar: %w", err)
+		}
+	}
+	if jobCfg.Exchange != "" {
+		exchange, ok := cal.Exchange(jobCfg.Exchange)
+		if !ok {
+			return nil, fmt.Errorf("job %q: unknown exchange %q", jobCfg.Name, jobCfg.Exchange)
+		}
+		return exchange, nil
+	}
+	if len(s.cfg.StockUniverse) == 0 {
+		return nil, nil
+	}
+	exchange, _ := cal.ForSymbol(s.cfg.StockUniverse[0])
+	return exchange, nil
+}
+
+// runScheduled runs every job on its schedule, until interrupted or the lifespan elapses.
+func (s *monitorScope) runScheduled(ctx context.Context, cli api.Client) error {
+	ctx, cancel := withLifespan(ctx, s.lifespan)
+	defer cancel()
+
+	jobs := make([]*schedule.Job, 0, len(s.jobs))
+	for _, m := range s.jobs {
+		m.writer = s.writer
+		m.metrics = s.metrics
+		m.push = s.push
+		scanner := m.newScanner(cli)
+		m.job.Run = func(ctx context.Context) error {
+			err := m.scan(ctx, scanner)
+			if ctx.Err() == nil {
+				m.history.saveState(lastRunState(m.job), m.job.LastRun)
+			}
+			return err
+		}
+		jobs = append(jobs, m.job)
+	}
+
+	slog.Info("starting scheduled market monitoring", "jobs", len(jobs))
+	started := time.Now()
+	summaries := schedule.NewScheduler(s.p, jobs...).Run(ctx)
+
+	var runs, failures, sent int
+	s.p.PrintColored(printer.Blue, "\n=== SESSION SUMMARY ===\n")
+	s.p.Printf("Duration: %s\n", time.Since(started).Round(time.Second))
+	for i, summary := range summaries {
+		s.jobs[i].shutdown()
+		s.p.Printf("%s: %d runs (%d failed, %d missed), %d alerts raised\n",
+			summary.Name, summary.Runs, summary.Failures, summary.Missed, s.jobs[i].sent)
+		runs += summary.Runs
+		failures += summary.Failures
+		sent += s.jobs[i].sent
+	}
+	s.p.Printf("Alerts raised: %d\n", sent)
+	if runs > 0 && failures == runs {
+		return fmt.Errorf("all %d scheduled runs failed", runs)
+	}
+	return nil
+}
+
+// printUpcoming prints the next runs of every job.
+func (s *monitorScope) printUpcoming() {
+	now := time.Now()
+	for _, m := range s.jobs {
+		s.p.PrintColored(printer.Blue, "%s (%s):\n", m.job.Name, m.job.Schedule)
+		runs := m.job.Upcoming(now, s.next)
+		if len(runs) == 0 {
+			s.p.Println("  never")
+		}
+		for _, run := range runs {
+			s.p.Printf("  %s\n", run.Local().Format("Mon "+time.DateTime+" MST"))
+		}
+	}
+}
+
+func lastRunState(job *schedule.Job) string {
+	return "schedule:" + job.Name
+}
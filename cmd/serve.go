@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/history"
+	"github.com/CanobbioE/algo-trading/pkg/rpc"
+	"github.com/CanobbioE/algo-trading/pkg/server"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+// serveShutdownTimeout is how long the requests in progress have to complete when the server stops.
+const serveShutdownTimeout = 30 * time.Second
+
+type serveScope struct {
+	cfg         *config.Config
+	cfgFile     string
+	addr        string
+	grpcAddr    string
+	historyPath string
+	configsDir  string
+	ui          bool
+}
+
+func (s *serveScope) preRunE(_ *cobra.Command, _ []string) error {
+	var err error
+	if s.cfg, err = cfgOpts.load(s.cfgFile); err != nil {
+		return err
+	}
+	if s.addr == "" && s.grpcAddr == "" {
+		return errors.New("nothing to serve, set --addr or --grpc-addr")
+	}
+	if s.ui && s.addr == "" {
+		return errors.New("--ui needs the HTTP API, set --addr")
+	}
+	if s.configsDir == "" {
+		s.configsDir = filepath.Dir(s.cfgFile)
+	}
+	return nil
+}
+
+func (s *serveScope) runE(cmd *cobra.Command, _ []string) (err error) {
+	cli, err := clientOpts.newClient()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	errs := make(chan error, 2)
+
+	stopHTTP, err := s.serveHTTP(ctx, cli, errs)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, stopHTTP())
+	}()
+	stopGRPC, err := s.serveGRPC(cli, errs)
+	if err != nil {
+		return err
+	}
+	defer stopGRPC()
+
+	select {
+	case err = <-errs:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// serveHTTP serves the HTTP API in the background, if enabled, sending to errs why it stopped unless stopped,
+// and returns the function stopping it.
+func (s *serveScope) serveHTTP(ctx context.Context, cli api.Client, errs chan<- error) (func() error, error) {
+	if s.addr == "" {
+		return func() error { return nil }, nil
+	}
+	var store *history.Store
+	if s.historyPath != "" {
+		store = history.NewStore(s.historyPath)
+	}
+	srv, err := server.New(cli, s.cfg, store)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	srv.SetStrict(cfgOpts.strict)
+	if s.ui {
+		srv.EnableUI()
+		srv.SetConfigs(os.DirFS(s.configsDir))
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	httpServer := &http.Server{
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		// let the scans in progress be cancelled when the command stops
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+	slog.Info("serving the API", "addr", listener.Addr().String(), "ui", s.ui)
+
+	return func() error {
+		slog.Info("stopping the API server")
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to stop the API server: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+// serveGRPC serves the gRPC service in the background, if enabled, sending to errs why it stopped unless stopped,
+// and returns the function stopping it.
+func (s *serveScope) serveGRPC(cli api.Client, errs chan<- error) (func(), error) {
+	if s.grpcAddr == "" {
+		return func() {}, nil
+	}
+	// the strategies keep their state, so the gRPC service runs its own rather than the ones of the HTTP API,
+	// which would otherwise be run concurrently by the two servers
+	cfg := s.cfg
+	if s.addr != "" {
+		var err error
+		if cfg, err = cfgOpts.load(s.cfgFile); err != nil {
+			return nil, err
+		}
+	}
+	srv, err := rpc.New(cli, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", s.grpcAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for gRPC: %w", err)
+	}
+	grpcServer := grpc.NewServer()
+	srv.Register(grpcServer)
+	// let tools like grpcurl discover the service
+	reflection.Register(grpcServer)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			errs <- err
+		}
+	}()
+	slog.Info("serving the gRPC service", "addr", listener.Addr().String())
+
+	return func() {
+		slog.Info("stopping the gRPC server")
+		srv.Close()
+		timer := time.AfterFunc(serveShutdownTimeout, grpcServer.Stop)
+		defer timer.Stop()
+		grpcServer.GracefulStop()
+	}, nil
+}
+
+func init() {
+	s := &serveScope{}
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the scans and the analyses over an HTTP API and gRPC",
+		Long: "Serve the scans, the analyses and the scan history over an HTTP API, optionally with a web UI, " +
+			"and the scanning engine over gRPC, until interrupted.",
+		PreRunE: s.preRunE,
+		RunE:    s.runE,
+	}
+
+	serveCmd.Flags().StringVarP(&s.cfgFile, "config", "c", "",
+		"Path to the config file of the analyses and of the scans posted without one")
+	serveCmd.Flags().StringVar(&s.addr, "addr", ":8080", "Address to serve the HTTP API on, empty to disable")
+	serveCmd.Flags().StringVar(&s.grpcAddr, "grpc-addr", "",
+		"Address to serve the gRPC service on, e.g. :9000, empty to disable")
+	serveCmd.Flags().StringVar(&s.historyPath, "history-db", history.DefaultPath(),
+		"Path to the database the scan history and the alerts are read from, empty to disable")
+	serveCmd.Flags().BoolVar(&s.ui, "ui", false,
+		"Serve a web UI at / to edit configurations, run scans, chart tickers and browse the alerts")
+	serveCmd.Flags().StringVar(&s.configsDir, "configs-dir", "",
+		"Directory of the config files the web UI offers, the one of --config if empty")
+
+	utilities.Must(serveCmd.MarkFlagRequired("config"))
+	rootCmd.AddCommand(serveCmd)
+}
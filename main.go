@@ -1,7 +1,11 @@
 package main
 
-import "github.com/CanobbioE/algo-trading/cmd"
+import (
+	"os"
+
+	"github.com/CanobbioE/algo-trading/cmd"
+)
 
 func main() {
-	cmd.Execute()
+	os.Exit(cmd.Execute())
 }
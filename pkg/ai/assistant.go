@@ -3,6 +3,8 @@ package ai
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"google.golang.org/genai"
 )
@@ -34,9 +36,13 @@ type Config struct {
 	APIKey string `json:"api_key"`
 }
 
+// model is the Gemini model the assistant asks.
+const model = "gemini-2.5-pro"
+
 // Assistant uses Gemini API to assist with stock picking.
 type Assistant struct {
-	cli *genai.Client
+	cli    *genai.Client
+	logger *slog.Logger
 }
 
 // NewAssistant creates a new AI Assistant using Gemini, with the given config.
@@ -49,7 +55,12 @@ func NewAssistant(ctx context.Context, cfg *Config) (*Assistant, error) {
 		return nil, err
 	}
 
-	return &Assistant{cli: client}, nil
+	return &Assistant{cli: client, logger: slog.Default()}, nil
+}
+
+// SetLogger replaces the logger of the diagnostics, slog.Default() unless set.
+func (a *Assistant) SetLogger(l *slog.Logger) {
+	a.logger = l
 }
 
 // Analyse calls the underlying Gemini client and asks it to run a technical analysis on the given stock,
@@ -74,11 +85,18 @@ func (a *Assistant) Analyse(ctx context.Context, input, risk, ticker string) (st
 		{Text: "Input:\n" + input},
 	}
 
+	a.logger.Debug("asking the assistant", "model", model, "ticker", ticker, "risk", risk, "input_bytes", len(input))
+	started := time.Now()
 	// TODO: consider caching
-	resp, err := a.cli.Models.GenerateContent(ctx, "gemini-2.5-pro", []*genai.Content{{Parts: parts}}, modelCfg)
+	resp, err := a.cli.Models.GenerateContent(ctx, model, []*genai.Content{{Parts: parts}}, modelCfg)
 	if err != nil {
 		return "", fmt.Errorf("ai assistant: failed to generate content: %w", err)
 	}
 
+	attrs := []any{"model", model, "ticker", ticker, "duration", time.Since(started)}
+	if resp.UsageMetadata != nil {
+		attrs = append(attrs, "tokens", resp.UsageMetadata.TotalTokenCount)
+	}
+	a.logger.Info("assistant answered", attrs...)
 	return resp.Text(), nil
 }
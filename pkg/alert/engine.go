@@ -0,0 +1,64 @@
+package alert
+
+import (
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// Match groups the stocks a rule fired for.
+type Match struct {
+	Rule   *Rule
+	Scores []*monitor.StockScore
+}
+
+// Engine evaluates alert rules against consecutive scans.
+type Engine struct {
+	previous map[string]*monitor.StockScore
+	filters  *monitor.ScanFilters
+	rules    []*Rule
+}
+
+// NewEngine creates a new Engine for the given rules, using the DefaultRule if there are none.
+// The filters tell the rules restricted to the stocks meeting the scan criteria which ones do,
+// nil meaning every stock does.
+func NewEngine(filters *monitor.ScanFilters, rules ...*Rule) *Engine {
+	if len(rules) == 0 {
+		rules = []*Rule{DefaultRule()}
+	}
+	return &Engine{
+		previous: make(map[string]*monitor.StockScore),
+		filters:  filters,
+		rules:    rules,
+	}
+}
+
+// Rules returns the rules evaluated by the engine.
+func (e *Engine) Rules() []*Rule {
+	return e.rules
+}
+
+// Evaluate returns the rules matched by the given scores, in the order the rules are defined,
+// then remembers the scores to compare them with the next evaluation.
+// The scores should be every stock scanned, not only the ones meeting the scan criteria,
+// so that price crossings and score changes are measured against the latest scan of each stock.
+func (e *Engine) Evaluate(scores []*monitor.StockScore) []*Match {
+	var matches []*Match
+	for _, rule := range e.rules {
+		var matched []*monitor.StockScore
+		for _, score := range scores {
+			if rule.MeetsCriteria && e.filters != nil && !e.filters.Match(score) {
+				continue
+			}
+			if rule.Match(score, e.previous[score.Symbol]) {
+				matched = append(matched, score)
+			}
+		}
+		if len(matched) > 0 {
+			matches = append(matches, &Match{Rule: rule, Scores: matched})
+		}
+	}
+
+	for _, score := range scores {
+		e.previous[score.Symbol] = score
+	}
+	return matches
+}
@@ -0,0 +1,138 @@
+package alert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+func mustRules(t *testing.T, data string) []*alert.Rule {
+	t.Helper()
+	var rules []*alert.Rule
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		t.Fatal(err)
+	}
+	return rules
+}
+
+func score(symbol string, price, weightedScore float64) *monitor.StockScore {
+	s := testScore()
+	s.Symbol, s.LastPrice, s.WeightedScore = symbol, price, weightedScore
+	return s
+}
+
+// matched returns the names of the matched rules with the symbols they fired for.
+func matched(matches []*alert.Match) map[string][]string {
+	got := make(map[string][]string)
+	for _, m := range matches {
+		for _, s := range m.Scores {
+			got[m.Rule.Name] = append(got[m.Rule.Name], s.Symbol)
+		}
+	}
+	return got
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	rules := mustRules(t, `[
+		{"name": "macd", "condition": "MACD == buy && Volume > 1e6", "severity": "critical"},
+		{"name": "eni above 15", "symbols": ["eni.mta"], "crosses_above": 15},
+		{"name": "below 10", "crosses_below": 10},
+		{"name": "score jump", "score_change": 1},
+		{"name": "score drop", "score_change": -1, "condition": "Risk <= medium"}
+	]`)
+	e := alert.NewEngine(nil, rules...)
+
+	for i, tc := range []struct {
+		want   map[string][]string
+		scores []*monitor.StockScore
+	}{
+		{
+			// no previous scan: only stateless conditions can fire
+			scores: []*monitor.StockScore{score("ENI.MTA", 14.5, 2), score("UCG.MTA", 10.5, 2)},
+			want:   map[string][]string{"macd": {"ENI.MTA", "UCG.MTA"}},
+		},
+		{
+			scores: []*monitor.StockScore{score("ENI.MTA", 15, 3.1), score("UCG.MTA", 10, 0.5)},
+			want: map[string][]string{
+				"macd":         {"ENI.MTA", "UCG.MTA"},
+				"eni above 15": {"ENI.MTA"},
+				"below 10":     {"UCG.MTA"},
+				"score jump":   {"ENI.MTA"},
+				"score drop":   {"UCG.MTA"},
+			},
+		},
+		{
+			// the levels were already crossed
+			scores: []*monitor.StockScore{score("ENI.MTA", 15.2, 3.5), score("UCG.MTA", 9, 0.4)},
+			want:   map[string][]string{"macd": {"ENI.MTA", "UCG.MTA"}},
+		},
+		{
+			scores: []*monitor.StockScore{score("ENI.MTA", 14, 2), score("UCG.MTA", 16, 0.4)},
+			want:   map[string][]string{"macd": {"ENI.MTA", "UCG.MTA"}, "score drop": {"ENI.MTA"}},
+		},
+	} {
+		got := matched(e.Evaluate(tc.scores))
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("scan %d: matched rules mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestEngine_Evaluate_MeetsCriteria(t *testing.T) {
+	rules := mustRules(t, `[
+		{"name": "stop loss", "crosses_below": 10},
+		{"name": "filtered stop loss", "crosses_below": 10, "meets_criteria": true}
+	]`)
+	e := alert.NewEngine(&monitor.ScanFilters{MinWeightedScore: 1, MaxRisk: monitor.RiskHigh}, rules...)
+
+	e.Evaluate([]*monitor.StockScore{score("ENI.MTA", 11, 2), score("UCG.MTA", 11, 2)})
+	// UCG.MTA drops out of the filters as it crosses the level
+	got := matched(e.Evaluate([]*monitor.StockScore{score("ENI.MTA", 9, 2), score("UCG.MTA", 9, 0)}))
+	want := map[string][]string{"stop loss": {"ENI.MTA", "UCG.MTA"}, "filtered stop loss": {"ENI.MTA"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("matched rules mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewEngine_DefaultRule(t *testing.T) {
+	low := score("LOW.MTA", 10, 1)
+	low.Opportunity = monitor.OpportunityLow
+	risky := score("RISKY.MTA", 10, 1)
+	risky.Risk = monitor.RiskHigh
+	filtered := score("FILTERED.MTA", 10, 0.5)
+
+	matches := alert.NewEngine(&monitor.ScanFilters{MinWeightedScore: 1, MaxRisk: monitor.RiskHigh}).
+		Evaluate([]*monitor.StockScore{score("ENI.MTA", 10, 1), low, risky, filtered})
+	want := map[string][]string{"high opportunity": {"ENI.MTA"}}
+	if diff := cmp.Diff(want, matched(matches)); diff != "" {
+		t.Errorf("matched rules mismatch (-want +got):\n%s", diff)
+	}
+	if matches[0].Rule.Severity != alert.SeverityInfo {
+		t.Errorf("expected default severity INFO, instead got %s", matches[0].Rule.Severity)
+	}
+}
+
+func TestRule_UnmarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		data    string
+		wantErr string
+	}{
+		{data: `{"condition": "Volume > 1"}`, wantErr: "alert rule without name"},
+		{data: `{"name": "empty"}`, wantErr: `alert rule "empty": at least one of condition`},
+		{data: `{"name": "bad", "condition": "Volume >"}`, wantErr: `alert rule "bad": invalid condition`},
+		{data: `{"name": "sev", "condition": "true", "severity": "panic"}`, wantErr: "invalid severity panic"},
+	} {
+		t.Run(tc.data, func(t *testing.T) {
+			var r alert.Rule
+			err := json.Unmarshal([]byte(tc.data), &r)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,457 @@
+package alert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+// kind is the static type of an expression.
+type kind int
+
+const (
+	kindBool kind = iota
+	kindNumber
+	kindText
+	kindLevel
+	kindOperation
+)
+
+func (k kind) String() string {
+	return [...]string{"boolean", "number", "text", "level", "operation"}[k]
+}
+
+// value is the result of evaluating an expression, only the field matching its kind is set.
+type value struct {
+	str string
+	num float64
+	b   bool
+}
+
+type node interface {
+	kind() kind
+	eval(score *monitor.StockScore) value
+}
+
+// variable reads a StockScore field.
+type variable struct {
+	get func(score *monitor.StockScore) value
+	k   kind
+}
+
+func (v *variable) kind() kind                           { return v.k }
+func (v *variable) eval(score *monitor.StockScore) value { return v.get(score) }
+
+type literal struct {
+	v value
+	k kind
+}
+
+func (l *literal) kind() kind                     { return l.k }
+func (l *literal) eval(*monitor.StockScore) value { return l.v }
+
+type not struct {
+	operand node
+}
+
+func (*not) kind() kind                             { return kindBool }
+func (n *not) eval(score *monitor.StockScore) value { return value{b: !n.operand.eval(score).b} }
+
+type negate struct {
+	operand node
+}
+
+func (*negate) kind() kind                             { return kindNumber }
+func (n *negate) eval(score *monitor.StockScore) value { return value{num: -n.operand.eval(score).num} }
+
+type binary struct {
+	left, right node
+	op          string
+}
+
+func (*binary) kind() kind { return kindBool }
+
+func (b *binary) eval(score *monitor.StockScore) value {
+	switch b.op {
+	case "&&":
+		return value{b: b.left.eval(score).b && b.right.eval(score).b}
+	case "||":
+		return value{b: b.left.eval(score).b || b.right.eval(score).b}
+	}
+
+	l, r := b.left.eval(score), b.right.eval(score)
+	var cmp int
+	switch b.left.kind() {
+	case kindBool:
+		if l.b != r.b {
+			cmp = 1
+		}
+	case kindText, kindOperation:
+		if !strings.EqualFold(l.str, r.str) {
+			cmp = 1
+		}
+	default:
+		switch {
+		case l.num < r.num:
+			cmp = -1
+		case l.num > r.num:
+			cmp = 1
+		}
+	}
+
+	switch b.op {
+	case "==":
+		return value{b: cmp == 0}
+	case "!=":
+		return value{b: cmp != 0}
+	case "<":
+		return value{b: cmp < 0}
+	case "<=":
+		return value{b: cmp <= 0}
+	case ">":
+		return value{b: cmp > 0}
+	default:
+		return value{b: cmp >= 0}
+	}
+}
+
+func number(f func(score *monitor.StockScore) float64) *variable {
+	return &variable{k: kindNumber, get: func(score *monitor.StockScore) value { return value{num: f(score)} }}
+}
+
+// variables are the identifiers an expression can read from a StockScore, in upper case.
+var variables = map[string]*variable{
+	"SYMBOL": {k: kindText, get: func(score *monitor.StockScore) value { return value{str: score.Symbol} }},
+	"PRICE":  number(func(score *monitor.StockScore) float64 { return score.LastPrice }),
+	"VOLUME": number(func(score *monitor.StockScore) float64 { return score.Volume }),
+	"CONFIDENCE": number(func(score *monitor.StockScore) float64 {
+		return score.Confidence
+	}),
+	"SCORE":        number(func(score *monitor.StockScore) float64 { return score.WeightedScore }),
+	"BUYSIGNALS":   number(func(score *monitor.StockScore) float64 { return float64(score.BuySignals) }),
+	"SELLSIGNALS":  number(func(score *monitor.StockScore) float64 { return float64(score.SellSignals) }),
+	"HOLDSIGNALS":  number(func(score *monitor.StockScore) float64 { return float64(score.HoldSignals) }),
+	"SETUPSIGNALS": number(func(score *monitor.StockScore) float64 { return float64(score.SetupSignals) }),
+	"RISK": {k: kindLevel, get: func(score *monitor.StockScore) value {
+		return value{num: float64(score.Risk)}
+	}},
+	"OPPORTUNITY": {k: kindLevel, get: func(score *monitor.StockScore) value {
+		return value{num: float64(score.Opportunity)}
+	}},
+}
+
+// aliases are alternative names for variables, matching the StockScore field names.
+var aliases = map[string]string{
+	"LASTPRICE":     "PRICE",
+	"WEIGHTEDSCORE": "SCORE",
+}
+
+// strategyNames are the strategies whose last signal can be read, as returned by strategies.StrategyWeight.Name.
+var strategyNames = []string{"BREAKOUT", "VWAP", "MEANREVERSION", "BOLLINGER", "MACD", "MOMENTUM"}
+
+func strategyVariable(name string) *variable {
+	return &variable{k: kindOperation, get: func(score *monitor.StockScore) value {
+		op, ok := score.Signals[name]
+		if !ok {
+			op = signals.NoOp
+		}
+		return value{str: string(op)}
+	}}
+}
+
+var literals = map[string]*literal{
+	"TRUE":   {k: kindBool, v: value{b: true}},
+	"FALSE":  {k: kindBool, v: value{b: false}},
+	"LOW":    {k: kindLevel, v: value{num: float64(monitor.RiskLow)}},
+	"MEDIUM": {k: kindLevel, v: value{num: float64(monitor.RiskMedium)}},
+	"HIGH":   {k: kindLevel, v: value{num: float64(monitor.RiskHigh)}},
+	"BUY":    {k: kindOperation, v: value{str: string(signals.Buy)}},
+	"SELL":   {k: kindOperation, v: value{str: string(signals.Sell)}},
+	"SETUP":  {k: kindOperation, v: value{str: string(signals.Setup)}},
+	"NOOP":   {k: kindOperation, v: value{str: string(signals.NoOp)}},
+	"HOLD":   {k: kindOperation, v: value{str: string(signals.NoOp)}},
+}
+
+// Expression is a compiled boolean condition over a StockScore.
+type Expression struct {
+	root   node
+	source string
+}
+
+// Compile parses a condition such as `MACD == buy && Volume > 1e6 && Confidence > 0.6`.
+// Identifiers are case-insensitive and are either StockScore fields (Symbol, Price, Volume, Confidence,
+// Score, BuySignals, SellSignals, HoldSignals, SetupSignals, Risk, Opportunity), strategy names compared
+// against buy, sell, setup, noop or hold, or the low, medium and high levels compared against Risk and
+// Opportunity. Numbers can be negated with a leading -, as in `Score < -1`. Conditions are combined with
+// &&, || and !, and grouped with parentheses.
+func Compile(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+	if root.kind() != kindBool {
+		return nil, fmt.Errorf("expression must be a condition, instead it is a %s", root.kind())
+	}
+	return &Expression{root: root, source: source}, nil
+}
+
+// Match reports whether the score satisfies the expression.
+func (e *Expression) Match(score *monitor.StockScore) bool {
+	return e.root.eval(score).b
+}
+
+// String returns the expression source.
+func (e *Expression) String() string {
+	return e.source
+}
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	text string
+	typ  tokenType
+	pos  int
+}
+
+var operators = []string{"&&", "||", "==", "!=", ">=", "<=", ">", "<", "!", "-", "(", ")"}
+
+func tokenize(source string) ([]*token, error) {
+	var tokens []*token
+	for i := 0; i < len(source); {
+		r := rune(source[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := strings.IndexByte(source[i+1:], source[i])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, &token{typ: tokenString, text: source[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case unicode.IsDigit(r) || r == '.':
+			j := i + 1
+			for j < len(source) && isNumberChar(source[j-1], source[j]) {
+				j++
+			}
+			tokens = append(tokens, &token{typ: tokenNumber, text: source[i:j], pos: i})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(source) && isIdentChar(source[j]) {
+				j++
+			}
+			tokens = append(tokens, &token{typ: tokenIdent, text: source[i:j], pos: i})
+			i = j
+		default:
+			var found bool
+			for _, op := range operators {
+				if strings.HasPrefix(source[i:], op) {
+					tokens = append(tokens, &token{typ: tokenOperator, text: op, pos: i})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+		}
+	}
+	return append(tokens, &token{typ: tokenEOF, text: "end of expression", pos: len(source)}), nil
+}
+
+// isNumberChar reports whether c continues a number, allowing a sign right after the exponent.
+func isNumberChar(prev, c byte) bool {
+	switch c {
+	case '.', 'e', 'E':
+		return true
+	case '-', '+':
+		return prev == 'e' || prev == 'E'
+	default:
+		return c >= '0' && c <= '9'
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+type parser struct {
+	tokens []*token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() *token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.typ != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) or() (node, error) {
+	return p.logical("||", p.and)
+}
+
+func (p *parser) and() (node, error) {
+	return p.logical("&&", p.unary)
+}
+
+func (p *parser) logical(op string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		pos := p.peek().pos
+		if _, ok := p.accept(op); !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.kind() != kindBool || right.kind() != kindBool {
+			return nil, fmt.Errorf("%s at position %d requires two conditions", op, pos)
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	pos := p.peek().pos
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if operand.kind() != kindBool {
+			return nil, fmt.Errorf("! at position %d requires a condition", pos)
+		}
+		return &not{operand: operand}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	pos := p.peek().pos
+	op, ok := p.accept("==", "!=", ">=", "<=", ">", "<")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	if left.kind() != right.kind() {
+		return nil, fmt.Errorf("cannot compare %s with %s at position %d", left.kind(), right.kind(), pos)
+	}
+	switch left.kind() {
+	case kindBool, kindText, kindOperation:
+		if op != "==" && op != "!=" {
+			return nil, fmt.Errorf("%s values at position %d can only be compared with == or !=", left.kind(), pos)
+		}
+	}
+	return &binary{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) operand() (node, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &literal{k: kindNumber, v: value{num: f}}, nil
+	case tokenString:
+		return &literal{k: kindText, v: value{str: t.text}}, nil
+	case tokenIdent:
+		return resolve(t)
+	case tokenOperator:
+		if t.text == "-" {
+			operand, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			if operand.kind() != kindNumber {
+				return nil, fmt.Errorf("- at position %d requires a number", t.pos)
+			}
+			return &negate{operand: operand}, nil
+		}
+		if t.text == "(" {
+			n, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("missing ) at position %d", p.peek().pos)
+			}
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+func resolve(t *token) (node, error) {
+	name := strings.ToUpper(t.text)
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	if v, ok := variables[name]; ok {
+		return v, nil
+	}
+	if l, ok := literals[name]; ok {
+		return l, nil
+	}
+	for _, strategy := range strategyNames {
+		if name == strategy {
+			return strategyVariable(strategy), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown identifier %q at position %d", t.text, t.pos)
+}
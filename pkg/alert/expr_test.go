@@ -0,0 +1,95 @@
+package alert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+func testScore() *monitor.StockScore {
+	return &monitor.StockScore{
+		Signals: map[string]signals.Operation{
+			"MACD":     signals.Buy,
+			"VWAP":     signals.NoOp,
+			"MOMENTUM": signals.Setup,
+		},
+		Symbol:        "ENI.MTA",
+		LastPrice:     14.52,
+		Volume:        1250000,
+		BuySignals:    1,
+		HoldSignals:   1,
+		SetupSignals:  1,
+		Confidence:    0.65,
+		WeightedScore: 2.4,
+		Risk:          monitor.RiskMedium,
+		Opportunity:   monitor.OpportunityHigh,
+	}
+}
+
+func TestExpression_Match(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		want bool
+	}{
+		{expr: "MACD == buy && Volume > 1e6 && Confidence > 0.6", want: true},
+		{expr: "macd == BUY && volume > 2e6", want: false},
+		{expr: "VWAP == hold && VWAP == noop", want: true},
+		{expr: "BREAKOUT == noop", want: true},
+		{expr: "MOMENTUM != setup || Price < 10", want: false},
+		{expr: "!(Risk > medium) && Opportunity >= high", want: true},
+		{expr: "Risk < medium", want: false},
+		{expr: "Symbol == 'eni.mta' && LastPrice >= 14.52", want: true},
+		{expr: `Symbol != "ENI.MTA"`, want: false},
+		{expr: "WeightedScore > 2 && Score <= 2.4 && BuySignals == 1 && SellSignals == 0", want: true},
+		{expr: "true && !false", want: true},
+		{expr: "Volume > 1.5e+6 || Volume < 1.3E6 && Confidence > 0.9", want: false},
+		{expr: "Score > -1 && !(Score<-1)", want: true},
+		{expr: "-Score < -2.4", want: false},
+		{expr: "-Score <= -2.4 && -(Confidence) > -1 && --1 == 1", want: true},
+		{expr: "SellSignals > -1e-3", want: true},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := alert.Compile(tc.expr)
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if got := e.Match(testScore()); got != tc.want {
+				t.Errorf("expected %v, instead got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "", wantErr: `unexpected "end of expression" at position 0`},
+		{expr: "Volme > 1e6", wantErr: `unknown identifier "Volme" at position 0`},
+		{expr: "Volume > 1e6 +", wantErr: `unexpected '+' at position 13`},
+		{expr: "Volume", wantErr: "expression must be a condition, instead it is a number"},
+		{expr: "MACD > buy", wantErr: "operation values at position 5 can only be compared with == or !="},
+		{expr: "MACD == 1", wantErr: "cannot compare operation with number at position 5"},
+		{expr: "Risk <= 'LOW'", wantErr: "cannot compare level with text at position 5"},
+		{expr: "Volume && true", wantErr: "&& at position 7 requires two conditions"},
+		{expr: "!Volume", wantErr: "! at position 0 requires a condition"},
+		{expr: "(Volume > 1", wantErr: "missing ) at position 11"},
+		{expr: "Volume > 1)", wantErr: `unexpected ")" at position 10`},
+		{expr: "Symbol == 'ENI", wantErr: "unterminated string at position 10"},
+		{expr: "Volume > 1..2", wantErr: `invalid number "1..2" at position 9`},
+		{expr: "Volume > -", wantErr: `unexpected "end of expression" at position 10`},
+		{expr: "MACD == -buy", wantErr: "- at position 8 requires a number"},
+		{expr: "-(Volume > 1)", wantErr: "- at position 0 requires a number"},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := alert.Compile(tc.expr)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Clock is a time of the day.
+type Clock struct {
+	Hour   int
+	Minute int
+}
+
+// ParseClock parses a time of the day in the 15:04 format.
+func ParseClock(s string) (Clock, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return Clock{}, fmt.Errorf("invalid time of the day %q, expected HH:MM", s)
+	}
+	return Clock{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// String returns the clock in the 15:04 format.
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", c.Hour, c.Minute)
+}
+
+// On returns the instant of the clock on the day of t, in the location of t.
+func (c Clock) On(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), c.Hour, c.Minute, 0, 0, t.Location())
+}
+
+func (c Clock) minutes() int {
+	return c.Hour*60 + c.Minute
+}
+
+// QuietHours is a daily time range in which alerts are held back, it may span midnight.
+type QuietHours struct {
+	Start Clock
+	End   Clock
+}
+
+// Contains reports whether t, already in the policy location, falls within the quiet hours.
+func (q *QuietHours) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	start, end := q.Start.minutes(), q.End.minutes()
+	if start <= end {
+		return m >= start && m < end
+	}
+	return m >= start || m < end
+}
+
+// Policy configures when alerts are delivered, for all the rules.
+type Policy struct {
+	// QuietHours hold back alerts, which are then delivered together when they end.
+	QuietHours *QuietHours
+	// DigestAt is when the daily digest of the digest rules is delivered.
+	DigestAt *Clock
+	Location *time.Location
+}
+
+type rawPolicy struct {
+	QuietHours *struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	} `json:"quiet_hours"`
+	DigestAt string `json:"digest_at"`
+	Timezone string `json:"timezone"`
+}
+
+// RawConfig returns the struct the JSON of a policy is decoded into, to check its fields.
+func (*Policy) RawConfig() any {
+	return rawPolicy{}
+}
+
+// UnmarshalJSON implements a custom json.Unmarshaler.
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	var raw rawPolicy
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse alerting policy: %w", err)
+	}
+
+	*p = Policy{Location: time.Local}
+	if raw.Timezone != "" {
+		loc, err := time.LoadLocation(raw.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid alerting timezone: %w", err)
+		}
+		p.Location = loc
+	}
+	if raw.QuietHours != nil {
+		start, err := ParseClock(raw.QuietHours.Start)
+		if err != nil {
+			return fmt.Errorf("invalid quiet hours start: %w", err)
+		}
+		end, err := ParseClock(raw.QuietHours.End)
+		if err != nil {
+			return fmt.Errorf("invalid quiet hours end: %w", err)
+		}
+		p.QuietHours = &QuietHours{Start: start, End: end}
+	}
+	if raw.DigestAt != "" {
+		at, err := ParseClock(raw.DigestAt)
+		if err != nil {
+			return fmt.Errorf("invalid digest time: %w", err)
+		}
+		p.DigestAt = &at
+	}
+	return nil
+}
+
+func (p *Policy) quiet(t time.Time) bool {
+	return p.QuietHours != nil && p.QuietHours.Contains(t.In(p.location()))
+}
+
+func (p *Policy) location() *time.Location {
+	if p.Location == nil {
+		return time.Local
+	}
+	return p.Location
+}
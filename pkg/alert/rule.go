@@ -0,0 +1,208 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// Severity is how urgent an alert is.
+type Severity int
+
+const (
+	// SeverityInfo is for alerts worth a look.
+	SeverityInfo Severity = iota
+	// SeverityWarning is for alerts worth acting on.
+	SeverityWarning
+	// SeverityCritical is for alerts worth acting on immediately.
+	SeverityCritical
+)
+
+// String returns a textual representation of a Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityCritical:
+		return "CRITICAL"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// MarshalJSON implements a custom json.Marshaler.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements a custom json.Unmarshaler.
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	switch strings.ToUpper(str) {
+	case "INFO", "":
+		*s = SeverityInfo
+	case "WARNING", "WARN":
+		*s = SeverityWarning
+	case "CRITICAL":
+		*s = SeverityCritical
+	default:
+		return fmt.Errorf("invalid severity %s", str)
+	}
+	return nil
+}
+
+// Rule is a user-defined alert condition, all of its non-empty criteria must be met for it to fire.
+type Rule struct {
+	// Condition is an expression over the StockScore fields and strategy signals.
+	Condition *Expression
+	// CrossesAbove fires when the price goes from below the level to at or above it, since the last scan.
+	CrossesAbove *float64
+	// CrossesBelow fires when the price goes from above the level to at or below it, since the last scan.
+	CrossesBelow *float64
+	// ScoreChange fires when the weighted score rose by at least this much since the last scan,
+	// or dropped by at least as much when negative.
+	ScoreChange *float64
+	Name        string
+	// Symbols restricts the rule to the given stocks, empty for all of them.
+	Symbols []string
+	// Notifiers are the names of the notifiers the alerts are sent to, empty for all of them.
+	Notifiers []string
+	// Cooldown is the minimum time between two alerts for the same stock.
+	Cooldown time.Duration
+	// EscalateAfter raises the severity of the alert, once, if the rule keeps matching the same stock this long.
+	EscalateAfter time.Duration
+	Severity      Severity
+	// OnlyOnChange alerts only when the rule starts matching a stock, not while it keeps matching it.
+	OnlyOnChange bool
+	// MeetsCriteria restricts the rule to the stocks meeting the scan filters.
+	MeetsCriteria bool
+	// Digest collects the alerts into the daily digest instead of delivering them right away,
+	// unless the policy has no digest time.
+	Digest bool
+}
+
+type rawRule struct {
+	CrossesAbove  *float64 `json:"crosses_above"`
+	CrossesBelow  *float64 `json:"crosses_below"`
+	ScoreChange   *float64 `json:"score_change"`
+	Name          string   `json:"name"`
+	Condition     string   `json:"condition"`
+	Cooldown      string   `json:"cooldown"`
+	EscalateAfter string   `json:"escalate_after"`
+	Symbols       []string `json:"symbols"`
+	Notifiers     []string `json:"notifiers"`
+	Severity      Severity `json:"severity"`
+	OnlyOnChange  bool     `json:"only_on_change"`
+	MeetsCriteria bool     `json:"meets_criteria"`
+	Digest        bool     `json:"digest"`
+}
+
+// RawConfig returns the struct the JSON of a rule is decoded into, to check its fields.
+func (*Rule) RawConfig() any {
+	return rawRule{}
+}
+
+// UnmarshalJSON implements a custom json.Unmarshaler.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	var raw rawRule
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse alert rule: %w", err)
+	}
+	if raw.Name == "" {
+		return errors.New("alert rule without name")
+	}
+	if raw.Condition == "" && raw.CrossesAbove == nil && raw.CrossesBelow == nil && raw.ScoreChange == nil {
+		return fmt.Errorf("alert rule %q: at least one of condition, crosses_above, crosses_below "+
+			"or score_change must be specified", raw.Name)
+	}
+
+	*r = Rule{
+		CrossesAbove:  raw.CrossesAbove,
+		CrossesBelow:  raw.CrossesBelow,
+		ScoreChange:   raw.ScoreChange,
+		Name:          raw.Name,
+		Symbols:       raw.Symbols,
+		Notifiers:     raw.Notifiers,
+		Severity:      raw.Severity,
+		OnlyOnChange:  raw.OnlyOnChange,
+		MeetsCriteria: raw.MeetsCriteria,
+		Digest:        raw.Digest,
+	}
+	var err error
+	if raw.Cooldown != "" {
+		if r.Cooldown, err = time.ParseDuration(raw.Cooldown); err != nil {
+			return fmt.Errorf("alert rule %q: invalid cooldown: %w", raw.Name, err)
+		}
+	}
+	if raw.EscalateAfter != "" {
+		if r.EscalateAfter, err = time.ParseDuration(raw.EscalateAfter); err != nil {
+			return fmt.Errorf("alert rule %q: invalid escalate_after: %w", raw.Name, err)
+		}
+	}
+	if raw.Condition != "" {
+		r.Condition, err = Compile(raw.Condition)
+		if err != nil {
+			return fmt.Errorf("alert rule %q: invalid condition: %w", raw.Name, err)
+		}
+	}
+	return nil
+}
+
+// DefaultRule is used when no rules are configured: it fires for the stocks meeting the scan filters,
+// with an opportunity of at least medium level and at most medium risk, when they first show up.
+func DefaultRule() *Rule {
+	return &Rule{
+		Name:          "high opportunity",
+		Condition:     mustCompile("Opportunity >= medium && Risk <= medium"),
+		OnlyOnChange:  true,
+		MeetsCriteria: true,
+	}
+}
+
+func mustCompile(source string) *Expression {
+	e, err := Compile(source)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Match reports whether the score meets the rule, given the score of the same stock
+// at the previous scan, nil if there isn't one.
+func (r *Rule) Match(score, previous *monitor.StockScore) bool {
+	if len(r.Symbols) > 0 && !slices.ContainsFunc(r.Symbols, func(s string) bool {
+		return strings.EqualFold(s, score.Symbol)
+	}) {
+		return false
+	}
+	if r.Condition != nil && !r.Condition.Match(score) {
+		return false
+	}
+
+	if (r.CrossesAbove != nil || r.CrossesBelow != nil || r.ScoreChange != nil) && previous == nil {
+		return false
+	}
+	if r.CrossesAbove != nil && (previous.LastPrice >= *r.CrossesAbove || score.LastPrice < *r.CrossesAbove) {
+		return false
+	}
+	if r.CrossesBelow != nil && (previous.LastPrice <= *r.CrossesBelow || score.LastPrice > *r.CrossesBelow) {
+		return false
+	}
+	if r.ScoreChange != nil {
+		change := score.WeightedScore - previous.WeightedScore
+		if *r.ScoreChange >= 0 && change < *r.ScoreChange || *r.ScoreChange < 0 && change > *r.ScoreChange {
+			return false
+		}
+	}
+	return true
+}
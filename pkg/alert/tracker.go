@@ -0,0 +1,196 @@
+package alert
+
+import (
+	"slices"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// Notification is an alert the Tracker decided to deliver.
+type Notification struct {
+	Timestamp time.Time             `json:"timestamp"`
+	Rule      string                `json:"rule"`
+	Scores    []*monitor.StockScore `json:"scores"`
+	Notifiers []string              `json:"notifiers,omitempty"`
+	Severity  Severity              `json:"severity"`
+	Escalated bool                  `json:"escalated,omitempty"`
+}
+
+// RuleState tracks a rule firing for a single stock.
+type RuleState struct {
+	// Since is when the rule started matching without interruption, zero if it currently doesn't.
+	Since     time.Time `json:"since"`
+	LastSent  time.Time `json:"last_sent"`
+	Escalated bool      `json:"escalated,omitempty"`
+}
+
+// State is what the Tracker remembers, it can be persisted to survive restarts.
+type State struct {
+	// Rules maps each rule name, then each symbol, to its state.
+	Rules map[string]map[string]*RuleState `json:"rules"`
+	// LastDigest is when the daily digest was last delivered.
+	LastDigest time.Time `json:"last_digest"`
+	// Held are the notifications raised during quiet hours.
+	Held []*Notification `json:"held,omitempty"`
+	// Digest are the notifications waiting for the daily digest.
+	Digest []*Notification `json:"digest,omitempty"`
+}
+
+// Retain drops the state of the rules that aren't among the given ones, such as the rules removed
+// from the configuration since the state was saved.
+func (s *State) Retain(rules []*Rule) {
+	for name := range s.Rules {
+		if !slices.ContainsFunc(rules, func(r *Rule) bool { return r.Name == name }) {
+			delete(s.Rules, name)
+		}
+	}
+}
+
+// Tracker throttles the matches of an Engine: it applies the rules cooldowns, only-on-change
+// and escalation settings, then holds back alerts during quiet hours and collects the digest ones.
+type Tracker struct {
+	policy *Policy
+	state  *State
+}
+
+// NewTracker creates a new Tracker, resuming from state if not nil.
+func NewTracker(policy *Policy, state *State) *Tracker {
+	if policy == nil {
+		policy = &Policy{}
+	}
+	if state == nil {
+		state = &State{}
+	}
+	if state.Rules == nil {
+		state.Rules = make(map[string]map[string]*RuleState)
+	}
+	return &Tracker{policy: policy, state: state}
+}
+
+// State returns the current state of the tracker.
+func (t *Tracker) State() *State {
+	return t.state
+}
+
+// Process returns the notifications to deliver right away for the matches found at now, and the ones
+// that were queued instead, either held for quiet hours or waiting for the digest.
+// Evaluated are the scores the matches were computed from, which should be every stock scanned,
+// whether it meets the scan filters or not: the rules not matching them are reset, so that a stock
+// leaving the filters and coming back later starts a new streak.
+func (t *Tracker) Process(now time.Time, evaluated []*monitor.StockScore, matches []*Match) (
+	deliver, queued []*Notification,
+) {
+	matched := make(map[string]map[string]bool, len(matches))
+	for _, m := range matches {
+		matched[m.Rule.Name] = make(map[string]bool, len(m.Scores))
+		var regular, escalated *Notification
+		for _, score := range m.Scores {
+			matched[m.Rule.Name][score.Symbol] = true
+			fire, escalate := t.fire(now, m.Rule, score.Symbol)
+			switch {
+			case !fire:
+				continue
+			case escalate:
+				escalated = appendScore(escalated, now, m.Rule, score, true)
+			default:
+				regular = appendScore(regular, now, m.Rule, score, false)
+			}
+		}
+
+		for _, n := range []*Notification{regular, escalated} {
+			switch {
+			case n == nil:
+			case m.Rule.Digest && t.policy.DigestAt != nil:
+				t.state.Digest = append(t.state.Digest, n)
+				queued = append(queued, n)
+			case t.policy.quiet(now):
+				t.state.Held = append(t.state.Held, n)
+				queued = append(queued, n)
+			default:
+				deliver = append(deliver, n)
+			}
+		}
+	}
+
+	// a stock that was evaluated without matching ends the streak of the rule
+	for rule, symbols := range t.state.Rules {
+		for _, score := range evaluated {
+			if s, ok := symbols[score.Symbol]; ok && !matched[rule][score.Symbol] {
+				s.Since, s.Escalated = time.Time{}, false
+			}
+		}
+	}
+
+	return deliver, queued
+}
+
+// fire updates the state of rule for symbol, reporting whether it should fire and if it's an escalation.
+func (t *Tracker) fire(now time.Time, rule *Rule, symbol string) (fire, escalate bool) {
+	symbols, ok := t.state.Rules[rule.Name]
+	if !ok {
+		symbols = make(map[string]*RuleState)
+		t.state.Rules[rule.Name] = symbols
+	}
+	s, ok := symbols[symbol]
+	if !ok {
+		s = &RuleState{}
+		symbols[symbol] = s
+	}
+
+	changed := s.Since.IsZero()
+	if changed {
+		s.Since = now
+	}
+
+	switch {
+	case rule.EscalateAfter > 0 && !changed && !s.Escalated && now.Sub(s.Since) >= rule.EscalateAfter:
+		s.Escalated = true
+		escalate = true
+	case rule.OnlyOnChange && !changed:
+		return false, false
+	case rule.Cooldown > 0 && !s.LastSent.IsZero() && now.Sub(s.LastSent) < rule.Cooldown:
+		return false, false
+	}
+
+	s.LastSent = now
+	return true, escalate
+}
+
+func appendScore(n *Notification, now time.Time, rule *Rule, score *monitor.StockScore, escalated bool) *Notification {
+	if n == nil {
+		n = &Notification{
+			Timestamp: now,
+			Rule:      rule.Name,
+			Notifiers: rule.Notifiers,
+			Severity:  rule.Severity,
+			Escalated: escalated,
+		}
+		if escalated && n.Severity < SeverityCritical {
+			n.Severity++
+		}
+	}
+	n.Scores = append(n.Scores, score)
+	return n
+}
+
+// Flush returns the queued notifications that are due at now: the ones held during quiet hours
+// once they are over, and the digest ones once a day at the digest time.
+func (t *Tracker) Flush(now time.Time) []*Notification {
+	if t.policy.quiet(now) {
+		return nil
+	}
+
+	out := t.state.Held
+	t.state.Held = nil
+
+	if t.policy.DigestAt != nil {
+		due := t.policy.DigestAt.On(now.In(t.policy.location()))
+		if !now.Before(due) && t.state.LastDigest.Before(due) {
+			out = append(out, t.state.Digest...)
+			t.state.Digest = nil
+			t.state.LastDigest = now
+		}
+	}
+	return out
+}
@@ -0,0 +1,273 @@
+package alert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+var monday = time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+// summarize lists the notifications as "rule SEVERITY symbols".
+func summarize(notifications []*alert.Notification) []string {
+	var out []string
+	for _, n := range notifications {
+		var symbols []string
+		for _, s := range n.Scores {
+			symbols = append(symbols, s.Symbol)
+		}
+		out = append(out, n.Rule+" "+n.Severity.String()+" "+strings.Join(symbols, ","))
+	}
+	return out
+}
+
+type tick struct {
+	offset      time.Duration
+	scores      []*monitor.StockScore
+	wantDeliver []string
+	wantQueued  []string
+	wantFlush   []string
+}
+
+func runTicks(t *testing.T, tracker *alert.Tracker, engine *alert.Engine, ticks []tick) {
+	t.Helper()
+	for i, tc := range ticks {
+		now := monday.Add(tc.offset)
+		deliver, queued := tracker.Process(now, tc.scores, engine.Evaluate(tc.scores))
+		flushed := tracker.Flush(now)
+		for _, c := range []struct {
+			name string
+			want []string
+			got  []*alert.Notification
+		}{
+			{"delivered", tc.wantDeliver, deliver},
+			{"queued", tc.wantQueued, queued},
+			{"flushed", tc.wantFlush, flushed},
+		} {
+			if diff := cmp.Diff(c.want, summarize(c.got)); diff != "" {
+				t.Errorf("tick %d: %s notifications mismatch (-want +got):\n%s", i, c.name, diff)
+			}
+		}
+	}
+}
+
+func TestTracker_Throttling(t *testing.T) {
+	rules := mustRules(t, `[
+		{"name": "every", "condition": "MACD == buy"},
+		{"name": "change", "condition": "MACD == buy", "only_on_change": true},
+		{"name": "cooldown", "condition": "MACD == buy", "cooldown": "1h"},
+		{"name": "escalate", "condition": "MACD == buy", "only_on_change": true, "escalate_after": "30m",
+			"severity": "warning"}
+	]`)
+	eni, ucg := score("ENI.MTA", 10, 1), score("UCG.MTA", 10, 1)
+	flat := score("UCG.MTA", 10, 1)
+	flat.Signals = nil
+
+	runTicks(t, alert.NewTracker(nil, nil), alert.NewEngine(nil, rules...), []tick{
+		{
+			scores: []*monitor.StockScore{eni, ucg},
+			wantDeliver: []string{
+				"every INFO ENI.MTA,UCG.MTA", "change INFO ENI.MTA,UCG.MTA",
+				"cooldown INFO ENI.MTA,UCG.MTA", "escalate WARNING ENI.MTA,UCG.MTA",
+			},
+		},
+		{
+			offset:      10 * time.Minute,
+			scores:      []*monitor.StockScore{eni, flat},
+			wantDeliver: []string{"every INFO ENI.MTA"},
+		},
+		{
+			// UCG starts matching again, ENI persisted long enough to escalate
+			offset: 40 * time.Minute,
+			scores: []*monitor.StockScore{eni, ucg},
+			wantDeliver: []string{"every INFO ENI.MTA,UCG.MTA", "change INFO UCG.MTA", "escalate WARNING UCG.MTA",
+				"escalate CRITICAL ENI.MTA"},
+		},
+		{
+			offset:      50 * time.Minute,
+			scores:      []*monitor.StockScore{eni, ucg},
+			wantDeliver: []string{"every INFO ENI.MTA,UCG.MTA"},
+		},
+		{
+			// the cooldown is over, ENI was already escalated
+			offset:      70 * time.Minute,
+			scores:      []*monitor.StockScore{eni},
+			wantDeliver: []string{"every INFO ENI.MTA", "cooldown INFO ENI.MTA"},
+		},
+	})
+}
+
+func TestTracker_QuietHoursAndDigest(t *testing.T) {
+	rules := mustRules(t, `[
+		{"name": "now", "condition": "MACD == buy", "notifiers": ["me"]},
+		{"name": "daily", "condition": "MACD == buy", "digest": true}
+	]`)
+	var policy alert.Policy
+	if err := json.Unmarshal(
+		[]byte(`{"quiet_hours": {"start": "22:00", "end": "07:00"}, "digest_at": "18:00", "timezone": "UTC"}`),
+		&policy); err != nil {
+		t.Fatal(err)
+	}
+	eni := []*monitor.StockScore{score("ENI.MTA", 10, 1)}
+
+	runTicks(t, alert.NewTracker(&policy, nil), alert.NewEngine(nil, rules...), []tick{
+		{scores: eni, wantDeliver: []string{"now INFO ENI.MTA"}, wantQueued: []string{"daily INFO ENI.MTA"}},
+		{
+			offset: time.Hour, scores: eni,
+			wantDeliver: []string{"now INFO ENI.MTA"}, wantQueued: []string{"daily INFO ENI.MTA"},
+		},
+		{offset: 9 * time.Hour, wantFlush: []string{"daily INFO ENI.MTA", "daily INFO ENI.MTA"}},
+		{offset: 10 * time.Hour},
+		{offset: 14 * time.Hour, scores: eni, wantQueued: []string{"now INFO ENI.MTA", "daily INFO ENI.MTA"}},
+		{offset: 20 * time.Hour},
+		{offset: 22 * time.Hour, wantFlush: []string{"now INFO ENI.MTA"}},
+		{offset: 33 * time.Hour, wantFlush: []string{"daily INFO ENI.MTA"}},
+	})
+}
+
+func TestTracker_DigestWithoutDigestTime(t *testing.T) {
+	rules := mustRules(t, `[{"name": "daily", "condition": "MACD == buy", "digest": true}]`)
+	eni := []*monitor.StockScore{score("ENI.MTA", 10, 1)}
+
+	// without a digest time, the digest alerts would never be flushed
+	tracker := alert.NewTracker(nil, nil)
+	runTicks(t, tracker, alert.NewEngine(nil, rules...), []tick{
+		{scores: eni, wantDeliver: []string{"daily INFO ENI.MTA"}},
+		{offset: 24 * time.Hour, scores: eni, wantDeliver: []string{"daily INFO ENI.MTA"}},
+	})
+	if len(tracker.State().Digest) != 0 {
+		t.Errorf("expected no alert waiting for the digest, instead got %v", summarize(tracker.State().Digest))
+	}
+}
+
+func TestTracker_ResumesFromState(t *testing.T) {
+	rules := mustRules(t, `[{"name": "change", "condition": "MACD == buy", "only_on_change": true}]`)
+	eni := []*monitor.StockScore{score("ENI.MTA", 10, 1)}
+
+	tracker := alert.NewTracker(nil, nil)
+	deliver, _ := tracker.Process(monday, eni, alert.NewEngine(nil, rules...).Evaluate(eni))
+	if len(deliver) != 1 {
+		t.Fatalf("expected the first alert to be delivered, instead got %v", summarize(deliver))
+	}
+
+	data, err := json.Marshal(tracker.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state alert.State
+	if err = json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	// a restarted monitor doesn't repeat the alert
+	deliver, _ = alert.NewTracker(nil, &state).Process(monday.Add(time.Minute), eni,
+		alert.NewEngine(nil, rules...).Evaluate(eni))
+	if len(deliver) != 0 {
+		t.Errorf("expected no alert after resuming, instead got %v", summarize(deliver))
+	}
+}
+
+func TestTracker_StockLeavingTheFilters(t *testing.T) {
+	rules := mustRules(t, `[
+		{"name": "default", "condition": "MACD == buy", "meets_criteria": true, "only_on_change": true},
+		{"name": "escalate", "condition": "MACD == buy", "meets_criteria": true, "only_on_change": true,
+			"escalate_after": "30m"}
+	]`)
+	filters := &monitor.ScanFilters{MinWeightedScore: 1, MaxRisk: monitor.RiskHigh}
+	eni, filtered := score("ENI.MTA", 10, 1), score("ENI.MTA", 10, 0.5)
+
+	runTicks(t, alert.NewTracker(nil, nil), alert.NewEngine(filters, rules...), []tick{
+		{
+			scores:      []*monitor.StockScore{eni},
+			wantDeliver: []string{"default INFO ENI.MTA", "escalate INFO ENI.MTA"},
+		},
+		{offset: 10 * time.Minute, scores: []*monitor.StockScore{filtered}},
+		{
+			// ENI comes back: it's a new streak, which doesn't escalate right away
+			offset:      40 * time.Minute,
+			scores:      []*monitor.StockScore{eni},
+			wantDeliver: []string{"default INFO ENI.MTA", "escalate INFO ENI.MTA"},
+		},
+		{offset: 50 * time.Minute, scores: []*monitor.StockScore{eni}},
+		{
+			offset:      70 * time.Minute,
+			scores:      []*monitor.StockScore{eni},
+			wantDeliver: []string{"escalate WARNING ENI.MTA"},
+		},
+	})
+}
+
+func TestState_Retain(t *testing.T) {
+	rules := mustRules(t, `[
+		{"name": "kept", "condition": "MACD == buy", "only_on_change": true},
+		{"name": "removed", "condition": "MACD == buy", "only_on_change": true}
+	]`)
+	eni := []*monitor.StockScore{score("ENI.MTA", 10, 1)}
+
+	tracker := alert.NewTracker(nil, nil)
+	tracker.Process(monday, eni, alert.NewEngine(nil, rules...).Evaluate(eni))
+	state := tracker.State()
+	state.Retain(rules[:1])
+	if _, ok := state.Rules["removed"]; ok {
+		t.Error("expected the state of the removed rule to be dropped")
+	}
+
+	// the kept rule doesn't repeat its alert, while a rule added back starts over
+	deliver, _ := alert.NewTracker(nil, state).Process(monday.Add(time.Minute), eni,
+		alert.NewEngine(nil, rules...).Evaluate(eni))
+	if diff := cmp.Diff([]string{"removed INFO ENI.MTA"}, summarize(deliver)); diff != "" {
+		t.Errorf("delivered notifications mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPolicy_UnmarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		data    string
+		wantErr string
+	}{
+		{data: `{"timezone": "Mars/Olympus"}`, wantErr: "invalid alerting timezone"},
+		{data: `{"quiet_hours": {"start": "25:00", "end": "07:00"}}`, wantErr: "invalid quiet hours start"},
+		{data: `{"quiet_hours": {"start": "22:00"}}`, wantErr: "invalid quiet hours end"},
+		{data: `{"digest_at": "6pm"}`, wantErr: "invalid digest time"},
+	} {
+		t.Run(tc.data, func(t *testing.T) {
+			var p alert.Policy
+			err := json.Unmarshal([]byte(tc.data), &p)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	overnight := &alert.QuietHours{Start: alert.Clock{Hour: 22}, End: alert.Clock{Hour: 7}}
+	lunch := &alert.QuietHours{Start: alert.Clock{Hour: 12, Minute: 30}, End: alert.Clock{Hour: 14}}
+	for _, tc := range []struct {
+		quiet *alert.QuietHours
+		at    string
+		want  bool
+	}{
+		{quiet: overnight, at: "21:59", want: false},
+		{quiet: overnight, at: "22:00", want: true},
+		{quiet: overnight, at: "03:00", want: true},
+		{quiet: overnight, at: "07:00", want: false},
+		{quiet: lunch, at: "12:29", want: false},
+		{quiet: lunch, at: "13:00", want: true},
+		{quiet: lunch, at: "14:00", want: false},
+	} {
+		c, err := alert.ParseClock(tc.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tc.quiet.Contains(c.On(monday)); got != tc.want {
+			t.Errorf("%s in %v-%v: expected %v, instead got %v", tc.at, tc.quiet.Start, tc.quiet.End, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package cache keeps the market data fetched recently in memory, to spare the provider repeated requests.
+package cache
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+)
+
+// Client is an api.Client decorator that serves the responses fetched within its time to live from memory.
+// Errors are not cached.
+type Client struct {
+	client  api.Client
+	now     func() time.Time
+	entries map[string]*entry
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	ttl     time.Duration
+	mu      sync.Mutex
+}
+
+type entry struct {
+	expires time.Time
+	data    []*api.OHLCV
+}
+
+// NewClient creates a new Client keeping the responses of cli for ttl.
+func NewClient(cli api.Client, ttl time.Duration) *Client {
+	return &Client{
+		client:  cli,
+		now:     time.Now,
+		entries: make(map[string]*entry),
+		ttl:     ttl,
+	}
+}
+
+// GetOHLCV returns the bars fetched for the same symbol and time frame within the time to live,
+// calling the underlying client otherwise.
+func (c *Client) GetOHLCV(ctx context.Context, symbol string, opts ...api.Option) ([]*api.OHLCV, error) {
+	key := strings.ToUpper(symbol) + "@" + replay.TimeFrameOf(opts...)
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && c.now().Before(e.expires) {
+		c.mu.Unlock()
+		c.hits.Add(1)
+		// callers may replace the bars of the slice they get, such as the live monitor does
+		return slices.Clone(e.data), nil
+	}
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	data, err := c.client.GetOHLCV(ctx, symbol, opts...)
+	if err != nil {
+		return data, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &entry{data: slices.Clone(data), expires: c.now().Add(c.ttl)}
+	// drop the expired entries, so that the cache doesn't outgrow the universe
+	for k, e := range c.entries {
+		if !c.now().Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	return data, nil
+}
+
+// Hits returns how many requests were served from memory.
+func (c *Client) Hits() uint64 {
+	return c.hits.Load()
+}
+
+// Misses returns how many requests were forwarded to the underlying client.
+func (c *Client) Misses() uint64 {
+	return c.misses.Load()
+}
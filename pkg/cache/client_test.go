@@ -0,0 +1,125 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/cache"
+)
+
+type fakeClient struct {
+	data  map[string][]*api.OHLCV
+	calls int
+}
+
+func (f *fakeClient) GetOHLCV(_ context.Context, symbol string, _ ...api.Option) ([]*api.OHLCV, error) {
+	f.calls++
+	data, ok := f.data[symbol]
+	if !ok {
+		return nil, errors.New("unknown symbol")
+	}
+	return data, nil
+}
+
+func TestClient_GetOHLCV(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	bars := []*api.OHLCV{
+		{Timestamp: ts, Open: 13.1, High: 13.4, Low: 13.0, Close: 13.3, Volume: 1e6},
+		{Timestamp: ts.AddDate(0, 0, 1), Open: 13.3, High: 13.5, Low: 13.2, Close: 13.2, Volume: 2e6},
+	}
+	hourly := &carnost.WithTimeframe{TimeFrame: carnost.TimeFrame("1h")}
+
+	type request struct {
+		symbol string
+		opts   []api.Option
+	}
+	type testCase struct {
+		name       string
+		requests   []request
+		ttl        time.Duration
+		wantCalls  int
+		wantHits   uint64
+		wantMisses uint64
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:       "serves the repeated requests from memory",
+			ttl:        time.Hour,
+			requests:   []request{{symbol: "ENI.MTA"}, {symbol: "eni.mta"}, {symbol: "ENI.MTA"}},
+			wantCalls:  1,
+			wantHits:   2,
+			wantMisses: 1,
+		},
+		{
+			name:       "keeps the time frames apart",
+			ttl:        time.Hour,
+			requests:   []request{{symbol: "ENI.MTA"}, {symbol: "ENI.MTA", opts: []api.Option{hourly}}},
+			wantCalls:  2,
+			wantMisses: 2,
+		},
+		{
+			name:       "doesn't cache the errors",
+			ttl:        time.Hour,
+			requests:   []request{{symbol: "UNKNOWN.MTA"}, {symbol: "UNKNOWN.MTA"}},
+			wantCalls:  2,
+			wantMisses: 2,
+		},
+		{
+			name:       "forwards every request once expired",
+			requests:   []request{{symbol: "ENI.MTA"}, {symbol: "ENI.MTA"}},
+			wantCalls:  2,
+			wantMisses: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			live := &fakeClient{data: map[string][]*api.OHLCV{"ENI.MTA": bars, "eni.mta": bars}}
+			cli := cache.NewClient(live, tc.ttl)
+			for _, r := range tc.requests {
+				got, err := cli.GetOHLCV(context.Background(), r.symbol, r.opts...)
+				if err != nil {
+					continue
+				}
+				if diff := cmp.Diff(bars, got); diff != "" {
+					t.Errorf("GetOHLCV() mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if live.calls != tc.wantCalls {
+				t.Errorf("expected %d calls to the client, instead got %d", tc.wantCalls, live.calls)
+			}
+			if cli.Hits() != tc.wantHits || cli.Misses() != tc.wantMisses {
+				t.Errorf("expected %d hits and %d misses, instead got %d and %d",
+					tc.wantHits, tc.wantMisses, cli.Hits(), cli.Misses())
+			}
+		})
+	}
+}
+
+func TestClient_GetOHLCV_ReturnsCopies(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	live := &fakeClient{data: map[string][]*api.OHLCV{
+		"ENI.MTA": {{Timestamp: ts, Close: 13.3}},
+	}}
+	cli := cache.NewClient(live, time.Hour)
+
+	first, err := cli.GetOHLCV(context.Background(), "ENI.MTA")
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	// replace the latest bar, as the live monitor does when a bar is updated
+	first[0] = &api.OHLCV{Timestamp: ts, Close: 14}
+
+	second, err := cli.GetOHLCV(context.Background(), "ENI.MTA")
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	if second[0].Close != 13.3 {
+		t.Errorf("expected the cached bar to be unchanged, instead got close %.2f", second[0].Close)
+	}
+}
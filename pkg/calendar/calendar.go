@@ -0,0 +1,197 @@
+package calendar
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+//go:embed exchanges/*.json
+var builtin embed.FS
+
+// Config configures the trading calendar.
+type Config struct {
+	// Files are additional exchange definitions, or holiday lists for the existing ones.
+	Files []string `json:"files"`
+	// ClosedBarsOnly ignores the bar still forming in the current session when scanning or analysing.
+	ClosedBarsOnly bool `json:"closed_bars_only"`
+}
+
+// Load returns the Default calendar, extended with the configured files.
+func (cfg *Config) Load() (*Calendar, error) {
+	c, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range cfg.Files {
+		if err = c.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Calendar is a set of exchanges.
+type Calendar struct {
+	exchanges map[string]*Exchange
+	codes     []string
+}
+
+// New creates an empty Calendar.
+func New() *Calendar {
+	return &Calendar{exchanges: make(map[string]*Exchange)}
+}
+
+// Default returns a calendar with the built-in exchanges: MTA (Borsa Italiana), XETRA, NYSE and NASDAQ.
+func Default() (*Calendar, error) {
+	c := New()
+	entries, err := builtin.ReadDir("exchanges")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := fs.ReadFile(builtin, "exchanges/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if err = c.load(entry.Name(), data); err != nil {
+			return nil, err
+		}
+	}
+	return c, c.link()
+}
+
+// LoadFile adds the exchange defined in the JSON file at path, replacing the one with the same code.
+// A file without time zone only adds its holidays and suffixes to an existing exchange.
+func (c *Calendar) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read calendar file: %w", err)
+	}
+	if err = c.load(path, data); err != nil {
+		return err
+	}
+	return c.link()
+}
+
+func (c *Calendar) load(name string, data []byte) error {
+	var e Exchange
+	if err := json.Unmarshal(data, &e); err != nil {
+		return fmt.Errorf("invalid calendar file %s: %w", name, err)
+	}
+	e.Code = strings.ToUpper(e.Code)
+
+	existing, ok := c.exchanges[e.Code]
+	switch {
+	case e.Location == nil && !ok:
+		return fmt.Errorf("invalid calendar file %s: unknown exchange %s requires a timezone", name, e.Code)
+	case e.Location == nil:
+		existing.merge(&e)
+	default:
+		if !ok {
+			c.codes = append(c.codes, e.Code)
+		}
+		c.exchanges[e.Code] = &e
+	}
+	return nil
+}
+
+// link resolves the exchanges sharing the holidays of another one, which may have been replaced since.
+func (c *Calendar) link() error {
+	for _, code := range c.codes {
+		e := c.exchanges[code]
+		if e.holidaysOf == "" {
+			continue
+		}
+		shared, ok := c.exchanges[e.holidaysOf]
+		switch {
+		case !ok:
+			return fmt.Errorf("exchange %s shares the holidays of unknown exchange %s", code, e.holidaysOf)
+		case shared.holidaysOf != "":
+			return fmt.Errorf("exchange %s shares the holidays of %s, which shares the ones of %s",
+				code, e.holidaysOf, shared.holidaysOf)
+		}
+		e.shared = shared
+	}
+	return nil
+}
+
+// Exchanges returns the codes of the exchanges in the calendar.
+func (c *Calendar) Exchanges() []string {
+	return c.codes
+}
+
+// Exchange returns the exchange with the given code.
+func (c *Calendar) Exchange(code string) (*Exchange, bool) {
+	e, ok := c.exchanges[strings.ToUpper(code)]
+	return e, ok
+}
+
+// ForSymbol returns the exchange the symbol is traded on, based on its suffix.
+func (c *Calendar) ForSymbol(symbol string) (*Exchange, bool) {
+	symbol = strings.ToUpper(symbol)
+	for _, code := range c.codes {
+		for _, suffix := range c.exchanges[code].Suffixes {
+			if strings.HasSuffix(symbol, strings.ToUpper(suffix)) {
+				return c.exchanges[code], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Hours are the combined trading hours of the exchanges of some symbols.
+type Hours struct {
+	exchanges []*Exchange
+	// always is set when a symbol is traded on an unknown exchange.
+	always bool
+}
+
+// Hours returns the combined trading hours of the exchanges the symbols are traded on.
+// Symbols on an unknown exchange are assumed to be always tradeable.
+func (c *Calendar) Hours(symbols ...string) *Hours {
+	h := &Hours{}
+	for _, symbol := range symbols {
+		e, ok := c.ForSymbol(symbol)
+		if !ok {
+			h.always = true
+			continue
+		}
+		if !slices.Contains(h.exchanges, e) {
+			h.exchanges = append(h.exchanges, e)
+		}
+	}
+	return h
+}
+
+// IsOpen reports whether any of the exchanges is open at t.
+func (h *Hours) IsOpen(t time.Time) bool {
+	if h.always {
+		return true
+	}
+	for _, e := range h.exchanges {
+		if e.IsOpen(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpen returns when the first of the exchanges opens after t, or t itself if any is open.
+func (h *Hours) NextOpen(t time.Time) time.Time {
+	if h.IsOpen(t) {
+		return t
+	}
+	var next time.Time
+	for _, e := range h.exchanges {
+		if open := e.NextOpen(t); !open.IsZero() && (next.IsZero() || open.Before(next)) {
+			next = open
+		}
+	}
+	return next
+}
@@ -0,0 +1,257 @@
+package calendar_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
+)
+
+func mustLoad(t *testing.T, files ...string) *calendar.Calendar {
+	t.Helper()
+	c, err := (&calendar.Config{Files: files}).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func mustExchange(t *testing.T, c *calendar.Calendar, code string) *calendar.Exchange {
+	t.Helper()
+	e, ok := c.Exchange(code)
+	if !ok {
+		t.Fatalf("exchange %s not found", code)
+	}
+	return e
+}
+
+func at(t *testing.T, value string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestExchange_IsOpen(t *testing.T) {
+	c := mustLoad(t, "./testdata/mta-extra.json", "./testdata/tse.json")
+	for _, tc := range []struct {
+		exchange string
+		at       string
+		want     bool
+	}{
+		{exchange: "MTA", at: "2025-06-03T08:59:00+02:00", want: false},
+		{exchange: "MTA", at: "2025-06-03T09:00:00+02:00", want: true},
+		{exchange: "MTA", at: "2025-06-03T17:29:59+02:00", want: true},
+		{exchange: "MTA", at: "2025-06-03T17:30:00+02:00", want: false},
+		{exchange: "MTA", at: "2025-06-03T07:30:00Z", want: true},
+		{exchange: "MTA", at: "2025-06-07T12:00:00+02:00", want: false},
+		{exchange: "MTA", at: "2025-12-25T12:00:00+01:00", want: false},
+		{exchange: "MTA", at: "2025-06-02T12:59:00+02:00", want: true},
+		{exchange: "MTA", at: "2025-06-02T13:00:00+02:00", want: false},
+		{exchange: "XETRA", at: "2025-06-02T13:00:00+02:00", want: true},
+		{exchange: "NYSE", at: "2025-06-03T15:29:00+02:00", want: false},
+		{exchange: "NYSE", at: "2025-06-03T15:30:00+02:00", want: true},
+		{exchange: "NYSE", at: "2025-11-28T12:59:00-05:00", want: true},
+		{exchange: "NYSE", at: "2025-11-28T13:00:00-05:00", want: false},
+		{exchange: "NASDAQ", at: "2025-07-04T10:00:00-04:00", want: false},
+		// the US moved to daylight saving time two weeks before Europe
+		{exchange: "NYSE", at: "2025-03-10T09:30:00-04:00", want: true},
+		{exchange: "NYSE", at: "2025-03-10T14:30:00+01:00", want: true},
+		{exchange: "TSE", at: "2025-06-03T11:45:00+09:00", want: false},
+		{exchange: "TSE", at: "2025-06-03T12:45:00+09:00", want: true},
+		{exchange: "TSE", at: "2025-06-02T10:00:00+09:00", want: false},
+	} {
+		t.Run(tc.exchange+" "+tc.at, func(t *testing.T) {
+			if got := mustExchange(t, c, tc.exchange).IsOpen(at(t, tc.at)); got != tc.want {
+				t.Errorf("expected %v, instead got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExchange_NextOpen(t *testing.T) {
+	c := mustLoad(t, "./testdata/tse.json")
+	for _, tc := range []struct {
+		exchange string
+		at       string
+		want     string
+	}{
+		{exchange: "MTA", at: "2025-06-03T10:00:00+02:00", want: "2025-06-03T10:00:00+02:00"},
+		{exchange: "MTA", at: "2025-06-03T07:00:00+02:00", want: "2025-06-03T09:00:00+02:00"},
+		{exchange: "MTA", at: "2025-06-06T18:00:00+02:00", want: "2025-06-09T09:00:00+02:00"},
+		{exchange: "MTA", at: "2025-12-23T18:00:00+01:00", want: "2025-12-29T09:00:00+01:00"},
+		{exchange: "NYSE", at: "2025-04-17T16:00:00-04:00", want: "2025-04-21T09:30:00-04:00"},
+		{exchange: "TSE", at: "2025-06-03T11:30:00+09:00", want: "2025-06-03T12:30:00+09:00"},
+	} {
+		t.Run(tc.exchange+" "+tc.at, func(t *testing.T) {
+			got := mustExchange(t, c, tc.exchange).NextOpen(at(t, tc.at))
+			if want := at(t, tc.want); !got.Equal(want) {
+				t.Errorf("expected %v, instead got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestExchange_IsForming(t *testing.T) {
+	c := mustLoad(t)
+	mta, nyse := mustExchange(t, c, "MTA"), mustExchange(t, c, "NYSE")
+	for _, tc := range []struct {
+		exchange *calendar.Exchange
+		name     string
+		start    string
+		now      string
+		interval time.Duration
+		want     bool
+	}{
+		{
+			name: "daily bar during the session", exchange: mta, interval: 24 * time.Hour,
+			start: "2025-06-03T00:00:00Z", now: "2025-06-03T16:00:00+02:00", want: true,
+		},
+		{
+			name: "daily bar after the close", exchange: mta, interval: 24 * time.Hour,
+			start: "2025-06-03T00:00:00Z", now: "2025-06-03T17:30:00+02:00", want: false,
+		},
+		{
+			name: "daily bar stamped at midnight UTC belongs to the same US date", exchange: nyse,
+			interval: 24 * time.Hour, start: "2025-06-03T00:00:00Z", now: "2025-06-03T15:00:00-04:00", want: true,
+		},
+		{
+			name: "daily bar on an early close", exchange: nyse, interval: 24 * time.Hour,
+			start: "2025-12-24T00:00:00Z", now: "2025-12-24T13:30:00-05:00", want: false,
+		},
+		{
+			name: "hourly bar cut by the close", exchange: mta, interval: time.Hour,
+			start: "2025-06-03T17:00:00+02:00", now: "2025-06-03T17:45:00+02:00", want: false,
+		},
+		{
+			name: "hourly bar in the session", exchange: mta, interval: time.Hour,
+			start: "2025-06-03T15:00:00+02:00", now: "2025-06-03T15:45:00+02:00", want: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.exchange.IsForming(at(t, tc.start), tc.interval, at(t, tc.now)); got != tc.want {
+				t.Errorf("expected %v, instead got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCalendar_Hours(t *testing.T) {
+	c := mustLoad(t, "./testdata/mta-extra.json")
+
+	var got []string
+	for _, symbol := range []string{"ENI.MTA", "QQQ3.ETF", "ENI.BIT", "SAP.DE", "AAPL.O", "IBM"} {
+		e, ok := c.ForSymbol(symbol)
+		if !ok {
+			got = append(got, symbol+": unknown")
+			continue
+		}
+		got = append(got, symbol+": "+e.Code)
+	}
+	want := []string{
+		"ENI.MTA: MTA", "QQQ3.ETF: MTA", "ENI.BIT: MTA", "SAP.DE: XETRA", "AAPL.O: NASDAQ", "IBM: unknown",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("exchanges mismatch (-want +got):\n%s", diff)
+	}
+
+	evening := at(t, "2025-06-03T19:00:00+02:00")
+	h := c.Hours("ENI.MTA", "SAP.DE")
+	if h.IsOpen(evening) || !h.NextOpen(evening).Equal(at(t, "2025-06-04T09:00:00+02:00")) {
+		t.Errorf("expected european markets to be closed until the morning, next open %v", h.NextOpen(evening))
+	}
+	if h := c.Hours("ENI.MTA", "AAPL.O"); !h.IsOpen(evening) {
+		t.Error("expected the US market to still be open")
+	}
+	if h := c.Hours("ENI.MTA", "IBM"); !h.IsOpen(at(t, "2025-06-07T03:00:00Z")) {
+		t.Error("expected symbols on unknown exchanges to be always tradeable")
+	}
+}
+
+func TestCalendar_LoadFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "no code", data: `{"timezone": "UTC"}`, wantErr: "exchange without code"},
+		{name: "unknown exchange", data: `{"code": "LSE"}`, wantErr: "unknown exchange LSE requires a timezone"},
+		{name: "bad timezone", data: `{"code": "LSE", "timezone": "Europe/Londra"}`, wantErr: "invalid timezone"},
+		{name: "no sessions", data: `{"code": "LSE", "timezone": "Europe/London"}`, wantErr: "at least one session"},
+		{
+			name:    "inverted session",
+			data:    `{"code": "LSE", "timezone": "Europe/London", "sessions": [{"open": "16:30", "close": "08:00"}]}`,
+			wantErr: "session closes at 08:00, before it opens at 16:30",
+		},
+		{
+			name:    "bad holiday",
+			data:    `{"code": "MTA", "holidays": [{"date": "25/12/2025"}]}`,
+			wantErr: `invalid holiday date "25/12/2025"`,
+		},
+		{
+			name:    "bad early close",
+			data:    `{"code": "MTA", "holidays": [{"date": "2025-12-24", "close": "1pm"}]}`,
+			wantErr: "invalid early close on 2025-12-24",
+		},
+		{
+			name: "unknown shared holidays",
+			data: `{"code": "MTA", "timezone": "Europe/Rome", "sessions": [{"open": "09:00", "close": "17:30"}],
+				"holidays_of": "LSE"}`,
+			wantErr: "exchange MTA shares the holidays of unknown exchange LSE",
+		},
+		{
+			name: "chained shared holidays",
+			data: `{"code": "XETRA", "timezone": "Europe/Berlin", "sessions": [{"open": "09:00", "close": "17:30"}],
+				"holidays_of": "NASDAQ"}`,
+			wantErr: "exchange XETRA shares the holidays of NASDAQ, which shares the ones of NYSE",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tc.name, " ", "-")+".json")
+			if err := os.WriteFile(path, []byte(tc.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := (&calendar.Config{Files: []string{path}}).Load()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExchange_SharedHolidays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nyse-2027.json")
+	if err := os.WriteFile(path, []byte(`{"code": "NYSE", "holidays": [{"date": "2027-01-01"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	nasdaq := mustExchange(t, mustLoad(t, path), "NASDAQ")
+	for _, tc := range []struct {
+		at         string
+		wantOpen   bool
+		wantCovers bool
+	}{
+		{at: "2025-07-04T10:00:00-04:00", wantOpen: false, wantCovers: true},
+		{at: "2026-11-27T12:59:00-05:00", wantOpen: true, wantCovers: true},
+		{at: "2026-11-27T13:00:00-05:00", wantOpen: false, wantCovers: true},
+		{at: "2027-01-01T10:00:00-05:00", wantOpen: false, wantCovers: true},
+		{at: "2028-01-03T10:00:00-05:00", wantOpen: true, wantCovers: false},
+	} {
+		t.Run(tc.at, func(t *testing.T) {
+			ts := at(t, tc.at)
+			if got := nasdaq.IsOpen(ts); got != tc.wantOpen {
+				t.Errorf("expected open %v, instead got %v", tc.wantOpen, got)
+			}
+			if got := nasdaq.Covers(ts); got != tc.wantCovers {
+				t.Errorf("expected covers %v, instead got %v", tc.wantCovers, got)
+			}
+		})
+	}
+}
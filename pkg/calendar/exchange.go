@@ -0,0 +1,304 @@
+package calendar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxClosedDays bounds the search for the next session, well beyond any real market closure.
+const maxClosedDays = 30
+
+// Interval is a trading session on a given day.
+type Interval struct {
+	Open  time.Time
+	Close time.Time
+}
+
+// Contains reports whether t is within the session.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Open) && t.Before(i.Close)
+}
+
+// Session is a daily trading session, as offsets from midnight in the exchange time zone.
+type Session struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// Holiday is a day the exchange is closed, or closes early if Close is set.
+type Holiday struct {
+	Close *time.Duration
+	Name  string
+}
+
+// Exchange defines when a market is open.
+type Exchange struct {
+	Location *time.Location
+	// holidays are keyed by date, in the 2006-01-02 format.
+	holidays map[string]*Holiday
+	// holidaysOf is the code of the exchange sharing its holidays, resolved into shared by the Calendar.
+	holidaysOf string
+	shared     *Exchange
+	// warned are the years the exchange was used in without knowing their holidays.
+	warned *sync.Map
+	Code   string
+	Name   string
+	// Suffixes of the symbols traded on the exchange, e.g. ".MTA".
+	Suffixes []string
+	Sessions []Session
+	Weekdays []time.Weekday
+}
+
+type rawExchange struct {
+	Code       string         `json:"code"`
+	Name       string         `json:"name"`
+	Timezone   string         `json:"timezone"`
+	Suffixes   []string       `json:"suffixes"`
+	Sessions   []*rawSession  `json:"sessions"`
+	Weekdays   []time.Weekday `json:"weekdays"`
+	Holidays   []*rawHoliday  `json:"holidays"`
+	HolidaysOf string         `json:"holidays_of"`
+}
+
+type rawSession struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+type rawHoliday struct {
+	Date  string `json:"date"`
+	Name  string `json:"name"`
+	Close string `json:"close"`
+}
+
+// UnmarshalJSON implements a custom json.Unmarshaler.
+// Exchanges without time zone only define holidays, to be merged into an existing exchange.
+func (e *Exchange) UnmarshalJSON(data []byte) error {
+	var raw rawExchange
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse exchange: %w", err)
+	}
+	if raw.Code == "" {
+		return errors.New("exchange without code")
+	}
+
+	*e = Exchange{
+		Code:       raw.Code,
+		Name:       raw.Name,
+		Suffixes:   raw.Suffixes,
+		Weekdays:   raw.Weekdays,
+		holidays:   make(map[string]*Holiday, len(raw.Holidays)),
+		holidaysOf: strings.ToUpper(raw.HolidaysOf),
+		warned:     &sync.Map{},
+	}
+	if len(e.Weekdays) == 0 {
+		e.Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	if raw.Timezone != "" {
+		loc, err := time.LoadLocation(raw.Timezone)
+		if err != nil {
+			return fmt.Errorf("exchange %s: invalid timezone: %w", raw.Code, err)
+		}
+		e.Location = loc
+	}
+
+	for _, s := range raw.Sessions {
+		open, err := parseClock(s.Open)
+		if err != nil {
+			return fmt.Errorf("exchange %s: invalid session open: %w", raw.Code, err)
+		}
+		closing, err := parseClock(s.Close)
+		if err != nil {
+			return fmt.Errorf("exchange %s: invalid session close: %w", raw.Code, err)
+		}
+		if closing <= open {
+			return fmt.Errorf("exchange %s: session closes at %s, before it opens at %s", raw.Code, s.Close, s.Open)
+		}
+		e.Sessions = append(e.Sessions, Session{Open: open, Close: closing})
+	}
+	if e.Location != nil && len(e.Sessions) == 0 {
+		return fmt.Errorf("exchange %s: at least one session must be specified", raw.Code)
+	}
+
+	for _, h := range raw.Holidays {
+		if _, err := time.Parse(time.DateOnly, h.Date); err != nil {
+			return fmt.Errorf("exchange %s: invalid holiday date %q", raw.Code, h.Date)
+		}
+		holiday := &Holiday{Name: h.Name}
+		if h.Close != "" {
+			closing, err := parseClock(h.Close)
+			if err != nil {
+				return fmt.Errorf("exchange %s: invalid early close on %s: %w", raw.Code, h.Date, err)
+			}
+			holiday.Close = &closing
+		}
+		e.holidays[h.Date] = holiday
+	}
+	return nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of the day %q, expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Holiday returns the holiday on the day of t, in the exchange time zone, if any,
+// including the ones of the exchange it shares the holidays of.
+func (e *Exchange) Holiday(t time.Time) (*Holiday, bool) {
+	date := t.In(e.Location).Format(time.DateOnly)
+	if h, ok := e.holidays[date]; ok {
+		return h, true
+	}
+	if e.shared != nil {
+		h, ok := e.shared.holidays[date]
+		return h, ok
+	}
+	return nil, false
+}
+
+// Covers reports whether the holidays of the year of t, in the exchange time zone, are known,
+// i.e. whether any holiday is defined in that year. An exchange without holidays covers every year.
+func (e *Exchange) Covers(t time.Time) bool {
+	year := t.In(e.Location).Format("2006-")
+	known := false
+	for _, holidays := range []map[string]*Holiday{e.holidays, e.sharedHolidays()} {
+		for date := range holidays {
+			if strings.HasPrefix(date, year) {
+				return true
+			}
+			known = true
+		}
+	}
+	return !known
+}
+
+func (e *Exchange) sharedHolidays() map[string]*Holiday {
+	if e.shared == nil {
+		return nil
+	}
+	return e.shared.holidays
+}
+
+// warnUncovered logs, once per year, that the holidays of the year of t are unknown,
+// so the sessions of the exchange are computed as if there were none.
+func (e *Exchange) warnUncovered(t time.Time) {
+	if e.Covers(t) || e.warned == nil {
+		return
+	}
+	year := t.In(e.Location).Year()
+	if _, warned := e.warned.LoadOrStore(year, true); !warned {
+		slog.Warn("the calendar has no holidays for the year, the exchange is assumed open on every weekday",
+			"exchange", e.Code, "year", year)
+	}
+}
+
+// SessionsOn returns the sessions of the day of t, in the exchange time zone,
+// none on weekends and holidays, shortened on early closes.
+func (e *Exchange) SessionsOn(t time.Time) []Interval {
+	t = t.In(e.Location)
+	if !slices.Contains(e.Weekdays, t.Weekday()) {
+		return nil
+	}
+	e.warnUncovered(t)
+	holiday, isHoliday := e.Holiday(t)
+	if isHoliday && holiday.Close == nil {
+		return nil
+	}
+
+	// wall clock times, which stay correct across daylight saving changes
+	at := func(d time.Duration) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, int(d/time.Minute), 0, 0, e.Location)
+	}
+	out := make([]Interval, 0, len(e.Sessions))
+	for _, s := range e.Sessions {
+		closing := s.Close
+		if isHoliday && *holiday.Close < closing {
+			closing = *holiday.Close
+		}
+		if closing <= s.Open {
+			continue
+		}
+		out = append(out, Interval{Open: at(s.Open), Close: at(closing)})
+	}
+	return out
+}
+
+// SessionAt returns the session t falls in, if the exchange is open at t.
+func (e *Exchange) SessionAt(t time.Time) (Interval, bool) {
+	for _, s := range e.SessionsOn(t) {
+		if s.Contains(t) {
+			return s, true
+		}
+	}
+	return Interval{}, false
+}
+
+// IsOpen reports whether the exchange is open at t.
+func (e *Exchange) IsOpen(t time.Time) bool {
+	_, ok := e.SessionAt(t)
+	return ok
+}
+
+// NextOpen returns when the next session opens after t, or t itself if the exchange is open.
+func (e *Exchange) NextOpen(t time.Time) time.Time {
+	if e.IsOpen(t) {
+		return t
+	}
+	day := t.In(e.Location)
+	for range maxClosedDays {
+		for _, s := range e.SessionsOn(day) {
+			if s.Open.After(t) {
+				return s.Open
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, e.Location)
+	}
+	return time.Time{}
+}
+
+// BarClose returns when the bar starting at start closes. Intraday bars close after interval,
+// or at the end of their session if earlier; daily or longer bars close at the end of the last
+// session of their date, which is read in UTC as daily bars are usually stamped at midnight UTC.
+func (e *Exchange) BarClose(start time.Time, interval time.Duration) time.Time {
+	if interval >= 24*time.Hour {
+		utc := start.UTC()
+		day := time.Date(utc.Year(), utc.Month(), utc.Day(), 12, 0, 0, 0, e.Location)
+		sessions := e.SessionsOn(day)
+		if len(sessions) == 0 {
+			return start.Add(interval)
+		}
+		return sessions[len(sessions)-1].Close
+	}
+
+	end := start.Add(interval)
+	if s, ok := e.SessionAt(start); ok && s.Close.Before(end) {
+		return s.Close
+	}
+	return end
+}
+
+// IsForming reports whether at now the bar starting at start is still forming, i.e. it isn't closed yet.
+func (e *Exchange) IsForming(start time.Time, interval time.Duration, now time.Time) bool {
+	return now.Before(e.BarClose(start, interval))
+}
+
+// merge adds the holidays and suffixes of other to the exchange.
+func (e *Exchange) merge(other *Exchange) {
+	for date, h := range other.holidays {
+		e.holidays[date] = h
+	}
+	for _, suffix := range other.Suffixes {
+		if !slices.Contains(e.Suffixes, suffix) {
+			e.Suffixes = append(e.Suffixes, suffix)
+		}
+	}
+}
@@ -2,10 +2,12 @@ package config
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
 	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/notify"
 	"github.com/CanobbioE/algo-trading/pkg/strategies"
 )
 
@@ -15,18 +17,28 @@ type (
 		Thresholds           *strategies.Thresholds       `json:"thresholds"`
 		MACDParams           *strategies.MACDParams       `json:"macd_params"`
 		Filters              *monitor.ScanFilters         `json:"filters"`
+		Alerting             *alert.Policy                `json:"alerting"`
+		Calendar             *calendar.Config             `json:"calendar"`
 		StockUniverse        []string                     `json:"stock_universe"`
 		Strategies           []*strategies.StrategyWeight `json:"strategies"`
+		Notifiers            []*notify.Config             `json:"notifiers"`
+		Alerts               []*alert.Rule                `json:"alerts"`
 		LookBack             int                          `json:"lookback"`
-		MomentumLookBack     int                          `json:"momentum_look_back"`
+		MomentumLookBack     int                          `json:"momentum_lookback"`
 		BollingerCoefficient float64                      `json:"bollinger_coefficient"`
+		// configured are the strategies as decoded, so that Validate can report the unknown ones.
+		configured []*rawStrategies
 	}
 	rawCfg struct {
 		Thresholds           *strategies.Thresholds `json:"thresholds"`
 		MACDParams           *strategies.MACDParams `json:"macd_params"`
 		ScanFilters          *monitor.ScanFilters   `json:"scan_filters"`
+		Alerting             *alert.Policy          `json:"alerting"`
+		Calendar             *calendar.Config       `json:"calendar"`
 		Strategies           []*rawStrategies       `json:"strategies"`
 		StockUniverse        []string               `json:"stock_universe"`
+		Notifiers            []*notify.Config       `json:"notifiers"`
+		Alerts               []*alert.Rule          `json:"alerts"`
 		LookBack             int                    `json:"lookback"`
 		MomentumLookBack     int                    `json:"momentum_lookback"`
 		BollingerCoefficient float64                `json:"bollinger_coefficient"`
@@ -38,44 +50,49 @@ type (
 )
 
 // UnmarshalJSON implements a custom json.Unmarshaler.
+// It only decodes the configuration, the missing and unknown fields are reported by Validate.
 func (c *Config) UnmarshalJSON(data []byte) error {
 	var raw rawCfg
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return fmt.Errorf("failed to parse json raw: %w", err)
 	}
 	c.Thresholds = raw.Thresholds
-	if c.Thresholds == nil {
-		return errors.New("no thresholds specified")
-	}
 	c.Filters = raw.ScanFilters
 	c.BollingerCoefficient = raw.BollingerCoefficient
 	c.StockUniverse = raw.StockUniverse
 	c.LookBack = raw.LookBack
 	c.MACDParams = raw.MACDParams
 	c.MomentumLookBack = raw.MomentumLookBack
+	c.Notifiers = raw.Notifiers
+	c.Alerts = raw.Alerts
+	c.Alerting = raw.Alerting
+	c.Calendar = raw.Calendar
 
-	c.Strategies = make([]*strategies.StrategyWeight, 0, len(raw.Strategies))
-	if len(raw.Strategies) == 0 {
-		return errors.New("at least one strategy must be specified")
+	// the strategies are still built without thresholds, which Validate requires
+	thresholds := c.Thresholds
+	if thresholds == nil {
+		thresholds = &strategies.Thresholds{}
 	}
-
+	c.configured = raw.Strategies
+	c.Strategies = make([]*strategies.StrategyWeight, 0, len(raw.Strategies))
 	for _, str := range raw.Strategies {
 		var s strategies.Strategy
-		switch str.Strategy {
-		case "BREAKOUT", "breakout":
-			s = strategies.NewBreakoutStrategy(c.Thresholds)
-		case "VWAP", "vwap":
+		switch strategyName(str.Strategy) {
+		case "BREAKOUT":
+			s = strategies.NewBreakoutStrategy(thresholds)
+		case "VWAP":
 			s = strategies.NewVWAPStrategy(c.LookBack)
-		case "MEANREVERSION", "meanreversion", "meanReversion", "mean-reversion":
-			s = strategies.NewMeanReversionStrategy(c.LookBack, c.Thresholds.Deviation)
-		case "BOLLINGER", "bollinger":
-			s = strategies.NewBollingerBandSqueezeStrategy(c.LookBack, c.BollingerCoefficient, c.Thresholds.Squeeze)
-		case "MACD", "macd":
+		case "MEANREVERSION":
+			s = strategies.NewMeanReversionStrategy(c.LookBack, thresholds.Deviation)
+		case "BOLLINGER":
+			s = strategies.NewBollingerBandSqueezeStrategy(c.LookBack, c.BollingerCoefficient, thresholds.Squeeze)
+		case "MACD":
 			s = strategies.NewMACDStrategy(c.MACDParams)
-		case "MOMENTUM", "momentum":
-			s = strategies.NewMomentumStrategy(c.MomentumLookBack, c.Thresholds)
+		case "MOMENTUM":
+			s = strategies.NewMomentumStrategy(c.MomentumLookBack, thresholds)
 		default:
-			return fmt.Errorf("unknown strategy %s", str.Strategy)
+			// reported by Validate
+			continue
 		}
 		c.Strategies = append(c.Strategies, &strategies.StrategyWeight{
 			Weight:   str.Weight,
@@ -85,3 +102,36 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// strategyName returns the name of the strategy configured as name, empty if unknown.
+func strategyName(name string) string {
+	switch name {
+	case "BREAKOUT", "breakout":
+		return "BREAKOUT"
+	case "VWAP", "vwap":
+		return "VWAP"
+	case "MEANREVERSION", "meanreversion", "meanReversion", "mean-reversion":
+		return "MEANREVERSION"
+	case "BOLLINGER", "bollinger":
+		return "BOLLINGER"
+	case "MACD", "macd":
+		return "MACD"
+	case "MOMENTUM", "momentum":
+		return "MOMENTUM"
+	default:
+		return ""
+	}
+}
+
+// configuredStrategies returns the strategies as configured, including the unknown ones left out of Strategies,
+// or the ones of Strategies if the configuration wasn't decoded.
+func (c *Config) configuredStrategies() []*rawStrategies {
+	if c.configured != nil {
+		return c.configured
+	}
+	out := make([]*rawStrategies, 0, len(c.Strategies))
+	for _, sw := range c.Strategies {
+		out = append(out, &rawStrategies{Strategy: sw.Name(), Weight: sw.Weight})
+	}
+	return out
+}
@@ -8,9 +8,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
+	"github.com/CanobbioE/algo-trading/pkg/alert"
 	"github.com/CanobbioE/algo-trading/pkg/config"
 	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/notify"
 	"github.com/CanobbioE/algo-trading/pkg/strategies"
 	"github.com/CanobbioE/algo-trading/pkg/utilities"
 )
@@ -99,6 +102,34 @@ func TestConfig_UnmarshalJSON(t *testing.T) {
 						MinVolume:        1000,
 						RequiredSignals:  1,
 					},
+					Notifiers: []*notify.Config{
+						{
+							Name: "me",
+							Type: "smtp",
+							SMTP: &notify.SMTPConfig{
+								Host:        "smtp.example.com",
+								Port:        587,
+								Security:    "starttls",
+								Username:    "alerts@example.com",
+								PasswordEnv: "ALGO_TRADING_SMTP_PASSWORD",
+								From:        "alerts@example.com",
+								To:          []string{"me@example.com", "you@example.com"},
+							},
+						},
+					},
+					Alerts: []*alert.Rule{
+						{
+							Name:      "macd volume",
+							Condition: utilities.MustReturn(alert.Compile("MACD == buy && Volume > 1e6 && Confidence > 0.6")),
+							Severity:  alert.SeverityCritical,
+							Notifiers: []string{"me"},
+						},
+						{
+							Name:         "gme above 30",
+							Symbols:      []string{"GME"},
+							CrossesAbove: utilities.ToPointer(30.0),
+						},
+					},
 				},
 			},
 		},
@@ -136,30 +167,6 @@ func TestConfig_UnmarshalJSON(t *testing.T) {
 				errMatcher: substringErrMatcher("failed to parse json raw"),
 			},
 		},
-		{
-			name: "fails with missing threshold",
-			data: []byte(`{}`),
-			want: &output{
-				wantErr:    true,
-				errMatcher: substringErrMatcher("no thresholds specified"),
-			},
-		},
-		{
-			name: "fails with missing strategies",
-			data: []byte(`{"Thresholds": {}}`),
-			want: &output{
-				wantErr:    true,
-				errMatcher: substringErrMatcher("at least one strategy must be specified"),
-			},
-		},
-		{
-			name: "fails with unknown strategies",
-			data: []byte(`{"Thresholds": {}, "Strategies": [{"strategy": "what's this?'"}]}`),
-			want: &output{
-				wantErr:    true,
-				errMatcher: substringErrMatcher("unknown strategy"),
-			},
-		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			got := &config.Config{}
@@ -191,8 +198,8 @@ func TestConfig_UnmarshalJSON(t *testing.T) {
 			}
 
 			diff := cmp.Diff(tc.want.cfg, got,
+				cmpopts.IgnoreUnexported(config.Config{}),
 				cmp.AllowUnexported(
-					config.Config{},
 					monitor.ScanFilters{},
 					strategies.Thresholds{},
 					strategies.MACDParams{},
@@ -201,7 +208,13 @@ func TestConfig_UnmarshalJSON(t *testing.T) {
 					strategies.MeanReversionStrategy{},
 					strategies.BollingerBandSqueezeStrategy{},
 					strategies.MomentumStrategy{},
-				), cmp.FilterPath(isStrategyField, compareStrategies))
+				), cmp.FilterPath(isStrategyField, compareStrategies),
+				cmp.Comparer(func(x, y *alert.Expression) bool {
+					if x == nil || y == nil {
+						return x == y
+					}
+					return x.String() == y.String()
+				}))
 
 			if diff != "" {
 				t.Errorf("unmarshal json result mismatch (-want +got):\n%s", diff)
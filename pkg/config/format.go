@@ -0,0 +1,258 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the encoding of a configuration file.
+type Format string
+
+// Available formats.
+const (
+	// FormatAuto detects the format from the extension of the file, JSON if unknown.
+	FormatAuto Format = ""
+	// FormatJSON is the default format of the configuration files.
+	FormatJSON Format = "json"
+	// FormatYAML allows comments, anchors and merge keys.
+	FormatYAML Format = "yaml"
+	// FormatTOML allows comments.
+	FormatTOML Format = "toml"
+)
+
+// ParseFormat returns the Format named s, FormatAuto if empty or auto.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatAuto, "auto":
+		return FormatAuto, nil
+	case FormatJSON, FormatYAML, FormatTOML:
+		return f, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("invalid config format %q, must be one of auto, json, yaml or toml", s)
+	}
+}
+
+// FormatOf returns the Format of the file at path from its extension, FormatAuto if unknown.
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatAuto
+	}
+}
+
+// Decode decodes the document read from r in the format f into v, JSON if FormatAuto.
+// YAML and TOML documents are converted to JSON first, so that they are decoded by the same
+// json.Unmarshaler implementations, with the same field names and the same validation.
+func Decode(r io.Reader, f Format, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if data, err = ToJSON(data, f); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Encode writes v to w in the format f, JSON if FormatAuto.
+func Encode(w io.Writer, f Format, v any) error {
+	switch f {
+	case FormatAuto, FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(fromJSON(v)); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case FormatTOML:
+		return toml.NewEncoder(w).Encode(fromJSON(v))
+	default:
+		return fmt.Errorf("invalid config format %q", f)
+	}
+}
+
+// fromJSON returns v with the json.Number values converted to integers or floats, as YAML and TOML
+// would otherwise encode them as strings.
+func fromJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = fromJSON(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = fromJSON(item)
+		}
+		return out
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		n, _ := v.Float64()
+		return n
+	default:
+		return v
+	}
+}
+
+// ToJSON converts the document data in the format f to JSON, returning JSON documents as they are.
+func ToJSON(data []byte, f Format) ([]byte, error) {
+	var v any
+	switch f {
+	case FormatAuto, FormatJSON:
+		return data, nil
+	case FormatYAML:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+		var err error
+		if v, err = fromYAML(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+	case FormatTOML:
+		if _, err := toml.Decode(string(data), &v); err != nil {
+			return nil, fmt.Errorf("failed to parse toml: %w", err)
+		}
+		v = fromTOML(v)
+	default:
+		return nil, fmt.Errorf("invalid config format %q", f)
+	}
+	return json.Marshal(v)
+}
+
+// fromYAML returns the value of n, keeping the timestamps as written: the configuration expects
+// dates and times as strings, in the formats they are documented with.
+func fromYAML(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return fromYAML(n.Content[0])
+	case yaml.AliasNode:
+		return fromYAML(n.Alias)
+	case yaml.SequenceNode:
+		out := make([]any, 0, len(n.Content))
+		for _, item := range n.Content {
+			v, err := fromYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case yaml.MappingNode:
+		out := make(map[string]any, len(n.Content)/2)
+		var merged []map[string]any
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := fromYAML(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			if n.Content[i].ShortTag() == "!!merge" {
+				if merged, err = appendMerged(merged, v); err != nil {
+					return nil, fmt.Errorf("line %d: %w", n.Content[i].Line, err)
+				}
+				continue
+			}
+			out[n.Content[i].Value] = v
+		}
+		// the keys of the mapping override the merged ones, the first merged mapping overrides the next ones
+		for _, m := range merged {
+			for k, v := range m {
+				if _, ok := out[k]; !ok {
+					out[k] = v
+				}
+			}
+		}
+		return out, nil
+	case yaml.ScalarNode:
+		if n.ShortTag() == "!!timestamp" {
+			return n.Value, nil
+		}
+		var v any
+		err := n.Decode(&v)
+		return v, err
+	default:
+		return nil, fmt.Errorf("line %d: unexpected yaml node", n.Line)
+	}
+}
+
+// appendMerged appends to merged the mappings of the value of a merge key, a mapping or a list of them.
+func appendMerged(merged []map[string]any, v any) ([]map[string]any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		return append(merged, v), nil
+	case []any:
+		for _, item := range v {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, errors.New("only mappings can be merged")
+			}
+			merged = append(merged, m)
+		}
+		return merged, nil
+	default:
+		return nil, errors.New("only mappings can be merged")
+	}
+}
+
+// fromTOML returns v with the TOML dates and times formatted as they were written,
+// since the configuration expects them as strings.
+func fromTOML(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = fromTOML(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = fromTOML(item)
+		}
+		return v
+	case []map[string]any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = fromTOML(item)
+		}
+		return out
+	case time.Time:
+		switch v.Location().String() {
+		case "date-local":
+			return v.Format(time.DateOnly)
+		case "time-local":
+			return v.Format(time.TimeOnly)
+		case "datetime-local":
+			return v.Format("2006-01-02T15:04:05")
+		default:
+			return v.Format(time.RFC3339)
+		}
+	default:
+		return v
+	}
+}
@@ -0,0 +1,211 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]config.Format{
+		"":     config.FormatAuto,
+		"auto": config.FormatAuto,
+		"JSON": config.FormatJSON,
+		"yml":  config.FormatYAML,
+		"yaml": config.FormatYAML,
+		"toml": config.FormatTOML,
+	} {
+		got, err := config.ParseFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, expected %q", in, got, err, want)
+		}
+	}
+	if _, err := config.ParseFormat("ini"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	for path, want := range map[string]config.Format{
+		"configs/value.json": config.FormatJSON,
+		"value.YAML":         config.FormatYAML,
+		"value.yml":          config.FormatYAML,
+		"value.toml":         config.FormatTOML,
+		"value.conf":         config.FormatAuto,
+	} {
+		if got := config.FormatOf(path); got != want {
+			t.Errorf("FormatOf(%q) = %q, expected %q", path, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	want := utilities.MustReturn((&config.Loader{}).Load("./testdata/full-config.json"))
+
+	type testCase struct {
+		name   string
+		path   string
+		format config.Format
+	}
+
+	for _, tc := range []testCase{
+		{name: "detects yaml", path: "./testdata/full-config.yaml"},
+		{name: "detects toml", path: "./testdata/full-config.toml"},
+		{name: "uses the requested format", path: "./testdata/full-config.yaml", format: config.FormatYAML},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := (&config.Loader{Format: tc.format, Strict: true}).Load(tc.path)
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if len(got.Strategies) != len(want.Strategies) {
+				t.Fatalf("expected %d strategies, instead got %d", len(want.Strategies), len(got.Strategies))
+			}
+			for i, sw := range got.Strategies {
+				if sw.Name() != want.Strategies[i].Name() || sw.Weight != want.Strategies[i].Weight {
+					t.Errorf("expected strategy %s with weight %f, instead got %s with weight %f",
+						want.Strategies[i].Name(), want.Strategies[i].Weight, sw.Name(), sw.Weight)
+				}
+			}
+			if diff := cmp.Diff(want.Thresholds, got.Thresholds); diff != "" {
+				t.Errorf("thresholds mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(want.Filters, got.Filters); diff != "" {
+				t.Errorf("filters mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(want.Notifiers, got.Notifiers); diff != "" {
+				t.Errorf("notifiers mismatch (-want +got):\n%s", diff)
+			}
+			if len(got.Alerts) != 2 || got.Alerts[0].Condition.String() != want.Alerts[0].Condition.String() {
+				t.Errorf("unexpected alerts: %+v", got.Alerts)
+			}
+		})
+	}
+
+	t.Run("ignores the extension when the format is requested", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "value.conf")
+		data := utilities.MustReturn(os.ReadFile("./testdata/full-config.toml"))
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		if _, err := (&config.Loader{Format: config.FormatTOML}).Load(path); err != nil {
+			t.Errorf("expected no error, instead got: %v", err)
+		}
+		if _, err := (&config.Loader{}).Load(path); err == nil {
+			t.Error("expected the toml file to fail to decode as json")
+		}
+	})
+
+	t.Run("validates every format the same way", func(t *testing.T) {
+		for format, data := range map[config.Format]string{
+			config.FormatJSON: `{"thresholds": {}, "strategies": [{"strategy": "RANDOM"}]}`,
+			config.FormatYAML: "thresholds: {}\nstrategies: [{strategy: RANDOM}]",
+			config.FormatTOML: "thresholds = {}\n[[strategies]]\nstrategy = \"RANDOM\"",
+		} {
+			_, err := (&config.Loader{}).Parse([]byte(data), format)
+			if err == nil || !strings.Contains(err.Error(), "unknown strategy RANDOM") {
+				t.Errorf("expected %s to fail with an unknown strategy, instead got: %v", format, err)
+			}
+		}
+	})
+}
+
+func TestToJSON(t *testing.T) {
+	type testCase struct {
+		name    string
+		data    string
+		want    string
+		wantErr string
+		format  config.Format
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:   "keeps json as it is",
+			format: config.FormatJSON,
+			data:   `{"lookback": 3}`,
+			want:   `{"lookback": 3}`,
+		},
+		{
+			name:   "keeps the yaml dates and times as written",
+			format: config.FormatYAML,
+			data:   "holidays:\n  - date: 2025-12-25\n    close: \"13:00\"\ndigest_at: 18:00",
+			want:   `{"holidays": [{"date": "2025-12-25", "close": "13:00"}], "digest_at": "18:00"}`,
+		},
+		{
+			name:   "merges the yaml mappings",
+			format: config.FormatYAML,
+			data: "base: &base {lookback: 3, momentum_lookback: 8}\n" +
+				"aggressive:\n  <<: *base\n  lookback: 2",
+			want: `{"base": {"lookback": 3, "momentum_lookback": 8}, "aggressive": {"lookback": 2, "momentum_lookback": 8}}`,
+		},
+		{
+			name:   "keeps the toml dates and times as written",
+			format: config.FormatTOML,
+			data:   "[[holidays]]\ndate = 2025-12-25\nclose = 13:00:00",
+			want:   `{"holidays": [{"date": "2025-12-25", "close": "13:00:00"}]}`,
+		},
+		{name: "fails with invalid yaml", format: config.FormatYAML, data: "a: [", wantErr: "failed to parse yaml"},
+		{name: "fails with invalid toml", format: config.FormatTOML, data: "a = ", wantErr: "failed to parse toml"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := config.ToJSON([]byte(tc.data), tc.format)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error to contain %q, instead got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+
+			var gotValue, wantValue any
+			if err = json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("expected valid json, instead got: %s", got)
+			}
+			utilities.Must(json.Unmarshal([]byte(tc.want), &wantValue))
+			if diff := cmp.Diff(wantValue, gotValue); diff != "" {
+				t.Errorf("ToJSON() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	doc, err := (&config.Loader{}).Render("./testdata/full-config.json")
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	want, err := json.Marshal(doc.Values)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	for _, format := range []config.Format{config.FormatJSON, config.FormatYAML, config.FormatTOML} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf strings.Builder
+			if err := config.Encode(&buf, format, doc.Values); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			got, err := config.ToJSON([]byte(buf.String()), format)
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+
+			var gotValue, wantValue any
+			utilities.Must(json.Unmarshal(got, &gotValue))
+			utilities.Must(json.Unmarshal(want, &wantValue))
+			if diff := cmp.Diff(wantValue, gotValue); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+)
+
+// Loader reads and validates configurations.
+//
+// A configuration can extend other files, being deep merged over them, include the stock_universe
+// of universe files, and define named profiles merged over it when selected.
+type Loader struct {
+	// Logger warns about the unknown fields when not Strict, slog.Default() if nil.
+	Logger *slog.Logger
+	// FS is where the files are read from, the operating system file system if nil.
+	FS fs.FS
+	// Format of the configuration files, detected from their extension if FormatAuto.
+	// The files they extend and include are always detected from their extension.
+	Format Format
+	// Profile is the name of the profile applied to the configurations, none if empty.
+	Profile string
+	// Strict rejects the configurations with unknown fields, instead of ignoring them.
+	Strict bool
+}
+
+// Load reads the configuration file at path.
+func (l *Loader) Load(path string) (*Config, error) {
+	doc, err := l.Render(path)
+	if err != nil {
+		return nil, err
+	}
+	return l.decode(doc.Values)
+}
+
+// Render resolves the configuration file at path, without decoding it.
+func (l *Loader) Render(path string) (*Document, error) {
+	data, err := l.readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	r := &resolver{loader: l}
+	values, err := r.file(path, data, l.Format)
+	if err != nil {
+		return nil, err
+	}
+	if values, err = profile(values, l.Profile); err != nil {
+		return nil, err
+	}
+	return &Document{Values: values, Files: r.files}, nil
+}
+
+// Parse decodes the configuration data in the format f and validates it.
+// The files it extends and includes are relative to the root of FS, or to the working directory.
+// Every problem found is reported at once by a *ValidationError, unless data is not a valid document.
+func (l *Loader) Parse(data []byte, f Format) (*Config, error) {
+	r := &resolver{loader: l}
+	values, err := r.document(data, f, ".")
+	if err != nil {
+		return nil, err
+	}
+	if values, err = profile(values, l.Profile); err != nil {
+		return nil, err
+	}
+	return l.decode(values)
+}
+
+// decode decodes the resolved configuration values and validates them.
+func (l *Loader) decode(values map[string]any) (*Config, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	unknown, err := UnknownFields(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	var problems []*FieldError
+	if l.Strict {
+		problems = unknown
+	} else {
+		for _, p := range unknown {
+			l.logger().Warn("ignoring unknown configuration field", "field", p.Path)
+		}
+	}
+
+	var cfg Config
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		problems = append(problems, &FieldError{Message: err.Error()})
+	} else {
+		var verr *ValidationError
+		if errors.As(cfg.Validate(), &verr) {
+			problems = append(problems, verr.Problems...)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", &ValidationError{Problems: problems})
+	}
+	return &cfg, nil
+}
+
+func (l *Loader) logger() *slog.Logger {
+	if l.Logger == nil {
+		return slog.Default()
+	}
+	return l.Logger
+}
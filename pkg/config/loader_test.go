@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+)
+
+func TestLoader_Parse(t *testing.T) {
+	const valid = `{"strategies": [{"strategy": "VWAP", "weight": 1}], "thresholds": {}, "scan_filters": {}, "lookback": 5`
+
+	type testCase struct {
+		name     string
+		data     string
+		wantWarn string
+		wantErr  string
+		want     []*config.FieldError
+		format   config.Format
+		strict   bool
+	}
+
+	for _, tc := range []testCase{
+		{name: "succeeds with valid config", data: valid + "}"},
+		{
+			name:     "warns about unknown fields",
+			data:     valid + `, "momentum_look_back": 8}`,
+			wantWarn: "field=momentum_look_back",
+		},
+		{
+			name:   "rejects unknown fields when strict",
+			data:   valid + `, "momentum_look_back": 8, "lookback": -1}`,
+			strict: true,
+			want: []*config.FieldError{
+				{Path: "momentum_look_back", Message: "unknown field"},
+				{Path: "lookback", Message: "must be positive for the VWAP strategy, -1 given"},
+			},
+		},
+		{
+			name:   "reports unknown fields next to decoding errors",
+			format: config.FormatYAML,
+			data:   "strategies: []\nthresholds: {}\nscan_filter: {}",
+			strict: true,
+			want: []*config.FieldError{
+				{Path: "scan_filter", Message: "unknown field"},
+				{Path: "strategies", Message: "at least one strategy must be specified"},
+				{Path: "scan_filters", Message: "required"},
+			},
+		},
+		{name: "fails with invalid json", data: valid, wantErr: "failed to decode configuration"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			l := &config.Loader{Strict: tc.strict, Logger: slog.New(slog.NewTextHandler(&logs, nil))}
+			_, err := l.Parse([]byte(tc.data), tc.format)
+			if !strings.Contains(logs.String(), tc.wantWarn) {
+				t.Errorf("expected a warning containing %q, instead got: %s", tc.wantWarn, logs.String())
+			}
+
+			var verr *config.ValidationError
+			switch {
+			case tc.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error to contain %q, instead got: %v", tc.wantErr, err)
+				}
+			case tc.want == nil:
+				if err != nil {
+					t.Fatalf("expected no error, instead got: %v", err)
+				}
+			case !errors.As(err, &verr):
+				t.Fatalf("expected a validation error, instead got: %v", err)
+			default:
+				if diff := cmp.Diff(tc.want, verr.Problems); diff != "" {
+					t.Errorf("problems mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// The fields composing a configuration out of several files, removed while resolving it.
+const (
+	// extendsField is the path, or the list of paths, of the configurations a file is merged over.
+	extendsField = "extends"
+	// includeField is the list of paths of the universe files whose stock_universe is added to the file one.
+	includeField = "include"
+	// profilesField maps the names of the profiles to the configurations merged over the file when selected.
+	profilesField = "profiles"
+	universeField = "stock_universe"
+)
+
+// Document is a configuration resolved from its files, as it is decoded.
+type Document struct {
+	// Values of the configuration, as decoded from JSON.
+	Values map[string]any
+	// Files the configuration is resolved from, the configuration file first.
+	Files []string
+}
+
+// resolver resolves the configuration files of a Loader, recording the files it reads.
+type resolver struct {
+	loader *Loader
+	// files are the ones read, each once.
+	files []string
+	// stack are the files being resolved, to detect the circular extends and includes.
+	stack []string
+}
+
+// resolve returns the values of the configuration file at path, merged over the files it extends
+// and with the universes it includes.
+func (r *resolver) resolve(path string) (map[string]any, error) {
+	data, err := r.loader.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.file(path, data, FormatAuto)
+}
+
+// file returns the values of the configuration file at path, whose content is data in the format f,
+// detected from its extension if FormatAuto.
+func (r *resolver) file(path string, data []byte, f Format) (map[string]any, error) {
+	path = filepath.Clean(path)
+	if slices.Contains(r.stack, path) {
+		return nil, fmt.Errorf("circular reference: %s -> %s", strings.Join(r.stack, " -> "), path)
+	}
+	if f == FormatAuto {
+		f = FormatOf(path)
+	}
+
+	if !slices.Contains(r.files, path) {
+		r.files = append(r.files, path)
+	}
+	r.stack = append(r.stack, path)
+	defer func() {
+		r.stack = r.stack[:len(r.stack)-1]
+	}()
+	return r.document(data, f, filepath.Dir(path))
+}
+
+// document returns the values of the configuration data, whose extends and includes are relative to dir.
+func (r *resolver) document(data []byte, f Format, dir string) (map[string]any, error) {
+	values, err := decodeValues(data, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	extends, err := paths(values, extendsField)
+	if err != nil {
+		return nil, err
+	}
+	base := map[string]any{}
+	for _, p := range extends {
+		v, err := r.resolve(relativeTo(dir, p))
+		if err != nil {
+			return nil, fmt.Errorf("failed to extend %s: %w", p, err)
+		}
+		base = merge(base, v)
+	}
+
+	if err = r.include(values, dir); err != nil {
+		return nil, err
+	}
+	if profiles, ok := values[profilesField].(map[string]any); ok {
+		for name, profile := range profiles {
+			p, ok := profile.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("profile %q must be an object", name)
+			}
+			if err = r.include(p, dir); err != nil {
+				return nil, fmt.Errorf("profile %q: %w", name, err)
+			}
+		}
+	}
+	return merge(base, values), nil
+}
+
+// include adds to the stock_universe of values the one of the universe files it includes.
+func (r *resolver) include(values map[string]any, dir string) error {
+	includes, err := paths(values, includeField)
+	if err != nil || len(includes) == 0 {
+		return err
+	}
+
+	universe, ok := values[universeField].([]any)
+	if _, set := values[universeField]; set && !ok {
+		return errors.New("stock_universe must be a list")
+	}
+	for _, p := range includes {
+		v, err := r.resolve(relativeTo(dir, p))
+		if err != nil {
+			return fmt.Errorf("failed to include %s: %w", p, err)
+		}
+		symbols, ok := v[universeField].([]any)
+		if !ok || len(v) != 1 {
+			return fmt.Errorf("failed to include %s: universe files must only define a stock_universe list", p)
+		}
+		for _, s := range symbols {
+			if !slices.Contains(universe, s) {
+				universe = append(universe, s)
+			}
+		}
+	}
+	values[universeField] = universe
+	return nil
+}
+
+// profile merges the profile with the given name over values, removing the profiles from them.
+func profile(values map[string]any, name string) (map[string]any, error) {
+	profiles, ok := values[profilesField].(map[string]any)
+	if _, set := values[profilesField]; set && !ok {
+		return nil, errors.New("profiles must be an object")
+	}
+	delete(values, profilesField)
+	if name == "" {
+		return values, nil
+	}
+	p, ok := profiles[name].(map[string]any)
+	if !ok {
+		names := slices.Sorted(maps.Keys(profiles))
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown profile %q, the configuration defines none", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q, must be one of %s", name, strings.Join(names, ", "))
+	}
+	return merge(values, p), nil
+}
+
+// merge returns the deep merge of src over dst: the objects are merged field by field, while the other values,
+// lists included, replace the ones of dst. A null value removes the field.
+func merge(dst, src map[string]any) map[string]any {
+	out := make(map[string]any, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		if m, ok := v.(map[string]any); ok {
+			base, _ := out[k].(map[string]any)
+			v = merge(base, m)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// paths removes the field with the given name from values, returning its path or list of paths.
+func paths(values map[string]any, field string) ([]string, error) {
+	v, ok := values[field]
+	delete(values, field)
+	if !ok {
+		return nil, nil
+	}
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a path or a list of paths", field)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%s must be a path or a list of paths", field)
+	}
+}
+
+// decodeValues decodes the object in data, in the format f, keeping the numbers as written.
+func decodeValues(data []byte, f Format) (map[string]any, error) {
+	data, err := ToJSON(data, f)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values map[string]any
+	if err = decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, errors.New("the configuration must be an object")
+	}
+	return values, nil
+}
+
+// relativeTo returns path relative to dir, unless absolute.
+func relativeTo(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func (l *Loader) readFile(path string) ([]byte, error) {
+	if l.FS == nil {
+		return os.ReadFile(path)
+	}
+	return fs.ReadFile(l.FS, filepath.ToSlash(path))
+}
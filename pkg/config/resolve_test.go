@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// composed are configurations extending and including each other.
+var composed = fstest.MapFS{
+	"base.json": {Data: []byte(`{
+		"include": "universes/italy.json",
+		"strategies": [{"strategy": "VWAP", "weight": 1}],
+		"thresholds": {"atr_period": 3, "deviation": 0.03},
+		"macd_params": {"fast_period": 12, "slow_period": 26, "signal_period": 9},
+		"scan_filters": {"max_risk": "HIGH", "min_volume": 1000},
+		"profiles": {"tight": {"scan_filters": {"max_risk": "LOW"}}}
+	}`)},
+	"universes/italy.json":   {Data: []byte(`{"stock_universe": ["ENI.MTA", "A2A.MTA"]}`)},
+	"universes/germany.yaml": {Data: []byte("stock_universe: [SAP.DE, ENI.MTA]")},
+	"child.yaml": {Data: []byte(`
+extends: base.json
+include: [universes/italy.json, universes/germany.yaml]
+stock_universe: [LDO.MTA]
+thresholds: {atr_period: 5}
+macd_params: null
+profiles:
+  tight: {scan_filters: {min_volume: 5000}}
+  momentum:
+    strategies: [{strategy: MOMENTUM, weight: 2}]
+    include: universes/germany.yaml
+`)},
+	"loop.json":     {Data: []byte(`{"extends": "loop-via.json"}`)},
+	"loop-via.json": {Data: []byte(`{"extends": "loop.json"}`)},
+	"bad-universe.json": {Data: []byte(`{
+		"include": "base.json",
+		"strategies": [{"strategy": "VWAP", "weight": 1}]
+	}`)},
+}
+
+func TestLoader_Render(t *testing.T) {
+	type testCase struct {
+		name      string
+		path      string
+		profile   string
+		want      string
+		wantErr   string
+		wantFiles []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "merges over the extended file",
+			path: "child.yaml",
+			want: `{
+				"stock_universe": ["LDO.MTA", "ENI.MTA", "A2A.MTA", "SAP.DE"],
+				"strategies": [{"strategy": "VWAP", "weight": 1}],
+				"thresholds": {"atr_period": 5, "deviation": 0.03},
+				"scan_filters": {"max_risk": "HIGH", "min_volume": 1000}
+			}`,
+			wantFiles: []string{"child.yaml", "base.json", "universes/italy.json", "universes/germany.yaml"},
+		},
+		{
+			name:    "merges the profiles by name",
+			path:    "child.yaml",
+			profile: "tight",
+			want: `{
+				"stock_universe": ["LDO.MTA", "ENI.MTA", "A2A.MTA", "SAP.DE"],
+				"strategies": [{"strategy": "VWAP", "weight": 1}],
+				"thresholds": {"atr_period": 5, "deviation": 0.03},
+				"scan_filters": {"max_risk": "LOW", "min_volume": 5000}
+			}`,
+		},
+		{
+			name:    "replaces the lists with the profile ones",
+			path:    "child.yaml",
+			profile: "momentum",
+			want: `{
+				"stock_universe": ["SAP.DE", "ENI.MTA"],
+				"strategies": [{"strategy": "MOMENTUM", "weight": 2}],
+				"thresholds": {"atr_period": 5, "deviation": 0.03},
+				"scan_filters": {"max_risk": "HIGH", "min_volume": 1000}
+			}`,
+		},
+		{
+			name:    "fails with unknown profile",
+			path:    "child.yaml",
+			profile: "loose",
+			wantErr: `unknown profile "loose", must be one of momentum, tight`,
+		},
+		{
+			name:    "fails with circular extends",
+			path:    "loop.json",
+			wantErr: "circular reference: loop.json -> loop-via.json -> loop.json",
+		},
+		{
+			name:    "fails including a configuration",
+			path:    "bad-universe.json",
+			wantErr: "failed to include base.json: universe files must only define a stock_universe list",
+		},
+		{
+			name:    "fails with missing file",
+			path:    "missing.json",
+			wantErr: "failed to open configuration file",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &config.Loader{FS: composed, Profile: tc.profile}
+			got, err := l.Render(tc.path)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error to contain %q, instead got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+
+			var gotValues, wantValues any
+			if err = json.Unmarshal(mustMarshal(t, got.Values), &gotValues); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if err = json.Unmarshal([]byte(tc.want), &wantValues); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if diff := cmp.Diff(wantValues, gotValues); diff != "" {
+				t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+			}
+			if tc.wantFiles != nil {
+				if diff := cmp.Diff(tc.wantFiles, got.Files); diff != "" {
+					t.Errorf("files mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestLoader_Parse_composed(t *testing.T) {
+	l := &config.Loader{FS: composed, Profile: "tight", Strict: true}
+	cfg, err := l.Parse([]byte(`{"extends": "base.json", "lookback": 4}`), config.FormatJSON)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	if diff := cmp.Diff([]string{"ENI.MTA", "A2A.MTA"}, cfg.StockUniverse); diff != "" {
+		t.Errorf("universe mismatch (-want +got):\n%s", diff)
+	}
+	if cfg.LookBack != 4 || cfg.Filters.MinVolume != 1000 || cfg.Filters.MaxRisk != monitor.RiskLow {
+		t.Errorf("expected the posted configuration merged over base.json, instead got: %+v", cfg)
+	}
+
+	if _, err = l.Parse([]byte(`{"extends": "../base.json"}`), config.FormatJSON); err == nil {
+		t.Error("expected an error extending a file outside of the file system")
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	return data
+}
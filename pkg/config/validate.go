@@ -0,0 +1,274 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/CanobbioE/algo-trading/pkg/notify"
+)
+
+// FieldError is a problem with the field of a configuration.
+type FieldError struct {
+	// Path is the JSON path of the field, e.g. strategies[0].weight, empty for the whole configuration.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError reports every problem found in a configuration.
+type ValidationError struct {
+	Problems []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].Error()
+	}
+	lines := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		lines = append(lines, "\n  - "+p.Error())
+	}
+	return fmt.Sprintf("%d problems found:%s", len(e.Problems), strings.Join(lines, ""))
+}
+
+// Validate reports the problems decoding the configuration doesn't catch, which would make the strategies
+// or the scans fail, all at once.
+func (c *Config) Validate() error {
+	var v validator
+	configured := c.configuredStrategies()
+	if len(configured) == 0 {
+		v.add("strategies", "at least one strategy must be specified")
+	}
+	// the signals of a scan are keyed by strategy, a second one would overwrite the first
+	seen := make(map[string]int, len(configured))
+	for i, str := range configured {
+		if str.Weight <= 0 {
+			v.add(fmt.Sprintf("strategies[%d].weight", i), "must be positive, %g given", str.Weight)
+		}
+		name := strategyName(str.Strategy)
+		if name == "" {
+			v.add(fmt.Sprintf("strategies[%d].strategy", i), "unknown strategy %s", str.Strategy)
+			continue
+		}
+		if j, ok := seen[name]; ok {
+			v.add(fmt.Sprintf("strategies[%d].strategy", i), "%s is already configured by strategies[%d]", name, j)
+			continue
+		}
+		seen[name] = i
+	}
+
+	// the strategies averaging over the lookback divide by it
+	if by := c.usedBy("VWAP", "MEANREVERSION", "BOLLINGER"); by != "" {
+		v.requiredPositive("lookback", c.LookBack, by)
+	} else {
+		notNegative(&v, "lookback", c.LookBack)
+	}
+	if c.uses("MOMENTUM") {
+		v.requiredPositive("momentum_lookback", c.MomentumLookBack, "MOMENTUM")
+	} else {
+		notNegative(&v, "momentum_lookback", c.MomentumLookBack)
+	}
+	notNegative(&v, "bollinger_coefficient", c.BollingerCoefficient)
+	if c.Thresholds == nil {
+		v.add("thresholds", "required")
+	} else {
+		notNegative(&v, "thresholds.atr_period", c.Thresholds.AtrPeriod)
+		notNegative(&v, "thresholds.low_lookback", c.Thresholds.LowLookback)
+		notNegative(&v, "thresholds.high_lookback", c.Thresholds.HighLookback)
+	}
+
+	switch p := c.MACDParams; {
+	case p == nil && c.uses("MACD"):
+		v.add("macd_params", "required by the MACD strategy")
+	case p != nil:
+		v.positive("macd_params.fast_period", p.FastPeriod)
+		v.positive("macd_params.slow_period", p.SlowPeriod)
+		v.positive("macd_params.signal_period", p.SignalPeriod)
+		if p.FastPeriod >= p.SlowPeriod {
+			v.add("macd_params.fast_period", "must be lower than slow_period (%d), %d given", p.SlowPeriod, p.FastPeriod)
+		}
+	}
+
+	if c.Filters == nil {
+		v.add("scan_filters", "required")
+	}
+
+	for i, rule := range c.Alerts {
+		for j, name := range rule.Notifiers {
+			if !slices.ContainsFunc(c.Notifiers, func(n *notify.Config) bool { return n.Name == name }) {
+				v.add(fmt.Sprintf("alerts[%d].notifiers[%d]", i, j), "unknown notifier %q", name)
+			}
+		}
+		// the digest alerts are only ever delivered at the digest time
+		if rule.Digest && (c.Alerting == nil || c.Alerting.DigestAt == nil) {
+			v.add(fmt.Sprintf("alerts[%d].digest", i), "requires alerting.digest_at")
+		}
+	}
+	return v.err()
+}
+
+// uses reports whether the configuration runs the strategy with the given name.
+func (c *Config) uses(name string) bool {
+	for _, sw := range c.Strategies {
+		if sw.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// usedBy returns the first of the strategies with the given names the configuration runs, empty if none.
+func (c *Config) usedBy(names ...string) string {
+	for _, sw := range c.Strategies {
+		if slices.Contains(names, sw.Name()) {
+			return sw.Name()
+		}
+	}
+	return ""
+}
+
+// validator collects the problems of a configuration.
+type validator struct {
+	problems []*FieldError
+}
+
+func (v *validator) add(path, format string, args ...any) {
+	v.problems = append(v.problems, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func notNegative[T int | float64](v *validator, path string, n T) {
+	if n < 0 {
+		v.add(path, "cannot be negative, %v given", n)
+	}
+}
+
+func (v *validator) positive(path string, n int) {
+	if n <= 0 {
+		v.add(path, "must be positive, %d given", n)
+	}
+}
+
+// requiredPositive reports n unless positive, as the strategy by requires it.
+func (v *validator) requiredPositive(path string, n int, by string) {
+	if n <= 0 {
+		v.add(path, "must be positive for the %s strategy, %d given", by, n)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// rawDecoder is implemented by the types of a configuration decoding their JSON into a different struct,
+// which is returned by RawConfig, so that the fields of their JSON can be checked.
+type rawDecoder interface {
+	RawConfig() any
+}
+
+var (
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	rawDecoderType  = reflect.TypeFor[rawDecoder]()
+)
+
+// UnknownFields returns a problem for every field of the JSON configuration data that decoding it ignores,
+// typically a misspelled one.
+func UnknownFields(data []byte) ([]*FieldError, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var v validator
+	v.unknownFields(doc, reflect.TypeFor[rawCfg](), "")
+	return v.problems, nil
+}
+
+func (v *validator) unknownFields(doc any, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(rawDecoderType) {
+		raw := reflect.New(t).Interface().(rawDecoder).RawConfig()
+		t = reflect.TypeOf(raw)
+	} else if reflect.PointerTo(t).Implements(unmarshalerType) {
+		// decoded from a value the type checks itself, such as an enum
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			value := obj[key]
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				v.add(join(path, key), "unknown field")
+				continue
+			}
+			v.unknownFields(value, field, join(path, key))
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := doc.([]any)
+		if !ok {
+			return
+		}
+		for i, item := range items {
+			v.unknownFields(item, t.Elem(), path+"["+strconv.Itoa(i)+"]")
+		}
+	case reflect.Map:
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			v.unknownFields(obj[key], t.Elem(), join(path, key))
+		}
+	default:
+	}
+}
+
+// jsonFields maps the lowercase JSON names of the fields of the struct t to their types,
+// as encoding/json matches them case-insensitively.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	out := make(map[string]reflect.Type, t.NumField())
+	for _, f := range reflect.VisibleFields(t) {
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			// its fields are promoted
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[strings.ToLower(name)] = f.Type
+	}
+	return out
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,204 @@
+package config_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	type testCase struct {
+		name string
+		data string
+		want []*config.FieldError
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "succeeds with valid config",
+			data: `{
+				"strategies": [{"strategy": "MACD", "weight": 1}, {"strategy": "MOMENTUM", "weight": 0.5}],
+				"thresholds": {"atr_period": 3},
+				"macd_params": {"fast_period": 12, "slow_period": 26, "signal_period": 9},
+				"scan_filters": {},
+				"momentum_lookback": 8
+			}`,
+		},
+		{
+			name: "reports every problem",
+			data: `{
+				"strategies": [{"strategy": "MACD", "weight": 0}, {"strategy": "VWAP", "weight": -1}],
+				"thresholds": {"atr_period": -3, "low_lookback": -1},
+				"lookback": -5
+			}`,
+			want: []*config.FieldError{
+				{Path: "strategies[0].weight", Message: "must be positive, 0 given"},
+				{Path: "strategies[1].weight", Message: "must be positive, -1 given"},
+				{Path: "lookback", Message: "must be positive for the VWAP strategy, -5 given"},
+				{Path: "thresholds.atr_period", Message: "cannot be negative, -3 given"},
+				{Path: "thresholds.low_lookback", Message: "cannot be negative, -1 given"},
+				{Path: "macd_params", Message: "required by the MACD strategy"},
+				{Path: "scan_filters", Message: "required"},
+			},
+		},
+		{
+			name: "fails with fast period not lower than slow period",
+			data: `{
+				"strategies": [{"strategy": "MACD", "weight": 1}],
+				"thresholds": {},
+				"macd_params": {"fast_period": 26, "slow_period": 26, "signal_period": 0},
+				"scan_filters": {}
+			}`,
+			want: []*config.FieldError{
+				{Path: "macd_params.signal_period", Message: "must be positive, 0 given"},
+				{Path: "macd_params.fast_period", Message: "must be lower than slow_period (26), 26 given"},
+			},
+		},
+		{
+			name: "fails with the same strategy configured twice",
+			data: `{
+				"strategies": [{"strategy": "VWAP", "weight": 1}, {"strategy": "MOMENTUM", "weight": 1}, {"strategy": "VWAP", "weight": 2}],
+				"thresholds": {},
+				"lookback": 5,
+				"momentum_lookback": 8,
+				"scan_filters": {}
+			}`,
+			want: []*config.FieldError{
+				{Path: "strategies[2].strategy", Message: "VWAP is already configured by strategies[0]"},
+			},
+		},
+		{
+			name: "reports the problems decoding doesn't catch",
+			data: `{
+				"strategies": [{"strategy": "RANDOM", "weight": 1}, {"strategy": "vwap", "weight": 1}],
+				"scan_filters": {},
+				"lookback": 5,
+				"alerts": [{"name": "x", "condition": "true", "notifiers": ["nobody"]}]
+			}`,
+			want: []*config.FieldError{
+				{Path: "strategies[0].strategy", Message: "unknown strategy RANDOM"},
+				{Path: "thresholds", Message: "required"},
+				{Path: "alerts[0].notifiers[0]", Message: `unknown notifier "nobody"`},
+			},
+		},
+		{
+			name: "fails without strategies",
+			data: `{"thresholds": {}, "scan_filters": {}}`,
+			want: []*config.FieldError{
+				{Path: "strategies", Message: "at least one strategy must be specified"},
+			},
+		},
+		{
+			name: "fails with digest alerts without digest time",
+			data: `{
+				"strategies": [{"strategy": "VWAP", "weight": 1}],
+				"thresholds": {},
+				"scan_filters": {},
+				"lookback": 5,
+				"alerts": [
+					{"name": "now", "condition": "Score > 1"},
+					{"name": "daily", "condition": "Score > 1", "digest": true}
+				]
+			}`,
+			want: []*config.FieldError{
+				{Path: "alerts[1].digest", Message: "requires alerting.digest_at"},
+			},
+		},
+		{
+			name: "ignores macd params without the MACD strategy",
+			data: `{"strategies": [{"strategy": "VWAP", "weight": 1}], "thresholds": {}, "scan_filters": {}, "lookback": 5}`,
+		},
+		{
+			name: "fails without the lookbacks of the strategies using them",
+			data: `{
+				"strategies": [{"strategy": "MEANREVERSION", "weight": 1}, {"strategy": "MOMENTUM", "weight": 1}],
+				"thresholds": {},
+				"scan_filters": {}
+			}`,
+			want: []*config.FieldError{
+				{Path: "lookback", Message: "must be positive for the MEANREVERSION strategy, 0 given"},
+				{Path: "momentum_lookback", Message: "must be positive for the MOMENTUM strategy, 0 given"},
+			},
+		},
+		{
+			name: "fails without the lookback of the bollinger strategy",
+			data: `{"strategies": [{"strategy": "BOLLINGER", "weight": 1}], "thresholds": {}, "scan_filters": {}}`,
+			want: []*config.FieldError{
+				{Path: "lookback", Message: "must be positive for the BOLLINGER strategy, 0 given"},
+			},
+		},
+		{
+			name: "accepts no lookbacks without the strategies using them",
+			data: `{"strategies": [{"strategy": "BREAKOUT", "weight": 1}], "thresholds": {}, "scan_filters": {}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg config.Config
+			if err := json.Unmarshal([]byte(tc.data), &cfg); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			err := cfg.Validate()
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("expected no error, instead got: %v", err)
+				}
+				return
+			}
+			var verr *config.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a validation error, instead got: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, verr.Problems); diff != "" {
+				t.Errorf("problems mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	type testCase struct {
+		name string
+		data string
+		want []*config.FieldError
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "accepts known fields in any case",
+			data: `{"Lookback": 3, "strategies": [{"STRATEGY": "VWAP"}], "scan_filters": {"max_risk": "HIGH"}}`,
+		},
+		{
+			name: "reports the paths of the unknown fields",
+			data: `{
+				"momentum_look_back": 8,
+				"strategies": [{"strategy": "VWAP"}, {"strategy": "MACD", "wieght": 1}],
+				"thresholds": {"atr": 3},
+				"notifiers": [{"name": "ops", "webhook": {"url": "http://example.com", "channel": "ops"}}],
+				"alerts": [{"name": "x", "condition": "true", "severity": "info", "cooldown_for": "1h"}],
+				"alerting": {"quiet_hours": {"start": "22:00", "stop": "07:00"}}
+			}`,
+			want: []*config.FieldError{
+				{Path: "alerting.quiet_hours.stop", Message: "unknown field"},
+				{Path: "alerts[0].cooldown_for", Message: "unknown field"},
+				{Path: "momentum_look_back", Message: "unknown field"},
+				{Path: "notifiers[0].webhook.channel", Message: "unknown field"},
+				{Path: "strategies[1].wieght", Message: "unknown field"},
+				{Path: "thresholds.atr", Message: "unknown field"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := config.UnknownFields([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unknown fields mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+// DefaultPath returns the default location of the history database, in the user data directory:
+// $XDG_DATA_HOME/algo-trading/history.db, or ~/.local/share/algo-trading/history.db.
+// It returns an empty path, which disables the history, if the home directory is unknown.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "algo-trading", "history.db")
+}
+
+// Run is a single scan of the stock universe.
+type Run struct {
+	StartedAt  time.Time     `json:"started_at"`
+	Command    string        `json:"command"`
+	ConfigHash string        `json:"config_hash"`
+	ID         uint64        `json:"id"`
+	Duration   time.Duration `json:"duration"`
+	Scanned    int           `json:"scanned"`
+	Matched    int           `json:"matched"`
+}
+
+// Record is the score of a single symbol in a Run.
+type Record struct {
+	Timestamp     time.Time                    `json:"timestamp"`
+	Signals       map[string]signals.Operation `json:"signals"`
+	Symbol        string                       `json:"symbol"`
+	ConfigHash    string                       `json:"config_hash"`
+	RunID         uint64                       `json:"run_id"`
+	WeightedScore float64                      `json:"weighted_score"`
+	Confidence    float64                      `json:"confidence"`
+	LastPrice     float64                      `json:"last_price"`
+	Volume        float64                      `json:"volume"`
+	Risk          monitor.RiskLevel            `json:"risk"`
+	Opportunity   monitor.OpportunityLevel     `json:"opportunity"`
+	// MeetsCriteria is true if the score passed the scan filters.
+	MeetsCriteria bool `json:"meets_criteria"`
+}
+
+// NewRecord creates a Record for a score computed during run.
+func NewRecord(run *Run, score *monitor.StockScore, meetsCriteria bool) *Record {
+	return &Record{
+		Timestamp:     run.StartedAt,
+		Signals:       score.Signals,
+		Symbol:        score.Symbol,
+		ConfigHash:    run.ConfigHash,
+		RunID:         run.ID,
+		WeightedScore: score.WeightedScore,
+		Confidence:    score.Confidence,
+		LastPrice:     score.LastPrice,
+		Volume:        score.Volume,
+		Risk:          score.Risk,
+		Opportunity:   score.Opportunity,
+		MeetsCriteria: meetsCriteria,
+	}
+}
@@ -0,0 +1,273 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+)
+
+var (
+	runsBucket   = []byte("runs")
+	scoresBucket = []byte("scores")
+	stateBucket  = []byte("state")
+	alertsBucket = []byte("alerts")
+)
+
+// lockTimeout is how long to wait for another process holding the database.
+const lockTimeout = 5 * time.Second
+
+// Store persists scan runs and scores into an embedded bbolt database.
+// The database is only held open for the duration of each operation, so that
+// a long-running monitor and the history command can share it.
+type Store struct {
+	path string
+}
+
+// NewStore creates a new Store backed by the database at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// SaveRun stores run and the records of its scores, assigning the run ID to a new run.
+// Saving a run that already has an ID updates it and adds the records to the ones it has.
+func (s *Store) SaveRun(run *Run, records []*Record) error {
+	return s.update(func(tx *bolt.Tx) error {
+		runs, err := tx.CreateBucketIfNotExists(runsBucket)
+		if err != nil {
+			return err
+		}
+		scores, err := tx.CreateBucketIfNotExists(scoresBucket)
+		if err != nil {
+			return err
+		}
+
+		if run.ID == 0 {
+			if run.ID, err = runs.NextSequence(); err != nil {
+				return err
+			}
+		}
+		if err = putJSON(runs, itob(run.ID), run); err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			r.RunID = run.ID
+			symbol, err := scores.CreateBucketIfNotExists([]byte(strings.ToUpper(r.Symbol)))
+			if err != nil {
+				return err
+			}
+			if err = putJSON(symbol, recordKey(r), r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Runs returns the latest runs, most recent first. A limit of 0 returns all runs.
+func (s *Store) Runs(limit int) ([]*Run, error) {
+	var out []*Run
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(runsBucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil && (limit <= 0 || len(out) < limit); k, v = c.Prev() {
+			var r Run
+			if err := json.Unmarshal(v, &r); err != nil {
+				return fmt.Errorf("failed to decode run: %w", err)
+			}
+			out = append(out, &r)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Timeline returns the records of symbol between from and to, oldest first.
+// A zero to means up to now.
+func (s *Store) Timeline(symbol string, from, to time.Time) ([]*Record, error) {
+	var out []*Record
+	err := s.view(func(tx *bolt.Tx) error {
+		scores := tx.Bucket(scoresBucket)
+		if scores == nil {
+			return nil
+		}
+		b := scores.Bucket([]byte(strings.ToUpper(symbol)))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.Seek(timeKey(from)); k != nil; k, v = c.Next() {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return fmt.Errorf("failed to decode record: %w", err)
+			}
+			if !to.IsZero() && r.Timestamp.After(to) {
+				break
+			}
+			out = append(out, &r)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// RunRecords returns the records stored for the run with the given ID, sorted by symbol, then chronologically.
+func (s *Store) RunRecords(id uint64) ([]*Record, error) {
+	var out []*Record
+	err := s.view(func(tx *bolt.Tx) error {
+		scores := tx.Bucket(scoresBucket)
+		if scores == nil {
+			return nil
+		}
+		return scores.ForEachBucket(func(symbol []byte) error {
+			return scores.Bucket(symbol).ForEach(func(k, v []byte) error {
+				if binary.BigEndian.Uint64(k[8:]) != id {
+					return nil
+				}
+				var r Record
+				if err := json.Unmarshal(v, &r); err != nil {
+					return fmt.Errorf("failed to decode record: %w", err)
+				}
+				out = append(out, &r)
+				return nil
+			})
+		})
+	})
+	slices.SortStableFunc(out, func(a, b *Record) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+	return out, err
+}
+
+// SaveState stores v as JSON under name, replacing the previous value.
+// It lets long-running commands persist what they need to resume after a restart.
+func (s *Store) SaveState(name string, v any) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(stateBucket)
+		if err != nil {
+			return err
+		}
+		return putJSON(b, []byte(name), v)
+	})
+}
+
+// LoadState decodes the value stored under name into v, reporting whether there was one.
+func (s *Store) LoadState(name string, v any) (bool, error) {
+	var found bool
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(stateBucket)
+		if b == nil {
+			return nil
+		}
+		data := b.Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+		found = true
+		if err := json.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("failed to decode state %s: %w", name, err)
+		}
+		return nil
+	})
+	return found, err
+}
+
+// SaveAlert appends n to the log of the delivered alerts.
+func (s *Store) SaveAlert(n *alert.Notification) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(alertsBucket)
+		if err != nil {
+			return err
+		}
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		return putJSON(b, append(timeKey(n.Timestamp), itob(id)...), n)
+	})
+}
+
+// Alerts returns the latest delivered alerts, most recent first. A limit of 0 returns all alerts.
+func (s *Store) Alerts(limit int) ([]*alert.Notification, error) {
+	var out []*alert.Notification
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(alertsBucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil && (limit <= 0 || len(out) < limit); k, v = c.Prev() {
+			var n alert.Notification
+			if err := json.Unmarshal(v, &n); err != nil {
+				return fmt.Errorf("failed to decode alert: %w", err)
+			}
+			out = append(out, &n)
+		}
+		return nil
+	})
+	return out, err
+}
+
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	db, err := bolt.Open(s.path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open history database: %w", err)
+	}
+	return errors.Join(db.Update(fn), db.Close())
+}
+
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	if _, err := os.Stat(s.path); errors.Is(err, fs.ErrNotExist) {
+		// nothing was recorded yet
+		return nil
+	}
+	db, err := bolt.Open(s.path, 0o600, &bolt.Options{Timeout: lockTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open history database: %w", err)
+	}
+	return errors.Join(db.View(fn), db.Close())
+}
+
+func putJSON(b *bolt.Bucket, key []byte, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, data)
+}
+
+// recordKey sorts the records of a symbol chronologically.
+func recordKey(r *Record) []byte {
+	return append(timeKey(r.Timestamp), itob(r.RunID)...)
+}
+
+func timeKey(t time.Time) []byte {
+	if t.IsZero() {
+		return make([]byte, 8)
+	}
+	return itob(uint64(t.UnixNano()))
+}
+
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
@@ -0,0 +1,204 @@
+package history_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/history"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+func TestStore(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("returns nothing before the first run", func(t *testing.T) {
+		runs, err := store.Runs(0)
+		if err != nil || len(runs) != 0 {
+			t.Errorf("expected no runs and no error, instead got %v, %v", runs, err)
+		}
+	})
+
+	for i, price := range []float64{13.1, 13.4, 12.9} {
+		run := &history.Run{
+			StartedAt:  start.Add(time.Duration(i) * time.Hour),
+			Command:    "monitor",
+			ConfigHash: "abc",
+			Scanned:    2,
+			Matched:    1,
+		}
+		records := []*history.Record{
+			history.NewRecord(run, &monitor.StockScore{
+				Signals:       map[string]signals.Operation{"VWAP": signals.Buy},
+				Symbol:        "ENI.MTA",
+				WeightedScore: float64(i),
+				LastPrice:     price,
+				Risk:          monitor.RiskMedium,
+				Opportunity:   monitor.OpportunityHigh,
+			}, true),
+			history.NewRecord(run, &monitor.StockScore{Symbol: "A2A.MTA", LastPrice: 2}, false),
+		}
+		if err := store.SaveRun(run, records); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+	}
+
+	t.Run("lists the latest runs first", func(t *testing.T) {
+		runs, err := store.Runs(2)
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		if len(runs) != 2 || runs[0].ID != 3 || runs[1].ID != 2 {
+			t.Errorf("unexpected runs: %+v", runs)
+		}
+	})
+
+	t.Run("returns the timeline of a symbol", func(t *testing.T) {
+		got, err := store.Timeline("eni.mta", start.Add(time.Hour), time.Time{})
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		want := []*history.Record{
+			{
+				Timestamp:     start.Add(time.Hour),
+				Signals:       map[string]signals.Operation{"VWAP": signals.Buy},
+				Symbol:        "ENI.MTA",
+				ConfigHash:    "abc",
+				RunID:         2,
+				WeightedScore: 1,
+				LastPrice:     13.4,
+				Risk:          monitor.RiskMedium,
+				Opportunity:   monitor.OpportunityHigh,
+				MeetsCriteria: true,
+			},
+			{
+				Timestamp:     start.Add(2 * time.Hour),
+				Signals:       map[string]signals.Operation{"VWAP": signals.Buy},
+				Symbol:        "ENI.MTA",
+				ConfigHash:    "abc",
+				RunID:         3,
+				WeightedScore: 2,
+				LastPrice:     12.9,
+				Risk:          monitor.RiskMedium,
+				Opportunity:   monitor.OpportunityHigh,
+				MeetsCriteria: true,
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("timeline mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("returns the records of a run", func(t *testing.T) {
+		got, err := store.RunRecords(1)
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		if len(got) != 2 || got[0].Symbol != "A2A.MTA" || got[1].Symbol != "ENI.MTA" || got[1].LastPrice != 13.1 {
+			t.Errorf("unexpected run records: %+v", got)
+		}
+	})
+}
+
+func TestStore_SaveRun_UpdatesExistingRun(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "live", "history.db"))
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	run := &history.Run{StartedAt: start, Command: "monitor"}
+	for i, price := range []float64{13.1, 13.4} {
+		run.Scanned++
+		record := history.NewRecord(run, &monitor.StockScore{Symbol: "ENI.MTA", LastPrice: price}, false)
+		record.Timestamp = start.Add(time.Duration(i+1) * time.Hour)
+		if err := store.SaveRun(run, []*history.Record{record}); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+	}
+
+	runs, err := store.Runs(0)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 1 || runs[0].Scanned != 2 {
+		t.Errorf("expected a single run with 2 scores, instead got: %+v", runs)
+	}
+	records, err := store.RunRecords(1)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	if len(records) != 2 || records[0].LastPrice != 13.1 || records[1].LastPrice != 13.4 {
+		t.Errorf("expected the records in chronological order, instead got: %+v", records)
+	}
+}
+
+func TestStore_State(t *testing.T) {
+	type state struct {
+		Seen map[string]time.Time `json:"seen"`
+	}
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+
+	var got state
+	found, err := store.LoadState("alerts", &got)
+	if err != nil || found {
+		t.Fatalf("expected no state and no error, instead got %v, %v", found, err)
+	}
+
+	want := state{Seen: map[string]time.Time{"ENI.MTA": time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)}}
+	for _, s := range []state{{Seen: map[string]time.Time{}}, want} {
+		if err = store.SaveState("alerts", s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err = store.LoadState("alerts", &got)
+	if err != nil || !found {
+		t.Fatalf("expected state and no error, instead got %v, %v", found, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("state mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStore_Alerts(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	alerts, err := store.Alerts(0)
+	if err != nil || len(alerts) != 0 {
+		t.Fatalf("expected no alerts and no error, instead got %v, %v", alerts, err)
+	}
+
+	var want []*alert.Notification
+	for i, rule := range []string{"breakout", "oversold", "breakout"} {
+		n := &alert.Notification{
+			// the same timestamp twice, the alerts must not overwrite each other
+			Timestamp: start.Add(time.Duration(i/2) * time.Hour),
+			Rule:      rule,
+			Scores:    []*monitor.StockScore{{Symbol: "ENI.MTA", LastPrice: 13.1}},
+			Notifiers: []string{"slack"},
+			Severity:  alert.SeverityWarning,
+		}
+		if err = store.SaveAlert(n); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		want = append([]*alert.Notification{n}, want...)
+	}
+
+	for _, limit := range []int{0, 2} {
+		got, err := store.Alerts(limit)
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		wantN := want
+		if limit > 0 {
+			wantN = want[:limit]
+		}
+		if diff := cmp.Diff(wantN, got); diff != "" {
+			t.Errorf("Alerts(%d) mismatch (-want +got):\n%s", limit, diff)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package logging creates the structured loggers of the diagnostics,
+// which are kept apart from the reports shown by a printer.Printer.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Format defines how the records are written.
+type Format string
+
+const (
+	// FormatText writes a line of key=value pairs per record.
+	FormatText Format = "text"
+	// FormatJSON writes a JSON object per record.
+	FormatJSON Format = "json"
+)
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid log format %q, expected %s or %s", s, FormatText, FormatJSON)
+	}
+}
+
+// ParseLevel returns the level named s: debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// New creates a logger writing the records of at least the given level to w.
+func New(w io.Writer, format Format, level slog.Leveler) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// Discard returns a logger dropping every record.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
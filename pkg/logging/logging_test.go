@@ -0,0 +1,84 @@
+package logging_test
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+)
+
+func TestParseLevel(t *testing.T) {
+	type testCase struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}
+
+	for _, tc := range []testCase{
+		{name: "debug", want: slog.LevelDebug},
+		{name: "INFO", want: slog.LevelInfo},
+		{name: "warn", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "loud", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := logging.ParseLevel(tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseLevel() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseLevel() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	type testCase struct {
+		name    string
+		format  logging.Format
+		want    string
+		wantErr bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:   "text",
+			format: logging.FormatText,
+			want:   `level=WARN msg="scan failed" symbol=ENI.MTA`,
+		},
+		{
+			name:   "json",
+			format: logging.FormatJSON,
+			want:   `"level":"WARN","msg":"scan failed","symbol":"ENI.MTA"}`,
+		},
+		{
+			name:    "xml",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := logging.ParseFormat(tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseFormat() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if format != tc.format {
+				t.Errorf("ParseFormat() = %q, want %q", format, tc.format)
+			}
+
+			var out strings.Builder
+			logger := logging.New(&out, format, slog.LevelWarn)
+			logger.Info("scanning stocks", "count", 2)
+			logger.Warn("scan failed", "symbol", "ENI.MTA")
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(lines) != 1 || !strings.HasSuffix(lines[0], tc.want) {
+				t.Errorf("New() logged %q, want a single record ending with %q", out.String(), tc.want)
+			}
+		})
+	}
+}
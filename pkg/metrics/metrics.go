@@ -0,0 +1,192 @@
+// Package metrics exposes the activity of the monitor to Prometheus.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+const namespace = "algo_trading"
+
+// Outcomes of the scans and of the provider requests.
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// Cache is a cache whose hits and misses are counted.
+type Cache interface {
+	Hits() uint64
+	Misses() uint64
+}
+
+// Metrics collects the metrics of the monitor.
+// A nil *Metrics is valid and collects nothing, so that the callers don't need to check whether metrics are enabled.
+type Metrics struct {
+	registry        *prometheus.Registry
+	scanDuration    prometheus.Histogram
+	scans           *prometheus.CounterVec
+	symbolsScanned  prometheus.Counter
+	symbolsFailed   prometheus.Counter
+	providerLatency *prometheus.HistogramVec
+	alerts          *prometheus.CounterVec
+	weightedScore   *prometheus.GaugeVec
+	confidence      *prometheus.GaugeVec
+}
+
+// New creates a new Metrics, registering its collectors along with the ones of the Go runtime and of the process.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		scanDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "scan_duration_seconds",
+			Help:      "Duration of the market scans.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		}),
+		scans: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scans_total",
+			Help:      "Market scans run, by outcome.",
+		}, []string{"outcome"}),
+		symbolsScanned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "symbols_scanned_total",
+			Help:      "Symbols analysed by the market scans.",
+		}),
+		symbolsFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "symbols_failed_total",
+			Help:      "Symbols the market scans failed to analyse.",
+		}),
+		providerLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "provider_request_duration_seconds",
+			Help:      "Duration of the market data requests, by outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		alerts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "alerts_total",
+			Help:      "Alerts raised, by rule and severity.",
+		}, []string{"rule", "severity"}),
+		weightedScore: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "symbol_weighted_score",
+			Help:      "Latest weighted score of a symbol.",
+		}, []string{"symbol"}),
+		confidence: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "symbol_confidence",
+			Help:      "Latest confidence, between 0 and 1, of a symbol.",
+		}, []string{"symbol"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.scanDuration, m.scans, m.symbolsScanned, m.symbolsFailed, m.providerLatency,
+		m.alerts, m.weightedScore, m.confidence,
+	)
+	return m
+}
+
+// Handler returns the handler serving the metrics to Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterCache exposes the hits and the misses of c.
+func (m *Metrics) RegisterCache(c Cache) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_hits_total",
+			Help:      "Market data requests served by the cache.",
+		}, func() float64 { return float64(c.Hits()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_misses_total",
+			Help:      "Market data requests the cache forwarded to the provider.",
+		}, func() float64 { return float64(c.Misses()) }),
+	)
+}
+
+// ObserveScan records a scan which took duration, scored the stocks in scores and failed to analyse the failed
+// stocks, or which failed altogether with err. The scores of the failed stocks are deleted, as they have no latest
+// one anymore, while the ones of the stocks not in the scan are left to the scans of the other universes.
+func (m *Metrics) ObserveScan(duration time.Duration, scores []*monitor.StockScore, failed []string, err error) {
+	if m == nil {
+		return
+	}
+	m.scanDuration.Observe(duration.Seconds())
+	if err != nil {
+		m.scans.WithLabelValues(outcomeFailure).Inc()
+		return
+	}
+	m.scans.WithLabelValues(outcomeSuccess).Inc()
+	m.symbolsScanned.Add(float64(len(scores) + len(failed)))
+	m.symbolsFailed.Add(float64(len(failed)))
+	for _, score := range scores {
+		m.ObserveScore(score)
+	}
+	for _, symbol := range failed {
+		m.weightedScore.DeleteLabelValues(symbol)
+		m.confidence.DeleteLabelValues(symbol)
+	}
+}
+
+// ObserveScore records the latest score of a stock.
+func (m *Metrics) ObserveScore(score *monitor.StockScore) {
+	if m == nil {
+		return
+	}
+	m.weightedScore.WithLabelValues(score.Symbol).Set(score.WeightedScore)
+	m.confidence.WithLabelValues(score.Symbol).Set(score.Confidence)
+}
+
+// ObserveAlert records an alert raised by rule.
+func (m *Metrics) ObserveAlert(rule, severity string) {
+	if m == nil {
+		return
+	}
+	m.alerts.WithLabelValues(rule, severity).Inc()
+}
+
+// Client decorates cli, recording the duration of its requests.
+func (m *Metrics) Client(cli api.Client) api.Client {
+	if m == nil {
+		return cli
+	}
+	return &client{client: cli, latency: m.providerLatency}
+}
+
+type client struct {
+	client  api.Client
+	latency *prometheus.HistogramVec
+}
+
+func (c *client) GetOHLCV(ctx context.Context, symbol string, opts ...api.Option) ([]*api.OHLCV, error) {
+	started := time.Now()
+	data, err := c.client.GetOHLCV(ctx, symbol, opts...)
+	switch {
+	case err == nil:
+		c.latency.WithLabelValues(outcomeSuccess).Observe(time.Since(started).Seconds())
+	case errors.Is(err, context.Canceled):
+		// the request was abandoned, its duration says nothing about the provider
+	default:
+		c.latency.WithLabelValues(outcomeFailure).Observe(time.Since(started).Seconds())
+	}
+	return data, err
+}
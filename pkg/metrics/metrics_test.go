@@ -0,0 +1,131 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/metrics"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+type fakeClient struct {
+	err error
+}
+
+func (f *fakeClient) GetOHLCV(context.Context, string, ...api.Option) ([]*api.OHLCV, error) {
+	return nil, f.err
+}
+
+type fakeCache struct{}
+
+func (fakeCache) Hits() uint64   { return 3 }
+func (fakeCache) Misses() uint64 { return 1 }
+
+func TestMetrics_Handler(t *testing.T) {
+	m := metrics.New()
+	m.RegisterCache(fakeCache{})
+
+	scores := []*monitor.StockScore{
+		{Symbol: "ENI.MTA", WeightedScore: 1.5, Confidence: 0.75},
+		{Symbol: "ISP.MTA", WeightedScore: -0.5, Confidence: 0.25},
+	}
+	m.ObserveScan(2*time.Second, scores, []string{"UCG.MTA"}, nil)
+	m.ObserveScan(time.Second, nil, nil, errors.New("scan interrupted"))
+	m.ObserveScore(&monitor.StockScore{Symbol: "ENI.MTA", WeightedScore: 2, Confidence: 0.5})
+	m.ObserveAlert("breakout", "critical")
+	m.ObserveAlert("breakout", "critical")
+
+	ctx := context.Background()
+	_, _ = m.Client(&fakeClient{}).GetOHLCV(ctx, "ENI.MTA")
+	_, _ = m.Client(&fakeClient{err: errors.New("timeout")}).GetOHLCV(ctx, "ENI.MTA")
+	_, _ = m.Client(&fakeClient{err: context.Canceled}).GetOHLCV(ctx, "ENI.MTA")
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	got := string(body)
+
+	for _, want := range []string{
+		"algo_trading_scan_duration_seconds_count 2",
+		`algo_trading_scans_total{outcome="success"} 1`,
+		`algo_trading_scans_total{outcome="failure"} 1`,
+		"algo_trading_symbols_scanned_total 3",
+		"algo_trading_symbols_failed_total 1",
+		`algo_trading_provider_request_duration_seconds_count{outcome="success"} 1`,
+		`algo_trading_provider_request_duration_seconds_count{outcome="failure"} 1`,
+		"algo_trading_cache_hits_total 3",
+		"algo_trading_cache_misses_total 1",
+		`algo_trading_alerts_total{rule="breakout",severity="critical"} 2`,
+		`algo_trading_symbol_weighted_score{symbol="ENI.MTA"} 2`,
+		`algo_trading_symbol_weighted_score{symbol="ISP.MTA"} -0.5`,
+		`algo_trading_symbol_confidence{symbol="ENI.MTA"} 0.5`,
+		`algo_trading_symbol_confidence{symbol="ISP.MTA"} 0.25`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(got, want+"\n") && !strings.Contains(got, want+" ") {
+			t.Errorf("expected the scrape to contain %q, instead got:\n%s", want, got)
+		}
+	}
+}
+
+func TestMetrics_ObserveScan_DeletesFailedSymbols(t *testing.T) {
+	m := metrics.New()
+	m.ObserveScan(time.Second, []*monitor.StockScore{{Symbol: "ENI.MTA"}, {Symbol: "ISP.MTA"}}, nil, nil)
+	// another universe, as scanned by another scheduled job sharing the metrics
+	m.ObserveScan(time.Second, []*monitor.StockScore{{Symbol: "AAPL"}}, nil, nil)
+	m.ObserveScan(time.Second, []*monitor.StockScore{{Symbol: "ENI.MTA"}}, []string{"ISP.MTA"}, nil)
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	got := string(body)
+	for _, want := range []string{`symbol="ENI.MTA"`, `symbol="AAPL"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in the metrics, instead got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, `symbol="ISP.MTA"`) {
+		t.Errorf("expected no scores of ISP.MTA, instead got:\n%s", got)
+	}
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var m *metrics.Metrics
+	m.RegisterCache(fakeCache{})
+	m.ObserveScan(time.Second, []*monitor.StockScore{{Symbol: "ENI.MTA"}}, nil, nil)
+	m.ObserveAlert("breakout", "critical")
+
+	cli := &fakeClient{}
+	if got := m.Client(cli); got != cli {
+		t.Errorf("expected the client to be left undecorated, instead got %T", got)
+	}
+}
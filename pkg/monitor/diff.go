@@ -0,0 +1,153 @@
+package monitor
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+// Snapshot is the outcome of a single market scan.
+type Snapshot struct {
+	Timestamp time.Time
+	// All holds every scanned stock.
+	All []*StockScore
+	// Ranked holds the stocks meeting the filter criteria, best first.
+	Ranked []*StockScore
+	// Failed holds the stocks the scan failed to analyse, whose ranking is unknown.
+	Failed []string
+}
+
+// ScanDiff describes what changed between two consecutive scans.
+type ScanDiff struct {
+	Since       time.Time     `json:"since"`
+	Until       time.Time     `json:"until"`
+	Entries     []*RankChange `json:"entries"`
+	DropOuts    []*RankChange `json:"drop_outs"`
+	Moves       []*RankChange `json:"moves"`
+	SignalFlips []*SignalFlip `json:"signal_flips"`
+}
+
+// RankChange is a change in the ranking of a stock, a rank of 0 meaning not ranked.
+type RankChange struct {
+	Symbol       string `json:"symbol"`
+	PreviousRank int    `json:"previous_rank"`
+	Rank         int    `json:"rank"`
+}
+
+// SignalFlip is a change in the operation suggested by a strategy for a stock.
+type SignalFlip struct {
+	Symbol   string            `json:"symbol"`
+	Strategy string            `json:"strategy"`
+	From     signals.Operation `json:"from"`
+	To       signals.Operation `json:"to"`
+}
+
+// Empty reports whether nothing changed.
+func (d *ScanDiff) Empty() bool {
+	return len(d.Entries) == 0 && len(d.DropOuts) == 0 && len(d.Moves) == 0 && len(d.SignalFlips) == 0
+}
+
+// Diff compares two consecutive scans. The stocks either scan failed to analyse neither enter nor drop out,
+// as their ranking is unknown. The signal flips are sorted by symbol and strategy.
+func Diff(previous, current *Snapshot) *ScanDiff {
+	d := &ScanDiff{
+		Since:       previous.Timestamp,
+		Until:       current.Timestamp,
+		Entries:     make([]*RankChange, 0),
+		DropOuts:    make([]*RankChange, 0),
+		Moves:       make([]*RankChange, 0),
+		SignalFlips: make([]*SignalFlip, 0),
+	}
+
+	prevRanks := ranks(previous.Ranked)
+	currRanks := ranks(current.Ranked)
+	for i, score := range current.Ranked {
+		prev, ok := prevRanks[score.Symbol]
+		switch {
+		case !ok && !slices.Contains(previous.Failed, score.Symbol):
+			d.Entries = append(d.Entries, &RankChange{Symbol: score.Symbol, Rank: i + 1})
+		case ok && prev != i+1:
+			d.Moves = append(d.Moves, &RankChange{Symbol: score.Symbol, PreviousRank: prev, Rank: i + 1})
+		}
+	}
+	for i, score := range previous.Ranked {
+		if _, ok := currRanks[score.Symbol]; !ok && !slices.Contains(current.Failed, score.Symbol) {
+			d.DropOuts = append(d.DropOuts, &RankChange{Symbol: score.Symbol, PreviousRank: i + 1})
+		}
+	}
+
+	prevScores := make(map[string]*StockScore, len(previous.All))
+	for _, score := range previous.All {
+		prevScores[score.Symbol] = score
+	}
+	for _, score := range current.All {
+		prev, ok := prevScores[score.Symbol]
+		if !ok {
+			continue
+		}
+		for _, strategy := range sortedKeys(score.Signals) {
+			from, ok := prev.Signals[strategy]
+			if to := score.Signals[strategy]; ok && from != to {
+				d.SignalFlips = append(d.SignalFlips, &SignalFlip{
+					Symbol:   score.Symbol,
+					Strategy: strategy,
+					From:     from,
+					To:       to,
+				})
+			}
+		}
+	}
+	slices.SortFunc(d.SignalFlips, func(a, b *SignalFlip) int {
+		return cmp.Or(strings.Compare(a.Symbol, b.Symbol), strings.Compare(a.Strategy, b.Strategy))
+	})
+
+	return d
+}
+
+// GenerateDiffReport prints a compact report of what changed between two scans.
+func (ms *MarketScanner) GenerateDiffReport(d *ScanDiff) {
+	ms.p.Printf("\n=== CHANGES SINCE %s ===\n", d.Since.Local().Format(time.TimeOnly))
+	if d.Empty() {
+		ms.p.Println("No changes.")
+		return
+	}
+
+	for _, c := range d.Entries {
+		ms.p.PrintColored(printer.Green, "+ %s entered at #%d\n", c.Symbol, c.Rank)
+	}
+	for _, c := range d.DropOuts {
+		ms.p.PrintColored(printer.Red, "- %s dropped out (was #%d)\n", c.Symbol, c.PreviousRank)
+	}
+	for _, c := range d.Moves {
+		if c.Rank < c.PreviousRank {
+			ms.p.PrintColored(printer.Green, "^ %s #%d -> #%d\n", c.Symbol, c.PreviousRank, c.Rank)
+			continue
+		}
+		ms.p.PrintColored(printer.Yellow, "v %s #%d -> #%d\n", c.Symbol, c.PreviousRank, c.Rank)
+	}
+	for _, f := range d.SignalFlips {
+		ms.p.Printf("~ %s %s %s -> %s\n", f.Symbol, f.Strategy,
+			strings.ToUpper(string(f.From)), strings.ToUpper(string(f.To)))
+	}
+}
+
+func ranks(scores []*StockScore) map[string]int {
+	out := make(map[string]int, len(scores))
+	for i, score := range scores {
+		out[score.Symbol] = i + 1
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
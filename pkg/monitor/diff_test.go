@@ -0,0 +1,141 @@
+package monitor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+func score(symbol string, sigs map[string]signals.Operation) *monitor.StockScore {
+	return &monitor.StockScore{Symbol: symbol, Signals: sigs}
+}
+
+func TestDiff(t *testing.T) {
+	since := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	until := since.Add(10 * time.Minute)
+
+	eni := score("ENI.MTA", map[string]signals.Operation{"MACD": signals.NoOp, "VWAP": signals.Buy})
+	eniFlipped := score("ENI.MTA", map[string]signals.Operation{"MACD": signals.Buy, "VWAP": signals.Buy})
+	a2a := score("A2A.MTA", map[string]signals.Operation{"VWAP": signals.Buy})
+	uni := score("UNI.MTA", map[string]signals.Operation{"VWAP": signals.Buy})
+	ldo := score("LDO.MTA", map[string]signals.Operation{"VWAP": signals.Sell})
+
+	type testCase struct {
+		name     string
+		previous *monitor.Snapshot
+		current  *monitor.Snapshot
+		want     *monitor.ScanDiff
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "reports entries, drop-outs, moves and signal flips",
+			previous: &monitor.Snapshot{
+				Timestamp: since,
+				All:       []*monitor.StockScore{eni, a2a, uni, ldo},
+				Ranked:    []*monitor.StockScore{a2a, uni, ldo},
+			},
+			current: &monitor.Snapshot{
+				Timestamp: until,
+				All:       []*monitor.StockScore{eniFlipped, a2a, uni, ldo},
+				Ranked:    []*monitor.StockScore{uni, eniFlipped, a2a},
+			},
+			want: &monitor.ScanDiff{
+				Since:    since,
+				Until:    until,
+				Entries:  []*monitor.RankChange{{Symbol: "ENI.MTA", Rank: 2}},
+				DropOuts: []*monitor.RankChange{{Symbol: "LDO.MTA", PreviousRank: 3}},
+				Moves: []*monitor.RankChange{
+					{Symbol: "UNI.MTA", PreviousRank: 2, Rank: 1},
+					{Symbol: "A2A.MTA", PreviousRank: 1, Rank: 3},
+				},
+				SignalFlips: []*monitor.SignalFlip{
+					{Symbol: "ENI.MTA", Strategy: "MACD", From: signals.NoOp, To: signals.Buy},
+				},
+			},
+		},
+		{
+			name: "doesn't report the failed stocks as dropping out or entering",
+			previous: &monitor.Snapshot{
+				Timestamp: since,
+				All:       []*monitor.StockScore{a2a, uni},
+				Ranked:    []*monitor.StockScore{a2a, uni},
+				Failed:    []string{"LDO.MTA"},
+			},
+			current: &monitor.Snapshot{
+				Timestamp: until,
+				All:       []*monitor.StockScore{a2a, ldo},
+				Ranked:    []*monitor.StockScore{a2a, ldo},
+				Failed:    []string{"UNI.MTA"},
+			},
+			want: &monitor.ScanDiff{
+				Since:       since,
+				Until:       until,
+				Entries:     []*monitor.RankChange{},
+				DropOuts:    []*monitor.RankChange{},
+				Moves:       []*monitor.RankChange{},
+				SignalFlips: []*monitor.SignalFlip{},
+			},
+		},
+		{
+			name: "sorts the signal flips by symbol and strategy",
+			previous: &monitor.Snapshot{
+				Timestamp: since,
+				All:       []*monitor.StockScore{uni, eni, a2a},
+			},
+			current: &monitor.Snapshot{
+				Timestamp: until,
+				All: []*monitor.StockScore{
+					score("UNI.MTA", map[string]signals.Operation{"VWAP": signals.Sell}),
+					score("ENI.MTA", map[string]signals.Operation{"MACD": signals.Buy, "VWAP": signals.Sell}),
+					score("A2A.MTA", map[string]signals.Operation{"VWAP": signals.Setup}),
+				},
+			},
+			want: &monitor.ScanDiff{
+				Since:    since,
+				Until:    until,
+				Entries:  []*monitor.RankChange{},
+				DropOuts: []*monitor.RankChange{},
+				Moves:    []*monitor.RankChange{},
+				SignalFlips: []*monitor.SignalFlip{
+					{Symbol: "A2A.MTA", Strategy: "VWAP", From: signals.Buy, To: signals.Setup},
+					{Symbol: "ENI.MTA", Strategy: "MACD", From: signals.NoOp, To: signals.Buy},
+					{Symbol: "ENI.MTA", Strategy: "VWAP", From: signals.Buy, To: signals.Sell},
+					{Symbol: "UNI.MTA", Strategy: "VWAP", From: signals.Buy, To: signals.Sell},
+				},
+			},
+		},
+		{
+			name: "reports nothing when nothing changed",
+			previous: &monitor.Snapshot{
+				Timestamp: since,
+				All:       []*monitor.StockScore{eni, a2a},
+				Ranked:    []*monitor.StockScore{a2a},
+			},
+			current: &monitor.Snapshot{
+				Timestamp: until,
+				All:       []*monitor.StockScore{a2a, eni, uni},
+				Ranked:    []*monitor.StockScore{a2a},
+			},
+			want: &monitor.ScanDiff{
+				Since:       since,
+				Until:       until,
+				Entries:     []*monitor.RankChange{},
+				DropOuts:    []*monitor.RankChange{},
+				Moves:       []*monitor.RankChange{},
+				SignalFlips: []*monitor.SignalFlip{},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := monitor.Diff(tc.previous, tc.current)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("scan diff mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
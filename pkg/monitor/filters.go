@@ -16,6 +16,29 @@ type ScanFilters struct {
 	RequiredSignals  int              `json:"required_signals"`
 }
 
+// Match reports whether score passes the filters.
+func (f *ScanFilters) Match(score *StockScore) bool {
+	return score.Confidence >= f.MinConfidence &&
+		score.WeightedScore >= f.MinWeightedScore &&
+		score.Risk <= f.MaxRisk &&
+		score.Opportunity >= f.MinOpportunity &&
+		score.Volume >= f.MinVolume &&
+		(score.BuySignals >= f.RequiredSignals ||
+			score.SetupSignals >= f.RequiredSignals)
+}
+
+// Rank returns the scores passing the filters, best opportunities first.
+func (f *ScanFilters) Rank(scores []*StockScore) []*StockScore {
+	var filtered []*StockScore
+	for _, score := range scores {
+		if f.Match(score) {
+			filtered = append(filtered, score)
+		}
+	}
+	sortByOpportunity(filtered)
+	return filtered
+}
+
 // RiskLevel defines the probability of success for trading a product.
 type RiskLevel int
 
@@ -45,6 +68,11 @@ func (r *RiskLevel) String() string {
 	}
 }
 
+// MarshalJSON implements a custom json.Marshaler.
+func (r RiskLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(r.String()))
+}
+
 // UnmarshalJSON implements a custom json.Unmarshaler.
 func (r *RiskLevel) UnmarshalJSON(data []byte) error {
 	var s string
@@ -93,6 +121,11 @@ func (o *OpportunityLevel) String() string {
 	}
 }
 
+// MarshalJSON implements a custom json.Marshaler.
+func (o OpportunityLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(o.String()))
+}
+
 // UnmarshalJSON implements a custom json.Unmarshaler.
 func (o *OpportunityLevel) UnmarshalJSON(data []byte) error {
 	var s string
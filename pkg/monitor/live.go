@@ -0,0 +1,155 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+
+	"github.com/CanobbioE/algo-trading/pkg/stream"
+)
+
+const (
+	// defaultMaxBars caps the history kept for each symbol in live mode.
+	defaultMaxBars = 500
+	// defaultReconnects is how many consecutive times a dropped stream is reconnected before giving up.
+	defaultReconnects = 5
+	// defaultBackoff is the delay before the first reconnection, doubled after every failed one.
+	defaultBackoff = time.Second
+	// maxBackoff caps the delay between reconnections.
+	maxBackoff = time.Minute
+)
+
+// ErrStreamEnded is returned when the stream ends, and can't be reconnected, before the monitoring is done.
+var ErrStreamEnded = errors.New("stream ended unexpectedly")
+
+// LiveMonitor re-evaluates the strategies for a single symbol every time
+// a new bar arrives, instead of rescanning the whole universe on a timer.
+type LiveMonitor struct {
+	scanner  *MarketScanner
+	streamer stream.Streamer
+	// bars and symbols are keyed by upper-case symbol, as providers don't preserve the case of the universe
+	bars       map[string][]*api.OHLCV
+	symbols    map[string]string
+	maxBars    int
+	reconnects int
+	backoff    time.Duration
+}
+
+// LiveScore is the result of re-evaluating a symbol after a bar update.
+type LiveScore struct {
+	*StockScore
+	// MeetsCriteria is true if the score passes the scanner filters.
+	MeetsCriteria bool
+	// Final is true if the score was computed on a closed bar.
+	Final bool
+}
+
+// NewLiveMonitor creates a new LiveMonitor for the universe and strategies of scanner.
+func NewLiveMonitor(scanner *MarketScanner, streamer stream.Streamer) *LiveMonitor {
+	return &LiveMonitor{
+		scanner:    scanner,
+		streamer:   streamer,
+		bars:       make(map[string][]*api.OHLCV, len(scanner.stockUniverse)),
+		symbols:    make(map[string]string, len(scanner.stockUniverse)),
+		maxBars:    defaultMaxBars,
+		reconnects: defaultReconnects,
+		backoff:    defaultBackoff,
+	}
+}
+
+// SetReconnect sets how many consecutive times a dropped stream is reconnected,
+// and the delay before the first reconnection, doubled after every failed one.
+func (lm *LiveMonitor) SetReconnect(attempts int, backoff time.Duration) {
+	lm.reconnects = attempts
+	lm.backoff = backoff
+}
+
+// Run loads the history of every symbol, then calls callback with the new score of a symbol
+// every time one of its bars is updated. It returns when ctx is done.
+// A dropped stream is reconnected with an exponential backoff; once the reconnections are exhausted,
+// Run returns ErrStreamEnded.
+func (lm *LiveMonitor) Run(ctx context.Context, callback func(*LiveScore) error) error {
+	lm.bootstrap(ctx)
+
+	updates, err := lm.streamer.Subscribe(ctx, lm.scanner.stockUniverse...)
+	if err != nil {
+		return err
+	}
+	lm.scanner.logger.Info("streaming updates", "count", len(lm.scanner.stockUniverse))
+
+	backoff, failures := lm.backoff, 0
+	for {
+		connected := time.Now()
+		if lm.consume(updates, callback) || time.Since(connected) >= maxBackoff {
+			// the stream worked for a while, start over with the reconnections
+			backoff, failures = lm.backoff, 0
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		for {
+			if failures >= lm.reconnects {
+				return fmt.Errorf("%w after %d reconnections", ErrStreamEnded, failures)
+			}
+			failures++
+			lm.scanner.logger.Warn("stream ended, reconnecting", "attempt", failures, "backoff", backoff)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxBackoff)
+
+			if updates, err = lm.streamer.Subscribe(ctx, lm.scanner.stockUniverse...); err == nil {
+				break
+			}
+			lm.scanner.logger.Error("failed to reconnect to the stream", "attempt", failures, "error", err)
+		}
+	}
+}
+
+// consume scores every update until the stream ends, reporting whether any was received.
+func (lm *LiveMonitor) consume(updates <-chan *stream.Update, callback func(*LiveScore) error) bool {
+	var received bool
+	for u := range updates {
+		received = true
+		key := strings.ToUpper(u.Symbol)
+		bars, ok := lm.bars[key]
+		if !ok {
+			// not part of the universe, or its history failed to load
+			continue
+		}
+		bars = stream.Merge(bars, u, lm.maxBars)
+		lm.bars[key] = bars
+
+		score := lm.scanner.scoreStock(lm.symbols[key], bars)
+		if err := callback(&LiveScore{
+			StockScore:    score,
+			MeetsCriteria: lm.scanner.filters.Match(score),
+			Final:         u.Final,
+		}); err != nil {
+			lm.scanner.logger.Error("failed to handle live update", "symbol", u.Symbol, "error", err)
+		}
+	}
+	return received
+}
+
+// bootstrap fetches the history the live updates are merged into.
+func (lm *LiveMonitor) bootstrap(ctx context.Context) {
+	lm.scanner.logger.Info("loading history", "count", len(lm.scanner.stockUniverse))
+	for _, symbol := range lm.scanner.stockUniverse {
+		data, err := lm.scanner.client.GetOHLCV(ctx, symbol, &carnost.WithTimeframe{TimeFrame: carnost.Daily})
+		if err != nil {
+			lm.scanner.logger.Error("failed to load history", "symbol", symbol, "error", err)
+			continue
+		}
+		lm.bars[strings.ToUpper(symbol)] = data
+		lm.symbols[strings.ToUpper(symbol)] = symbol
+	}
+}
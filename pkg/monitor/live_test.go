@@ -0,0 +1,127 @@
+package monitor_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+	"github.com/CanobbioE/algo-trading/pkg/stream"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+func TestLiveMonitor_Run(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+	history := utilities.MustReturn(player.GetOHLCV(context.Background(), "FLAT.MTA"))
+	last := history[len(history)-1]
+
+	srv := stream.NewServer()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	scanner := monitor.NewMarketScanner(testStrategies(), []string{"FLAT.MTA", "MISSING.MTA"},
+		&monitor.ScanFilters{MinWeightedScore: -100, MaxRisk: monitor.RiskHigh}, player,
+		printer.NewStringsPrinter(&strings.Builder{}))
+	scanner.SetLogger(logging.Discard())
+	lm := monitor.NewLiveMonitor(scanner, stream.NewWebsocket("ws"+strings.TrimPrefix(ts.URL, "http")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		for srv.Subscribers("FLAT.MTA") == 0 && ctx.Err() == nil {
+			time.Sleep(10 * time.Millisecond)
+		}
+		// a forming bar, its final value and a bar for a symbol that failed to load
+		next := last.Timestamp.AddDate(0, 0, 1)
+		srv.Publish(&stream.Update{Symbol: "FLAT.MTA", Bar: &api.OHLCV{
+			Timestamp: next, Open: last.Close, High: 21, Low: last.Close, Close: 20.9, Volume: 1e6,
+		}})
+		srv.Publish(&stream.Update{Symbol: "MISSING.MTA", Bar: &api.OHLCV{Timestamp: next, Close: 1}})
+		srv.Publish(&stream.Update{Symbol: "FLAT.MTA", Final: true, Bar: &api.OHLCV{
+			Timestamp: next, Open: last.Close, High: 21, Low: last.Close, Close: 21, Volume: 2e6,
+		}})
+	}()
+
+	var got []*monitor.LiveScore
+	_ = lm.Run(ctx, func(score *monitor.LiveScore) error {
+		got = append(got, score)
+		if score.Final {
+			cancel()
+		}
+		return nil
+	})
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 live scores, instead got %d", len(got))
+	}
+	for i, want := range []float64{20.9, 21} {
+		if got[i].Symbol != "FLAT.MTA" || got[i].LastPrice != want || !got[i].MeetsCriteria {
+			t.Errorf("unexpected live score #%d: %+v", i, got[i])
+		}
+	}
+	if !got[1].Final {
+		t.Error("expected the last score to be final")
+	}
+}
+
+// sessionStreamer serves one of its sessions on every subscription, then fails to subscribe.
+type sessionStreamer struct {
+	sessions [][]*stream.Update
+}
+
+func (s *sessionStreamer) Subscribe(context.Context, ...string) (<-chan *stream.Update, error) {
+	if len(s.sessions) == 0 {
+		return nil, errors.New("connection refused")
+	}
+	updates := make(chan *stream.Update, len(s.sessions[0]))
+	for _, u := range s.sessions[0] {
+		updates <- u
+	}
+	close(updates)
+	s.sessions = s.sessions[1:]
+	return updates, nil
+}
+
+func TestLiveMonitor_Run_Reconnects(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+	history := utilities.MustReturn(player.GetOHLCV(context.Background(), "FLAT.MTA"))
+	last := history[len(history)-1]
+	next := last.Timestamp.AddDate(0, 0, 1)
+
+	scanner := monitor.NewMarketScanner(testStrategies(), []string{"flat.mta"},
+		&monitor.ScanFilters{MinWeightedScore: -100, MaxRisk: monitor.RiskHigh}, player,
+		printer.NewStringsPrinter(&strings.Builder{}))
+	scanner.SetLogger(logging.Discard())
+	lm := monitor.NewLiveMonitor(scanner, &sessionStreamer{sessions: [][]*stream.Update{
+		{{Symbol: "FLAT.MTA", Bar: &api.OHLCV{Timestamp: next, Open: last.Close, High: 21, Low: last.Close, Close: 20.9}}},
+		{{Symbol: "FLAT.MTA", Final: true, Bar: &api.OHLCV{Timestamp: next, Open: last.Close, High: 21, Low: last.Close, Close: 21}}},
+	}})
+	lm.SetReconnect(2, time.Millisecond)
+
+	var got []*monitor.LiveScore
+	err := lm.Run(context.Background(), func(score *monitor.LiveScore) error {
+		got = append(got, score)
+		return nil
+	})
+
+	if !errors.Is(err, monitor.ErrStreamEnded) {
+		t.Errorf("expected %v, instead got: %v", monitor.ErrStreamEnded, err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 live scores, instead got %d", len(got))
+	}
+	for i, want := range []float64{20.9, 21} {
+		if got[i].Symbol != "flat.mta" || got[i].LastPrice != want {
+			t.Errorf("unexpected live score #%d: %+v", i, got[i])
+		}
+	}
+}
@@ -3,6 +3,7 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sort"
 	"sync"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/CanobbioE/stock-market-clients/api"
 	"github.com/CanobbioE/stock-market-clients/carnost"
 
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
 	"github.com/CanobbioE/algo-trading/pkg/printer"
 	"github.com/CanobbioE/algo-trading/pkg/signals"
 	"github.com/CanobbioE/algo-trading/pkg/strategies"
@@ -17,6 +19,8 @@ import (
 
 // StockScore represents the analysis result for a single stock.
 type StockScore struct {
+	// Signals maps each strategy name to the operation it suggested.
+	Signals       map[string]signals.Operation
 	Symbol        string
 	Reasoning     []string
 	Confidence    float64
@@ -33,12 +37,20 @@ type StockScore struct {
 
 // MarketScanner scans multiple stocks and ranks them.
 type MarketScanner struct {
-	client         api.Client
-	p              printer.Printer
-	filters        *ScanFilters
+	client   api.Client
+	p        printer.Printer
+	logger   *slog.Logger
+	calendar *calendar.Calendar
+	filters  *ScanFilters
+	// bars are the bars each stock of the latest scan was scored on, if kept.
+	bars map[string][]*api.OHLCV
+	// failed are the stocks the latest scan failed to analyse.
+	failed         []string
 	strategies     []*strategies.StrategyWeight
 	stockUniverse  []string
 	maxConcurrency int
+	mu             sync.Mutex
+	keepBars       bool
 }
 
 // NewMarketScanner creates a new market scanner.
@@ -56,19 +68,32 @@ func NewMarketScanner(
 		maxConcurrency: 5, // Limit concurrent API calls
 		filters:        filters,
 		p:              p,
+		logger:         slog.Default(),
 	}
 }
 
-// ScanMarket analyzes all stocks in the universe.
+// ScanMarket analyzes all stocks in the universe and returns the ones meeting
+// the filter criteria, best opportunities first.
 func (ms *MarketScanner) ScanMarket(ctx context.Context) ([]*StockScore, error) {
-	ms.p.PrintColored(printer.Blue, "Scanning %d stocks...\n", len(ms.stockUniverse))
+	scores, err := ms.ScanAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ms.Filter(scores), nil
+}
+
+// ScanAll analyzes all stocks in the universe, without filtering them.
+func (ms *MarketScanner) ScanAll(ctx context.Context) ([]*StockScore, error) {
+	ms.logger.Info("scanning stocks", "count", len(ms.stockUniverse))
 
 	// Channel to control concurrency
 	semaphore := make(chan struct{}, ms.maxConcurrency)
 	results := make(chan *StockScore, len(ms.stockUniverse))
-	errors := make(chan error, len(ms.stockUniverse))
+	errors := make(chan *scanError, len(ms.stockUniverse))
 
 	var wg sync.WaitGroup
+	var barsMu sync.Mutex
+	bars := make(map[string][]*api.OHLCV)
 
 	// Analyze each stock concurrently
 	for _, symbol := range ms.stockUniverse {
@@ -76,15 +101,24 @@ func (ms *MarketScanner) ScanMarket(ctx context.Context) ([]*StockScore, error)
 		go func(sym string) {
 			defer wg.Done()
 
-			// Acquire semaphore
-			semaphore <- struct{}{}
+			// Acquire semaphore, unless the scan was cancelled while waiting
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
 			defer func() { <-semaphore }()
 
-			score, err := ms.analyzeStock(ctx, sym)
+			score, data, err := ms.analyzeStock(ctx, sym)
 			if err != nil {
-				errors <- fmt.Errorf("error analyzing %s: %w", sym, err)
+				errors <- &scanError{symbol: sym, err: err}
 				return
 			}
+			if ms.keepBars {
+				barsMu.Lock()
+				bars[sym] = data
+				barsMu.Unlock()
+			}
 
 			results <- score
 			// prevent getting timed out
@@ -101,7 +135,7 @@ func (ms *MarketScanner) ScanMarket(ctx context.Context) ([]*StockScore, error)
 
 	// Collect results
 	var scores []*StockScore
-	var scanErrors []error
+	var scanErrors []*scanError
 
 	for {
 		select {
@@ -124,32 +158,116 @@ func (ms *MarketScanner) ScanMarket(ctx context.Context) ([]*StockScore, error)
 		}
 	}
 
+	// a cancelled scan is incomplete, don't let it pass for a full one
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("scan interrupted: %w", err)
+	}
+
 	// Log errors but don't fail the entire scan
-	for _, err := range scanErrors {
-		ms.p.PrintColored(printer.Red, "Scan error: %v\n", err)
+	failed := make([]string, 0, len(scanErrors))
+	for _, e := range scanErrors {
+		ms.logger.Error("failed to analyse stock", "symbol", e.symbol, "error", e.err)
+		failed = append(failed, e.symbol)
 	}
 
-	// Filter and sort results
-	ms.p.Printf("Filtering %d results...\n", len(scores))
-	filteredScores := ms.filterResults(scores)
-	ms.sortByOpportunity(filteredScores)
+	ms.mu.Lock()
+	ms.failed = failed
+	if ms.keepBars {
+		ms.bars = bars
+	}
+	ms.mu.Unlock()
+
+	return scores, nil
+}
 
-	return filteredScores, nil
+// Filter returns the scores meeting the filter criteria, sorted by opportunity.
+func (ms *MarketScanner) Filter(scores []*StockScore) []*StockScore {
+	ms.logger.Debug("filtering results", "count", len(scores))
+	return ms.filters.Rank(scores)
 }
 
-// analyzeStock performs strategy analysis on a single stock.
-func (ms *MarketScanner) analyzeStock(ctx context.Context, symbol string) (*StockScore, error) {
+// analyzeStock performs strategy analysis on a single stock, returning the bars it was scored on.
+func (ms *MarketScanner) analyzeStock(ctx context.Context, symbol string) (*StockScore, []*api.OHLCV, error) {
 	// Get stock data
 	data, err := ms.client.GetOHLCV(ctx, symbol, &carnost.WithTimeframe{TimeFrame: carnost.Daily})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(data) == 0 {
-		return nil, fmt.Errorf("no data available for %s", symbol)
+		return nil, nil, fmt.Errorf("no data available for %s", symbol)
 	}
 
+	data = ms.closedBars(symbol, data)
+	return ms.scoreStock(symbol, data), data, nil
+}
+
+// scanError is the failed analysis of a stock.
+type scanError struct {
+	err    error
+	symbol string
+}
+
+// SetLogger replaces the logger of the diagnostics, slog.Default() unless set.
+func (ms *MarketScanner) SetLogger(l *slog.Logger) {
+	ms.logger = l
+}
+
+// SetCalendar makes the scanner ignore the daily bar still forming in the current session,
+// so that the strategies only run on closed bars.
+func (ms *MarketScanner) SetCalendar(c *calendar.Calendar) {
+	ms.calendar = c
+}
+
+// SetKeepBars makes the scanner remember the bars the stocks of the latest scan were scored on,
+// so that they can be charted without fetching them again, see Bars.
+func (ms *MarketScanner) SetKeepBars(keep bool) {
+	ms.keepBars = keep
+}
+
+// Bars returns the bars symbol was scored on in the latest scan, if the scanner keeps them.
+func (ms *MarketScanner) Bars(symbol string) ([]*api.OHLCV, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	data, ok := ms.bars[symbol]
+	return data, ok
+}
+
+// Failed returns the stocks the latest scan failed to analyse, such as the ones whose data couldn't be fetched.
+func (ms *MarketScanner) Failed() []string {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.failed
+}
+
+// closedBars drops the last daily bar if it's still forming, according to the exchange of the symbol.
+func (ms *MarketScanner) closedBars(symbol string, data []*api.OHLCV) []*api.OHLCV {
+	return ClosedBars(ms.calendar, symbol, data, 24*time.Hour, time.Now())
+}
+
+// ClosedBars drops the last of the bars of symbol lasting interval if at now it's still forming,
+// according to the exchange of the symbol in c. A nil c, or a symbol of no exchange, keeps every bar.
+func ClosedBars(
+	c *calendar.Calendar,
+	symbol string,
+	data []*api.OHLCV,
+	interval time.Duration,
+	now time.Time,
+) []*api.OHLCV {
+	if c == nil || len(data) < 2 {
+		return data
+	}
+	exchange, ok := c.ForSymbol(symbol)
+	if !ok || !exchange.IsForming(data[len(data)-1].Timestamp, interval, now) {
+		return data
+	}
+	return data[:len(data)-1]
+}
+
+// scoreStock runs every strategy on the given data.
+func (ms *MarketScanner) scoreStock(symbol string, data []*api.OHLCV) *StockScore {
 	score := &StockScore{
+		Signals:   make(map[string]signals.Operation, len(ms.strategies)),
 		Symbol:    symbol,
 		LastPrice: data[len(data)-1].Close,
 		Volume:    data[len(data)-1].Volume,
@@ -161,6 +279,7 @@ func (ms *MarketScanner) analyzeStock(ctx context.Context, symbol string) (*Stoc
 
 	for _, sw := range ms.strategies {
 		operation := sw.Strategy.Execute(data)
+		score.Signals[sw.Name()] = operation
 		signalCounts[operation]++
 		weightedScores[operation] += sw.Weight
 
@@ -180,7 +299,7 @@ func (ms *MarketScanner) analyzeStock(ctx context.Context, symbol string) (*Stoc
 	score.Risk = ms.calculateRisk(data, score)
 	score.Opportunity = ms.calculateOpportunity(score)
 
-	return score, nil
+	return score
 }
 
 // calculateRisk assesses the risk level of a stock.
@@ -229,27 +348,8 @@ func (*MarketScanner) calculateOpportunity(score *StockScore) OpportunityLevel {
 	}
 }
 
-// filterResults applies filters to the scan results.
-func (ms *MarketScanner) filterResults(scores []*StockScore) []*StockScore {
-	var filtered []*StockScore
-
-	for _, score := range scores {
-		if score.Confidence >= ms.filters.MinConfidence &&
-			score.WeightedScore >= ms.filters.MinWeightedScore &&
-			score.Risk <= ms.filters.MaxRisk &&
-			score.Opportunity >= ms.filters.MinOpportunity &&
-			score.Volume >= ms.filters.MinVolume &&
-			(score.BuySignals >= ms.filters.RequiredSignals ||
-				score.SetupSignals >= ms.filters.RequiredSignals) {
-			filtered = append(filtered, score)
-		}
-	}
-
-	return filtered
-}
-
 // sortByOpportunity sorts stocks by opportunity score (best first).
-func (*MarketScanner) sortByOpportunity(scores []*StockScore) {
+func sortByOpportunity(scores []*StockScore) {
 	sort.Slice(scores, func(i, j int) bool {
 		// Primary sort: Opportunity level
 		if scores[i].Opportunity != scores[j].Opportunity {
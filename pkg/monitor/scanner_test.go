@@ -0,0 +1,278 @@
+package monitor_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+func testStrategies() []*strategies.StrategyWeight {
+	thresholds := &strategies.Thresholds{
+		AtrPeriod:         3,
+		LowATRThreshold:   0.07,
+		HighATRThreshold:  0.4,
+		LowLookback:       8,
+		HighLookback:      3,
+		VolumeThreshold:   1.0,
+		Deviation:         0.03,
+		Squeeze:           0.07,
+		MinMomentumReturn: 0.03,
+	}
+	return []*strategies.StrategyWeight{
+		{Strategy: strategies.NewBreakoutStrategy(thresholds), Weight: 1.8},
+		{Strategy: strategies.NewVWAPStrategy(3), Weight: 1.0},
+		{Strategy: strategies.NewMeanReversionStrategy(3, thresholds.Deviation), Weight: 0.6},
+		{Strategy: strategies.NewBollingerBandSqueezeStrategy(3, 1.8, thresholds.Squeeze), Weight: 1.2},
+		{Strategy: strategies.NewMomentumStrategy(8, thresholds), Weight: 1.8},
+		{Strategy: strategies.NewMACDStrategy(&strategies.MACDParams{
+			FastPeriod:      6,
+			SlowPeriod:      13,
+			SignalPeriod:    5,
+			TriggerDistance: 0.005,
+		}), Weight: 3.0},
+	}
+}
+
+var (
+	upTrendScore = &monitor.StockScore{
+		Signals: map[string]signals.Operation{
+			"BREAKOUT":      signals.NoOp,
+			"VWAP":          signals.Buy,
+			"MEANREVERSION": signals.Sell,
+			"BOLLINGER":     signals.NoOp,
+			"MOMENTUM":      signals.Buy,
+			"MACD":          signals.NoOp,
+		},
+		Symbol: "UPTREND.MTA",
+		Reasoning: []string{
+			"*strategies.VWAPStrategy suggests BUY",
+			"*strategies.MomentumStrategy suggests BUY",
+		},
+		Confidence:    1.0 / 3,
+		HoldSignals:   3,
+		WeightedScore: 2.2,
+		SellSignals:   1,
+		BuySignals:    2,
+		LastPrice:     20.6965,
+		Volume:        6.24e+06,
+		Risk:          monitor.RiskLow,
+		Opportunity:   monitor.OpportunityMedium,
+	}
+	flatScore = &monitor.StockScore{
+		Signals: map[string]signals.Operation{
+			"BREAKOUT":      signals.NoOp,
+			"VWAP":          signals.Buy,
+			"MEANREVERSION": signals.NoOp,
+			"BOLLINGER":     signals.NoOp,
+			"MOMENTUM":      signals.NoOp,
+			"MACD":          signals.NoOp,
+		},
+		Symbol:        "FLAT.MTA",
+		Reasoning:     []string{"*strategies.VWAPStrategy suggests BUY"},
+		Confidence:    1.0 / 6,
+		HoldSignals:   5,
+		WeightedScore: 1,
+		BuySignals:    1,
+		LastPrice:     19.9827,
+		Volume:        400000,
+		Risk:          monitor.RiskLow,
+		Opportunity:   monitor.OpportunityLow,
+	}
+	downTrendScore = &monitor.StockScore{
+		Signals: map[string]signals.Operation{
+			"BREAKOUT":      signals.NoOp,
+			"VWAP":          signals.Sell,
+			"MEANREVERSION": signals.Buy,
+			"BOLLINGER":     signals.NoOp,
+			"MOMENTUM":      signals.Sell,
+			"MACD":          signals.NoOp,
+		},
+		Symbol:        "DOWNTREND.MTA",
+		Reasoning:     []string{"*strategies.MeanReversionStrategy suggests BUY"},
+		Confidence:    1.0 / 6,
+		HoldSignals:   3,
+		WeightedScore: -2.2,
+		SellSignals:   2,
+		BuySignals:    1,
+		LastPrice:     25.9035,
+		Volume:        1.8e+06,
+		Risk:          monitor.RiskLow,
+		Opportunity:   monitor.OpportunityLow,
+	}
+	thinScore = &monitor.StockScore{
+		Signals: map[string]signals.Operation{
+			"BREAKOUT":      signals.NoOp,
+			"VWAP":          signals.Sell,
+			"MEANREVERSION": signals.NoOp,
+			"BOLLINGER":     signals.NoOp,
+			"MOMENTUM":      signals.NoOp,
+			"MACD":          signals.Sell,
+		},
+		Symbol:        "THIN.ETF",
+		Reasoning:     []string{},
+		HoldSignals:   4,
+		WeightedScore: -4,
+		SellSignals:   2,
+		LastPrice:     5.9578,
+		Volume:        20000,
+		Risk:          monitor.RiskHigh,
+		Opportunity:   monitor.OpportunityLow,
+	}
+)
+
+func TestMarketScanner_ScanMarket(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+	universe := []string{"UPTREND.MTA", "DOWNTREND.MTA", "FLAT.MTA", "THIN.ETF", "BROKEN.MTA", "MISSING.MTA"}
+
+	type testCase struct {
+		name    string
+		filters *monitor.ScanFilters
+		want    []*monitor.StockScore
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "returns every successfully scanned stock with permissive filters",
+			filters: &monitor.ScanFilters{
+				MinWeightedScore: -100,
+				MaxRisk:          monitor.RiskHigh,
+				MinOpportunity:   monitor.OpportunityLow,
+			},
+			want: []*monitor.StockScore{upTrendScore, flatScore, downTrendScore, thinScore},
+		},
+		{
+			name: "drops stocks not meeting the filter criteria",
+			filters: &monitor.ScanFilters{
+				MinConfidence:   0.3,
+				MaxRisk:         monitor.RiskMedium,
+				MinOpportunity:  monitor.OpportunityMedium,
+				MinVolume:       1000,
+				RequiredSignals: 2,
+			},
+			want: []*monitor.StockScore{upTrendScore},
+		},
+		{
+			name: "returns no results when nothing meets the criteria",
+			filters: &monitor.ScanFilters{
+				MaxRisk:        monitor.RiskLow,
+				MinOpportunity: monitor.OpportunityHigh,
+			},
+			want: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &strings.Builder{}
+			scanner := monitor.NewMarketScanner(testStrategies(), universe, tc.filters, player,
+				printer.NewStringsPrinter(&strings.Builder{}))
+			scanner.SetLogger(logging.New(out, logging.FormatText, slog.LevelInfo))
+
+			got, err := scanner.ScanMarket(context.Background())
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("scan result mismatch (-want +got):\n%s", diff)
+			}
+			sorted := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if diff := cmp.Diff([]string{"BROKEN.MTA", "MISSING.MTA"}, scanner.Failed(), sorted); diff != "" {
+				t.Errorf("failed stocks mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, want := range []string{
+				`level=ERROR msg="failed to analyse stock" symbol=BROKEN.MTA error="carnost: unexpected status code 500"`,
+				`level=ERROR msg="failed to analyse stock" symbol=MISSING.MTA error="no fixture recorded for MISSING.MTA (1d)"`,
+			} {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected logs to contain %q, instead got:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestMarketScanner_Bars(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+	scanner := monitor.NewMarketScanner(testStrategies(), []string{"UPTREND.MTA", "BROKEN.MTA"},
+		&monitor.ScanFilters{}, player, printer.NewStringsPrinter(&strings.Builder{}))
+	scanner.SetLogger(logging.Discard())
+
+	if _, err := scanner.ScanAll(context.Background()); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	if _, ok := scanner.Bars("UPTREND.MTA"); ok {
+		t.Error("expected no bars unless the scanner keeps them")
+	}
+
+	scanner.SetKeepBars(true)
+	if _, err := scanner.ScanAll(context.Background()); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	want := utilities.MustReturn(player.GetOHLCV(context.Background(), "UPTREND.MTA"))
+	got, ok := scanner.Bars("UPTREND.MTA")
+	if !ok {
+		t.Fatal("expected the bars of UPTREND.MTA to be kept")
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("bars mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok = scanner.Bars("BROKEN.MTA"); ok {
+		t.Error("expected no bars for a stock that failed to be scanned")
+	}
+}
+
+func TestClosedBars(t *testing.T) {
+	c := utilities.MustReturn(calendar.Default())
+	bar := func(start string) *api.OHLCV {
+		return &api.OHLCV{Timestamp: utilities.MustReturn(time.Parse(time.RFC3339, start))}
+	}
+	daily := []*api.OHLCV{bar("2025-06-02T00:00:00Z"), bar("2025-06-03T00:00:00Z")}
+	hourly := []*api.OHLCV{bar("2025-06-03T14:00:00+02:00"), bar("2025-06-03T15:00:00+02:00")}
+	during := utilities.MustReturn(time.Parse(time.RFC3339, "2025-06-03T15:30:00+02:00"))
+	after := utilities.MustReturn(time.Parse(time.RFC3339, "2025-06-03T18:00:00+02:00"))
+
+	for _, tc := range []struct {
+		calendar *calendar.Calendar
+		now      time.Time
+		name     string
+		symbol   string
+		data     []*api.OHLCV
+		interval time.Duration
+		want     int
+	}{
+		{name: "drops the forming daily bar", calendar: c, symbol: "ENI.MTA", data: daily,
+			interval: 24 * time.Hour, now: during, want: 1},
+		{name: "keeps the closed daily bar", calendar: c, symbol: "ENI.MTA", data: daily,
+			interval: 24 * time.Hour, now: after, want: 2},
+		{name: "drops the forming hourly bar", calendar: c, symbol: "ENI.MTA", data: hourly,
+			interval: time.Hour, now: during, want: 1},
+		{name: "keeps the bars of unknown exchanges", calendar: c, symbol: "ENI.XYZ", data: daily,
+			interval: 24 * time.Hour, now: during, want: 2},
+		{name: "keeps the bars without a calendar", symbol: "ENI.MTA", data: daily,
+			interval: 24 * time.Hour, now: during, want: 2},
+		{name: "keeps a single bar", calendar: c, symbol: "ENI.MTA", data: daily[1:],
+			interval: 24 * time.Hour, now: during, want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := monitor.ClosedBars(tc.calendar, tc.symbol, tc.data, tc.interval, tc.now)
+			if diff := cmp.Diff(tc.data[:tc.want], got); diff != "" {
+				t.Errorf("bars mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,52 +1,118 @@
 package monitor
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"time"
-
-	"github.com/CanobbioE/algo-trading/pkg/printer"
 )
 
+// Sessions tells when the market is open.
+type Sessions interface {
+	// IsOpen reports whether the market is open at t.
+	IsOpen(t time.Time) bool
+	// NextOpen returns when the market opens next after t, or t itself if it's open.
+	NextOpen(t time.Time) time.Time
+}
+
+// Summary reports what a monitoring session did.
+type Summary struct {
+	Started  time.Time
+	Duration time.Duration
+	// Runs counts the completed scans, including the failed ones.
+	Runs     int
+	Failures int
+	// Skipped counts the scans skipped because the market was closed.
+	Skipped int
+	// Interrupted is set when the monitoring stopped in the middle of a scan.
+	Interrupted bool
+}
+
 // WatchList maintains a dynamic watch list based on scan results.
 type WatchList struct {
-	p              printer.Printer
-	stopChan       chan bool
+	logger         *slog.Logger
+	sessions       Sessions
 	updateInterval time.Duration
+	paused         bool
 }
 
 // NewWatchList creates a new watch list.
-func NewWatchList(p printer.Printer, updateInterval time.Duration) *WatchList {
+func NewWatchList(updateInterval time.Duration) *WatchList {
 	return &WatchList{
 		updateInterval: updateInterval,
-		stopChan:       make(chan bool),
-		p:              p,
+		logger:         slog.Default(),
 	}
 }
 
-// StartMonitoring begins continuous market monitoring.
-func (wl *WatchList) StartMonitoring(callback func() error) {
+// SetLogger replaces the logger of the diagnostics, slog.Default() unless set.
+func (wl *WatchList) SetLogger(l *slog.Logger) {
+	wl.logger = l
+}
+
+// SetSessions pauses the monitoring outside the given trading sessions.
+func (wl *WatchList) SetSessions(sessions Sessions) {
+	wl.sessions = sessions
+}
+
+// StartMonitoring begins continuous market monitoring, calling callback every update interval
+// until ctx is done. The context given to callback is cancelled as soon as ctx is,
+// so that a scan in flight is interrupted.
+func (wl *WatchList) StartMonitoring(ctx context.Context, callback func(ctx context.Context) error) *Summary {
 	ticker := time.NewTicker(wl.updateInterval)
 	defer ticker.Stop()
 
+	summary := &Summary{Started: time.Now()}
+	defer func() {
+		summary.Duration = time.Since(summary.Started)
+	}()
+
 	for {
 		select {
-		case <-ticker.C:
-			wl.p.PrintColored(printer.Blue, "Running market scan...\n")
-			if callback != nil {
-				// todo: maybe return a bool "shouldContinue" to improve err handling.
-				if err := callback(); err != nil {
-					wl.p.PrintColored(printer.Red, fmt.Sprintf("Scan error: %v\n", err))
-					continue
-				}
+		case now := <-ticker.C:
+			if wl.closed(now) {
+				summary.Skipped++
+				continue
 			}
-		case <-wl.stopChan:
-			wl.p.PrintColored(printer.Yellow, "Stopping market monitoring")
-			return
+			wl.logger.Info("running market scan")
+			if callback == nil {
+				summary.Runs++
+				continue
+			}
+
+			err := callback(ctx)
+			if ctx.Err() != nil {
+				summary.Interrupted = true
+				wl.logger.Warn("scan interrupted, stopping market monitoring")
+				return summary
+			}
+			summary.Runs++
+			if err != nil {
+				summary.Failures++
+				wl.logger.Error("scan failed", "error", err)
+			}
+		case <-ctx.Done():
+			wl.logger.Info("stopping market monitoring")
+			return summary
 		}
 	}
 }
 
-// Stop stops the monitoring.
-func (wl *WatchList) Stop() {
-	wl.stopChan <- true
+// closed reports whether the market is closed at now, notifying when the monitoring pauses.
+func (wl *WatchList) closed(now time.Time) bool {
+	if wl.sessions == nil || wl.sessions.IsOpen(now) {
+		if wl.paused {
+			wl.logger.Info("market open, resuming market monitoring")
+		}
+		wl.paused = false
+		return false
+	}
+
+	if !wl.paused {
+		wl.paused = true
+		if next := wl.sessions.NextOpen(now); !next.IsZero() {
+			wl.logger.Info("market closed, pausing market monitoring", "until", next)
+		} else {
+			wl.logger.Info("market closed, pausing market monitoring")
+		}
+	}
+	return true
 }
@@ -0,0 +1,112 @@
+package monitor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// closedSessions keeps the market closed.
+type closedSessions struct{}
+
+func (closedSessions) IsOpen(time.Time) bool { return false }
+
+func (closedSessions) NextOpen(time.Time) time.Time { return time.Time{} }
+
+func TestWatchList_StartMonitoring(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sessions monitor.Sessions
+		// callback is called with the number of the scan, starting from 1, and the cancel function of the monitoring
+		callback func(ctx context.Context, n int, cancel context.CancelFunc) error
+		want     *monitor.Summary
+		name     string
+	}{
+		{
+			name: "stops when cancelled",
+			callback: func(_ context.Context, n int, cancel context.CancelFunc) error {
+				if n == 3 {
+					cancel()
+				}
+				return nil
+			},
+			// the third scan completed before the cancellation was noticed
+			want: &monitor.Summary{Runs: 2, Interrupted: true},
+		},
+		{
+			name: "counts failures",
+			callback: func(_ context.Context, n int, cancel context.CancelFunc) error {
+				if n == 4 {
+					cancel()
+					return nil
+				}
+				if n%2 == 0 {
+					return errors.New("no data")
+				}
+				return nil
+			},
+			want: &monitor.Summary{Runs: 3, Failures: 1, Interrupted: true},
+		},
+		{
+			name: "interrupts the scan in flight",
+			callback: func(ctx context.Context, n int, cancel context.CancelFunc) error {
+				if n < 2 {
+					return nil
+				}
+				cancel()
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			want: &monitor.Summary{Runs: 1, Interrupted: true},
+		},
+		{
+			name:     "skips while the market is closed",
+			sessions: closedSessions{},
+			want:     &monitor.Summary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.callback == nil {
+				ctx, cancel = context.WithTimeout(ctx, 50*time.Millisecond)
+				defer cancel()
+			}
+
+			wl := monitor.NewWatchList(5 * time.Millisecond)
+			wl.SetLogger(logging.Discard())
+			if tt.sessions != nil {
+				wl.SetSessions(tt.sessions)
+			}
+
+			var n int
+			got := wl.StartMonitoring(ctx, func(ctx context.Context) error {
+				n++
+				if tt.callback == nil {
+					return nil
+				}
+				return tt.callback(ctx, n, cancel)
+			})
+
+			if tt.sessions != nil && got.Skipped == 0 {
+				t.Errorf("StartMonitoring() skipped no scan while the market was closed")
+			}
+			opts := cmp.Options{cmpopts.IgnoreFields(monitor.Summary{}, "Started", "Duration", "Skipped")}
+			if diff := cmp.Diff(tt.want, got, opts); diff != "" {
+				t.Errorf("StartMonitoring() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
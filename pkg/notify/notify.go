@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// Alert is a notification about one or more exceptional StockScore.
+type Alert struct {
+	Timestamp time.Time `json:"timestamp"`
+	Title     string    `json:"title"`
+	// Severity is optional, e.g. INFO, WARNING or CRITICAL.
+	Severity string `json:"severity,omitempty"`
+	// Message is an optional free text description of the alert.
+	Message string                `json:"message,omitempty"`
+	Scores  []*monitor.StockScore `json:"scores,omitempty"`
+}
+
+// Notifier delivers alerts to the user.
+type Notifier interface {
+	// Notify delivers the alert, or returns an error if it couldn't.
+	Notify(ctx context.Context, a *Alert) error
+}
+
+// Config defines a named notifier, only the section matching its type is used.
+type Config struct {
+	SMTP    *SMTPConfig    `json:"smtp"`
+	Webhook *WebhookConfig `json:"webhook"`
+	Name    string         `json:"name"`
+	Type    string         `json:"type"`
+}
+
+// New creates the Notifier described by cfg.
+func New(cfg *Config) (Notifier, error) {
+	switch strings.ToUpper(cfg.Type) {
+	case "SMTP", "EMAIL":
+		if cfg.SMTP == nil {
+			return nil, fmt.Errorf("notifier %q: no smtp section specified", cfg.Name)
+		}
+		return NewSMTP(cfg.SMTP)
+	case "WEBHOOK", "SLACK", "DISCORD", "TELEGRAM":
+		if cfg.Webhook == nil {
+			return nil, fmt.Errorf("notifier %q: no webhook section specified", cfg.Name)
+		}
+		return NewWebhook(cfg.Type, cfg.Webhook)
+	default:
+		return nil, fmt.Errorf("notifier %q: unknown type %s", cfg.Name, cfg.Type)
+	}
+}
+
+// Dispatcher delivers alerts to multiple named notifiers.
+type Dispatcher struct {
+	notifiers map[string]Notifier
+	names     []string
+}
+
+// NewDispatcher creates a Dispatcher for every notifier in cfgs.
+func NewDispatcher(cfgs ...*Config) (*Dispatcher, error) {
+	d := &Dispatcher{notifiers: make(map[string]Notifier, len(cfgs))}
+	for _, cfg := range cfgs {
+		if _, ok := d.notifiers[cfg.Name]; ok {
+			return nil, fmt.Errorf("duplicated notifier name %q", cfg.Name)
+		}
+		n, err := New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		d.Add(cfg.Name, n)
+	}
+	return d, nil
+}
+
+// Add registers a Notifier under the given name.
+func (d *Dispatcher) Add(name string, n Notifier) {
+	if _, ok := d.notifiers[name]; !ok {
+		d.names = append(d.names, name)
+	}
+	d.notifiers[name] = n
+}
+
+// Len returns the number of registered notifiers.
+func (d *Dispatcher) Len() int {
+	return len(d.names)
+}
+
+// Has reports whether a notifier with the given name is registered.
+func (d *Dispatcher) Has(name string) bool {
+	_, ok := d.notifiers[name]
+	return ok
+}
+
+// Notify delivers the alert to every registered notifier, joining their errors.
+func (d *Dispatcher) Notify(ctx context.Context, a *Alert) error {
+	return d.NotifyTo(ctx, a, d.names...)
+}
+
+// NotifyTo delivers the alert to the named notifiers, or to all of them if none is given, joining their errors.
+func (d *Dispatcher) NotifyTo(ctx context.Context, a *Alert, names ...string) error {
+	if len(names) == 0 {
+		names = d.names
+	}
+
+	var errs []error
+	for _, name := range names {
+		n, ok := d.notifiers[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown notifier %q", name))
+			continue
+		}
+		if err := n.Notify(ctx, a); err != nil {
+			errs = append(errs, fmt.Errorf("notifier %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/CanobbioE/algo-trading/pkg/notify"
+)
+
+type notifierFunc func(ctx context.Context, a *notify.Alert) error
+
+func (f notifierFunc) Notify(ctx context.Context, a *notify.Alert) error {
+	return f(ctx, a)
+}
+
+func TestNewDispatcher(t *testing.T) {
+	smtpCfg := &notify.SMTPConfig{Host: "localhost", From: "a@example.com", To: []string{"b@example.com"}}
+	for _, tc := range []struct {
+		name    string
+		wantErr string
+		cfgs    []*notify.Config
+	}{
+		{name: "no notifiers"},
+		{name: "smtp", cfgs: []*notify.Config{{Name: "me", Type: "smtp", SMTP: smtpCfg}}},
+		{
+			name:    "missing section",
+			cfgs:    []*notify.Config{{Name: "me", Type: "smtp"}},
+			wantErr: `notifier "me": no smtp section specified`,
+		},
+		{
+			name:    "unknown type",
+			cfgs:    []*notify.Config{{Name: "me", Type: "pigeon"}},
+			wantErr: `notifier "me": unknown type pigeon`,
+		},
+		{
+			name: "duplicated name",
+			cfgs: []*notify.Config{
+				{Name: "me", Type: "smtp", SMTP: smtpCfg},
+				{Name: "me", Type: "email", SMTP: smtpCfg},
+			},
+			wantErr: `duplicated notifier name "me"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := notify.NewDispatcher(tc.cfgs...)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("expected no error, instead got: %v", err)
+			case tc.wantErr == "" && d.Len() != len(tc.cfgs):
+				t.Fatalf("expected %d notifiers, instead got %d", len(tc.cfgs), d.Len())
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	var delivered []string
+	d, _ := notify.NewDispatcher()
+	d.Add("ok", notifierFunc(func(_ context.Context, a *notify.Alert) error {
+		delivered = append(delivered, a.Title)
+		return nil
+	}))
+	d.Add("broken", notifierFunc(func(context.Context, *notify.Alert) error {
+		return errors.New("boom")
+	}))
+	d.Add("also-ok", notifierFunc(func(_ context.Context, a *notify.Alert) error {
+		delivered = append(delivered, a.Title)
+		return nil
+	}))
+
+	err := d.Notify(context.Background(), testAlert())
+	if err == nil || err.Error() != `notifier "broken": boom` {
+		t.Fatalf("expected the broken notifier error, instead got: %v", err)
+	}
+	if len(delivered) != 2 {
+		t.Fatalf("expected the other notifiers to be delivered, instead got %v", delivered)
+	}
+}
@@ -0,0 +1,229 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTimeout bounds a whole SMTP exchange when the context has no deadline.
+const defaultTimeout = 30 * time.Second
+
+// SMTPConfig configures an SMTP notifier.
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// PasswordEnv is the name of an environment variable holding the password.
+	PasswordEnv string `json:"password_env"`
+	From        string `json:"from"`
+	// Security is one of "none", "starttls" or "tls".
+	Security           string   `json:"security"`
+	To                 []string `json:"to"`
+	Port               int      `json:"port"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify"`
+}
+
+// SMTP sends alerts by email.
+type SMTP struct {
+	cfg      *SMTPConfig
+	password string
+}
+
+// NewSMTP creates a new SMTP notifier.
+func NewSMTP(cfg *SMTPConfig) (*SMTP, error) {
+	switch {
+	case cfg.Host == "":
+		return nil, errors.New("smtp: no host specified")
+	case cfg.From == "":
+		return nil, errors.New("smtp: no sender specified")
+	case len(cfg.To) == 0:
+		return nil, errors.New("smtp: at least one recipient must be specified")
+	}
+
+	switch strings.ToLower(cfg.Security) {
+	case "", "none", "starttls", "tls":
+	default:
+		return nil, fmt.Errorf("smtp: invalid security %q, must be one of none, starttls or tls", cfg.Security)
+	}
+
+	password := cfg.Password
+	if cfg.PasswordEnv != "" {
+		password = os.Getenv(cfg.PasswordEnv)
+	}
+
+	return &SMTP{cfg: cfg, password: password}, nil
+}
+
+// Notify implements Notifier.
+func (s *SMTP) Notify(ctx context.Context, a *Alert) error {
+	msg, err := s.message(a)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if s.security() == "starttls" {
+		if err = c.StartTLS(s.tlsConfig()); err != nil {
+			return fmt.Errorf("smtp: starttls failed: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.cfg.Username, s.password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp: authentication failed: %w", err)
+		}
+	}
+
+	if err = c.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("smtp: sender rejected: %w", err)
+	}
+	for _, to := range s.cfg.To {
+		if err = c.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp: recipient %s rejected: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if _, err = w.Write(msg); err != nil {
+		return fmt.Errorf("smtp: failed to write message: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("smtp: message rejected: %w", err)
+	}
+
+	return c.Quit()
+}
+
+func (s *SMTP) dial(ctx context.Context) (*smtp.Client, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.port()))
+	dialer := &net.Dialer{Deadline: deadline}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.security() == "tls" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig()}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("smtp: failed to connect to %s: %w", addr, err)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("smtp: %w", err)
+	}
+	return c, nil
+}
+
+func (s *SMTP) security() string {
+	if s.cfg.Security == "" {
+		return "none"
+	}
+	return strings.ToLower(s.cfg.Security)
+}
+
+func (s *SMTP) port() int {
+	switch {
+	case s.cfg.Port != 0:
+		return s.cfg.Port
+	case s.security() == "tls":
+		return 465
+	case s.security() == "starttls":
+		return 587
+	default:
+		return 25
+	}
+}
+
+func (s *SMTP) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName: s.cfg.Host,
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // opt-in, for self-signed local relays
+		InsecureSkipVerify: s.cfg.InsecureSkipVerify,
+	}
+}
+
+// message builds a multipart email with both a plain text and an HTML body.
+func (s *SMTP) message(a *Alert) ([]byte, error) {
+	var text, html bytes.Buffer
+	if err := textBody.Execute(&text, a); err != nil {
+		return nil, fmt.Errorf("smtp: failed to render text body: %w", err)
+	}
+	if err := htmlBody.Execute(&html, a); err != nil {
+		return nil, fmt.Errorf("smtp: failed to render html body: %w", err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	for _, h := range [][2]string{
+		{"From", s.cfg.From},
+		{"To", strings.Join(s.cfg.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject(a))},
+		{"Date", a.Timestamp.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	} {
+		msg.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,385 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/notify"
+)
+
+// delivery is what the SMTP stand-in received during a single session.
+type delivery struct {
+	auth string
+	from string
+	to   []string
+	data string
+	tls  bool
+}
+
+// smtpServer is a minimal SMTP stand-in, supporting AUTH PLAIN and STARTTLS.
+type smtpServer struct {
+	listener   net.Listener
+	tlsConfig  *tls.Config
+	deliveries chan *delivery
+	startTLS   bool
+}
+
+func newSMTPServer(t *testing.T, security string) *smtpServer {
+	t.Helper()
+	cert := selfSignedCert(t)
+	s := &smtpServer{
+		tlsConfig:  &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+		deliveries: make(chan *delivery, 1),
+		startTLS:   security == "starttls",
+	}
+
+	var err error
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if security == "tls" {
+		s.listener = tls.NewListener(s.listener, s.tlsConfig)
+	}
+	t.Cleanup(func() {
+		_ = s.listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := s.listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, isTLS := conn.(*tls.Conn)
+	d := &delivery{tls: isTLS}
+	r := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		_, _ = io.WriteString(conn, strings.Join(lines, "\r\n")+"\r\n")
+	}
+
+	reply("220 localhost ESMTP stand-in")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if s.startTLS && !d.tls {
+				reply("250-localhost", "250-STARTTLS", "250 AUTH PLAIN")
+			} else {
+				reply("250-localhost", "250 AUTH PLAIN")
+			}
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err = tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, r, d.tls = tlsConn, bufio.NewReader(tlsConn), true
+		case "AUTH":
+			_, creds, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(creds)
+			d.auth = string(decoded)
+			reply("235 authenticated")
+		case "MAIL":
+			d.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			d.to = append(d.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err = r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			d.data = data.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			s.deliveries <- d
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func selfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func testAlert() *notify.Alert {
+	return &notify.Alert{
+		Timestamp: time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		Title:     "HIGH OPPORTUNITY ALERT",
+		Scores: []*monitor.StockScore{
+			{
+				Symbol:        "ENI.MTA",
+				LastPrice:     14.52,
+				Volume:        1250000,
+				BuySignals:    3,
+				HoldSignals:   1,
+				Confidence:    0.75,
+				WeightedScore: 2.4,
+				Risk:          monitor.RiskLow,
+				Opportunity:   monitor.OpportunityHigh,
+				Reasoning:     []string{"Breakout above resistance"},
+			},
+			{
+				Symbol:        "UCG.MTA",
+				LastPrice:     38.1,
+				Volume:        980000,
+				BuySignals:    2,
+				SetupSignals:  1,
+				Confidence:    0.5,
+				WeightedScore: 1.6,
+				Risk:          monitor.RiskMedium,
+				Opportunity:   monitor.OpportunityMedium,
+			},
+		},
+	}
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	type testCase struct {
+		name     string
+		security string
+		username string
+		want     *delivery
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:     "plain without auth",
+			security: "none",
+			want:     &delivery{from: "alerts@example.com", to: []string{"me@example.com", "you@example.com"}},
+		},
+		{
+			name:     "starttls with auth",
+			security: "starttls",
+			username: "alerts",
+			want: &delivery{
+				auth: "\x00alerts\x00secret",
+				from: "alerts@example.com",
+				to:   []string{"me@example.com", "you@example.com"},
+				tls:  true,
+			},
+		},
+		{
+			name:     "implicit tls with auth",
+			security: "tls",
+			username: "alerts",
+			want: &delivery{
+				auth: "\x00alerts\x00secret",
+				from: "alerts@example.com",
+				to:   []string{"me@example.com", "you@example.com"},
+				tls:  true,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newSMTPServer(t, tc.security)
+			n, err := notify.NewSMTP(&notify.SMTPConfig{
+				Host:               "127.0.0.1",
+				Port:               srv.port(),
+				Security:           tc.security,
+				Username:           tc.username,
+				Password:           "secret",
+				From:               "alerts@example.com",
+				To:                 []string{"me@example.com", "you@example.com"},
+				InsecureSkipVerify: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err = n.Notify(ctx, testAlert()); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+
+			got := <-srv.deliveries
+			data := got.data
+			got.data = ""
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(delivery{})); diff != "" {
+				t.Errorf("delivery mismatch (-want +got):\n%s", diff)
+			}
+			checkMessage(t, data)
+		})
+	}
+}
+
+func checkMessage(t *testing.T, data string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+
+	wantHeaders := map[string]string{
+		"From":    "alerts@example.com",
+		"To":      "me@example.com, you@example.com",
+		"Subject": "HIGH OPPORTUNITY ALERT: ENI.MTA, UCG.MTA",
+		"Date":    "Fri, 14 Mar 2025 09:30:00 +0000",
+	}
+	for k, want := range wantHeaders {
+		if got := msg.Header.Get(k); got != want {
+			t.Errorf("header %s: expected %q, instead got %q", k, want, got)
+		}
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, instead got %q (%v)", mediaType, err)
+	}
+
+	wantParts := []struct {
+		contentType string
+		contains    []string
+	}{
+		{
+			contentType: "text/plain; charset=utf-8",
+			contains: []string{
+				"ENI.MTA\n  Price: €14.52", "Signals: 3 BUY, 0 SELL, 1 HOLD, 0 SETUP", "Confidence: 75.0%",
+				"Risk: Low | Opportunity: High", "- Breakout above resistance", "UCG.MTA\n  Price: €38.10",
+			},
+		},
+		{
+			contentType: "text/html; charset=utf-8",
+			contains: []string{
+				"<h2>HIGH OPPORTUNITY ALERT</h2>", "<td><b>ENI.MTA</b></td><td>€14.52</td>", "<td>Medium</td><td>Medium</td>",
+			},
+		},
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for i, want := range wantParts {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		if got := part.Header.Get("Content-Type"); got != want.contentType {
+			t.Errorf("part %d: expected content type %q, instead got %q", i, want.contentType, got)
+		}
+		raw, _ := io.ReadAll(part)
+		// the SMTP client normalizes line endings to CRLF
+		body := strings.ReplaceAll(string(raw), "\r\n", "\n")
+		for _, s := range want.contains {
+			if !strings.Contains(body, s) {
+				t.Errorf("part %d: expected body to contain %q, instead got:\n%s", i, s, body)
+			}
+		}
+	}
+	if _, err = mr.NextPart(); err != io.EOF {
+		t.Errorf("expected exactly %d parts", len(wantParts))
+	}
+}
+
+func TestNewSMTP(t *testing.T) {
+	valid := func() *notify.SMTPConfig {
+		return &notify.SMTPConfig{Host: "localhost", From: "a@example.com", To: []string{"b@example.com"}}
+	}
+	for _, tc := range []struct {
+		name    string
+		mutate  func(c *notify.SMTPConfig)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*notify.SMTPConfig) {}},
+		{name: "missing host", mutate: func(c *notify.SMTPConfig) { c.Host = "" }, wantErr: "no host"},
+		{name: "missing sender", mutate: func(c *notify.SMTPConfig) { c.From = "" }, wantErr: "no sender"},
+		{name: "missing recipients", mutate: func(c *notify.SMTPConfig) { c.To = nil }, wantErr: "recipient"},
+		{name: "invalid security", mutate: func(c *notify.SMTPConfig) { c.Security = "ssl" }, wantErr: "invalid security"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid()
+			tc.mutate(cfg)
+			_, err := notify.NewSMTP(cfg)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("expected no error, instead got: %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSMTP_NotifyFailsWhenUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	_ = l.Close()
+
+	n, err := notify.NewSMTP(&notify.SMTPConfig{
+		Host: "127.0.0.1", Port: port, From: "a@example.com", To: []string{"b@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.Notify(context.Background(), testAlert())
+	if err == nil || !strings.Contains(err.Error(), "failed to connect to 127.0.0.1:"+strconv.Itoa(port)) {
+		t.Fatalf("expected connection error, instead got: %v", err)
+	}
+}
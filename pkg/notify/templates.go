@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"encoding/json"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+func subject(a *Alert) string {
+	title := a.Title
+	if a.Severity != "" {
+		title = "[" + a.Severity + "] " + title
+	}
+	if len(a.Scores) == 0 {
+		return title
+	}
+	symbols := make([]string, 0, len(a.Scores))
+	for _, score := range a.Scores {
+		symbols = append(symbols, score.Symbol)
+	}
+	return title + ": " + strings.Join(symbols, ", ")
+}
+
+var funcs = map[string]any{
+	"percent": func(f float64) string { return strconv.FormatFloat(f*100, 'f', 1, 64) + "%" },
+	"price":   func(f float64) string { return "€" + strconv.FormatFloat(f, 'f', 2, 64) },
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// Summary renders the alert as plain text.
+func Summary(a *Alert) (string, error) {
+	var sb strings.Builder
+	if err := textBody.Execute(&sb, a); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+var textBody = texttemplate.Must(texttemplate.New("text").Funcs(funcs).Parse(
+	`{{ with .Severity }}[{{ . }}] {{ end }}{{ .Title }} ({{ .Timestamp.Format "2006-01-02 15:04:05" }})
+{{- with .Message }}
+
+{{ . }}
+{{- end }}
+{{ range .Scores }}
+{{ .Symbol }}
+  Price: {{ price .LastPrice }}
+  Signals: {{ .BuySignals }} BUY, {{ .SellSignals }} SELL, {{ .HoldSignals }} HOLD, {{ .SetupSignals }} SETUP
+  Confidence: {{ percent .Confidence }}
+  Weighted Score: {{ printf "%.2f" .WeightedScore }}
+  Risk: {{ .Risk.String }} | Opportunity: {{ .Opportunity.String }}
+  Volume: {{ printf "%.0f" .Volume }}
+{{- range .Reasoning }}
+  - {{ . }}
+{{- end }}
+{{ end }}`))
+
+var htmlBody = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif">
+<h2>{{ with .Severity }}[{{ . }}] {{ end }}{{ .Title }}</h2>
+<p>{{ .Timestamp.Format "2006-01-02 15:04:05" }}</p>
+{{ with .Message }}<p>{{ . }}</p>
+{{ end }}{{ if .Scores }}<table border="1" cellpadding="6" style="border-collapse: collapse">
+<tr><th>Symbol</th><th>Price</th><th>Buy</th><th>Sell</th><th>Hold</th><th>Setup</th>` +
+		`<th>Confidence</th><th>Weighted Score</th><th>Risk</th><th>Opportunity</th><th>Volume</th></tr>
+{{ range .Scores }}<tr>
+<td><b>{{ .Symbol }}</b></td><td>{{ price .LastPrice }}</td>
+<td>{{ .BuySignals }}</td><td>{{ .SellSignals }}</td><td>{{ .HoldSignals }}</td><td>{{ .SetupSignals }}</td>
+<td>{{ percent .Confidence }}</td><td>{{ printf "%.2f" .WeightedScore }}</td>
+<td>{{ .Risk.String }}</td><td>{{ .Opportunity.String }}</td><td>{{ printf "%.0f" .Volume }}</td>
+</tr>
+{{ end }}</table>
+{{ end }}</body>
+</html>
+`))
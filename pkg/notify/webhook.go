@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	defaultRetries    = 3
+	defaultRetryDelay = time.Second
+	// maxErrorBody limits how much of an error response is reported back.
+	maxErrorBody = 512
+)
+
+// Preset payload templates for popular chat services.
+const (
+	slackTemplate    = `{"text": {{ json (summary .Alert) }}}`
+	discordTemplate  = `{"content": {{ json (summary .Alert) }}}`
+	telegramTemplate = `{"chat_id": {{ json .ChatID }}, "text": {{ json (summary .Alert) }}}`
+	webhookTemplate  = `{{ json .Alert }}`
+)
+
+// WebhookConfig configures a webhook notifier.
+type WebhookConfig struct {
+	Headers map[string]string `json:"headers"`
+	URL     string            `json:"url"`
+	// Method defaults to POST.
+	Method string `json:"method"`
+	// Template is a text/template rendering the request body, it overrides the preset one.
+	Template string `json:"template"`
+	// ChatID is the recipient of Telegram messages.
+	ChatID string `json:"chat_id"`
+	// RetryDelay is the delay before the first retry, doubled on each attempt.
+	RetryDelay string `json:"retry_delay"`
+	// Retries defaults to 3, a negative value disables them.
+	Retries int `json:"retries"`
+}
+
+// Webhook sends alerts as HTTP requests.
+type Webhook struct {
+	client     *http.Client
+	cfg        *WebhookConfig
+	tmpl       *template.Template
+	retryDelay time.Duration
+	retries    int
+}
+
+// payload is what the webhook template is executed against.
+type payload struct {
+	*Alert
+	ChatID string
+}
+
+// NewWebhook creates a new Webhook notifier, using the preset template for the given type
+// (webhook, slack, discord or telegram) unless the config defines its own.
+func NewWebhook(preset string, cfg *WebhookConfig) (*Webhook, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("webhook: no url specified")
+	}
+
+	text := cfg.Template
+	if text == "" {
+		switch strings.ToUpper(preset) {
+		case "SLACK":
+			text = slackTemplate
+		case "DISCORD":
+			text = discordTemplate
+		case "TELEGRAM":
+			if cfg.ChatID == "" {
+				return nil, errors.New("webhook: telegram requires a chat_id")
+			}
+			text = telegramTemplate
+		default:
+			text = webhookTemplate
+		}
+	}
+	tmpl, err := template.New("webhook").Funcs(funcs).Funcs(map[string]any{
+		"summary": Summary,
+		"subject": subject,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: invalid template: %w", err)
+	}
+
+	w := &Webhook{
+		client:     &http.Client{Timeout: defaultTimeout},
+		cfg:        cfg,
+		tmpl:       tmpl,
+		retries:    cfg.Retries,
+		retryDelay: defaultRetryDelay,
+	}
+	if w.retries == 0 {
+		w.retries = defaultRetries
+	}
+	if cfg.RetryDelay != "" {
+		w.retryDelay, err = time.ParseDuration(cfg.RetryDelay)
+		if err != nil {
+			return nil, fmt.Errorf("webhook: invalid retry delay: %w", err)
+		}
+	}
+
+	return w, nil
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(ctx context.Context, a *Alert) error {
+	var body bytes.Buffer
+	if err := w.tmpl.Execute(&body, &payload{Alert: a, ChatID: w.cfg.ChatID}); err != nil {
+		return fmt.Errorf("webhook: failed to render body: %w", err)
+	}
+
+	delay := w.retryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := w.send(ctx, body.Bytes())
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
+
+// send delivers the body once, reporting whether a failure is worth retrying.
+func (w *Webhook) send(ctx context.Context, body []byte) (bool, error) {
+	method := w.cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("webhook: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook: request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retry, fmt.Errorf("webhook: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,204 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/notify"
+)
+
+// request is what the webhook stand-in received.
+type request struct {
+	header http.Header
+	method string
+	body   string
+}
+
+// webhookServer answers with the given status codes in order, then with 200.
+type webhookServer struct {
+	*httptest.Server
+	statuses []int
+	requests []*request
+	mu       sync.Mutex
+}
+
+func newWebhookServer(t *testing.T, statuses ...int) *webhookServer {
+	t.Helper()
+	s := &webhookServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, &request{header: r.Header, method: r.Method, body: string(body)})
+		if len(s.statuses) > 0 {
+			w.WriteHeader(s.statuses[0])
+			_, _ = io.WriteString(w, http.StatusText(s.statuses[0]))
+			s.statuses = s.statuses[1:]
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestWebhook_NotifyPresets(t *testing.T) {
+	summary, err := notify.Summary(testAlert())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		preset string
+		chatID string
+		want   map[string]any
+	}{
+		{name: "slack", preset: "slack", want: map[string]any{"text": summary}},
+		{name: "discord", preset: "discord", want: map[string]any{"content": summary}},
+		{name: "telegram", preset: "telegram", chatID: "-1001", want: map[string]any{"chat_id": "-1001", "text": summary}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newWebhookServer(t)
+			n, err := notify.NewWebhook(tc.preset, &notify.WebhookConfig{URL: srv.URL, ChatID: tc.chatID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = n.Notify(context.Background(), testAlert()); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+
+			if len(srv.requests) != 1 {
+				t.Fatalf("expected 1 request, instead got %d", len(srv.requests))
+			}
+			var got map[string]any
+			if err = json.Unmarshal([]byte(srv.requests[0].body), &got); err != nil {
+				t.Fatalf("invalid json body %q: %v", srv.requests[0].body, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_NotifyCustomTemplate(t *testing.T) {
+	srv := newWebhookServer(t)
+	n, err := notify.NewWebhook("webhook", &notify.WebhookConfig{
+		URL:      srv.URL,
+		Method:   "put",
+		Headers:  map[string]string{"Authorization": "Bearer token", "Content-Type": "text/plain"},
+		Template: `{{ subject .Alert }}{{ range .Scores }} | {{ .Symbol }} {{ price .LastPrice }}{{ end }}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = n.Notify(context.Background(), testAlert()); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	got := srv.requests[0]
+	want := "HIGH OPPORTUNITY ALERT: ENI.MTA, UCG.MTA | ENI.MTA €14.52 | UCG.MTA €38.10"
+	if got.method != http.MethodPut || got.body != want {
+		t.Errorf("expected PUT %q, instead got %s %q", want, got.method, got.body)
+	}
+	if got.header.Get("Authorization") != "Bearer token" || got.header.Get("Content-Type") != "text/plain" {
+		t.Errorf("expected custom headers, instead got %v", got.header)
+	}
+}
+
+func TestWebhook_NotifyRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		wantErr      string
+		statuses     []int
+		retries      int
+		wantRequests int
+	}{
+		{
+			name:         "succeeds after transient failures",
+			statuses:     []int{http.StatusInternalServerError, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		{
+			name:         "gives up after the last retry",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			retries:      2,
+			wantRequests: 3,
+			wantErr:      "unexpected status 502 Bad Gateway: Bad Gateway",
+		},
+		{
+			name:         "does not retry client errors",
+			statuses:     []int{http.StatusBadRequest},
+			wantRequests: 1,
+			wantErr:      "unexpected status 400 Bad Request",
+		},
+		{
+			name:         "retries can be disabled",
+			statuses:     []int{http.StatusServiceUnavailable},
+			retries:      -1,
+			wantRequests: 1,
+			wantErr:      "unexpected status 503",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newWebhookServer(t, tc.statuses...)
+			n, err := notify.NewWebhook("slack", &notify.WebhookConfig{
+				URL: srv.URL, Retries: tc.retries, RetryDelay: "1ms",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = n.Notify(ctx, testAlert())
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("expected no error, instead got: %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+			if len(srv.requests) != tc.wantRequests {
+				t.Errorf("expected %d requests, instead got %d", tc.wantRequests, len(srv.requests))
+			}
+		})
+	}
+}
+
+func TestNewWebhook(t *testing.T) {
+	for _, tc := range []struct {
+		cfg     *notify.WebhookConfig
+		name    string
+		preset  string
+		wantErr string
+	}{
+		{name: "missing url", preset: "slack", cfg: &notify.WebhookConfig{}, wantErr: "no url"},
+		{
+			name: "telegram without chat", preset: "telegram",
+			cfg: &notify.WebhookConfig{URL: "http://localhost"}, wantErr: "chat_id",
+		},
+		{
+			name: "invalid template", preset: "webhook",
+			cfg: &notify.WebhookConfig{URL: "http://localhost", Template: "{{ .Nope"}, wantErr: "invalid template",
+		},
+		{
+			name: "invalid retry delay", preset: "discord",
+			cfg: &notify.WebhookConfig{URL: "http://localhost", RetryDelay: "soon"}, wantErr: "invalid retry delay",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := notify.NewWebhook(tc.preset, tc.cfg)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, instead got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
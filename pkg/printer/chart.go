@@ -0,0 +1,377 @@
+package printer
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"golang.org/x/term"
+)
+
+// ChartStyle defines how a Chart draws the prices.
+type ChartStyle string
+
+const (
+	// Candlesticks draws the open, high, low and close of every bar.
+	Candlesticks ChartStyle = "candles"
+	// Line draws the close of every bar.
+	Line ChartStyle = "line"
+)
+
+// ParseChartStyle returns the ChartStyle named s.
+func ParseChartStyle(s string) (ChartStyle, error) {
+	switch style := ChartStyle(strings.ToLower(s)); style {
+	case Candlesticks, Line:
+		return style, nil
+	default:
+		return "", fmt.Errorf("invalid chart style %q, expected %s or %s", s, Candlesticks, Line)
+	}
+}
+
+// The layout of a chart, in characters.
+const (
+	// DefaultChartWidth is the width of a chart when the one of the terminal is unknown.
+	DefaultChartWidth = 80
+	// DefaultChartHeight is the default height of the price panel of a chart.
+	DefaultChartHeight = 20
+	minChartHeight     = 5
+	volumeHeight       = 3
+	axisPadding        = 2
+)
+
+var eighths = []rune("▁▂▃▄▅▆▇█")
+
+// Overlay is a series of values drawn over the prices, such as an indicator.
+type Overlay struct {
+	Name string
+	// Values has a value for each bar of the chart, the zero ones are not drawn.
+	Values []float64
+	Mark   rune
+	Color  Color
+}
+
+// Chart draws the price history of a symbol with text characters, followed by a strip of the volumes.
+type Chart struct {
+	Style    ChartStyle
+	Bars     []*api.OHLCV
+	Overlays []*Overlay
+	// Width is the number of characters of a line, including the price axis.
+	// Only the latest bars fitting in it are drawn.
+	Width int
+	// Height is the number of lines of the price panel.
+	Height int
+}
+
+// cell is a character of a chart.
+type cell struct {
+	r     rune
+	color Color
+}
+
+// grid is a panel of a chart.
+type grid [][]cell
+
+func newGrid(height, width int) grid {
+	g := make(grid, height)
+	for i := range g {
+		g[i] = make([]cell, width)
+		for j := range g[i] {
+			g[i][j] = cell{r: ' ', color: None}
+		}
+	}
+	return g
+}
+
+func (g grid) set(row, col int, r rune, color Color) {
+	if row >= 0 && row < len(g) && col >= 0 && col < len(g[row]) {
+		g[row][col] = cell{r: r, color: color}
+	}
+}
+
+// line renders a row of the grid, coloring the runs of characters of the same color.
+func (g grid) line(row int) string {
+	var b strings.Builder
+	for i := 0; i < len(g[row]); {
+		j := i
+		var run strings.Builder
+		for ; j < len(g[row]) && g[row][j].color == g[row][i].color; j++ {
+			run.WriteRune(g[row][j].r)
+		}
+		b.WriteString(WrapInColor(run.String(), g[row][i].color))
+		i = j
+	}
+	return b.String()
+}
+
+// layout places the bars of a chart in its columns.
+type layout struct {
+	// start is the index of the first bar that is drawn.
+	start int
+	// step is the number of columns of a bar.
+	step int
+	// offset is the column of the first bar.
+	offset int
+}
+
+// col returns the column of the i-th bar.
+func (l *layout) col(i int) int {
+	return l.offset + (i-l.start)*l.step
+}
+
+// priceScale maps prices to the rows of the price panel.
+type priceScale struct {
+	low, high float64
+	rows      int
+}
+
+func (s *priceScale) row(price float64) int {
+	return int(math.Round((s.high - price) / (s.high - s.low) * float64(s.rows-1)))
+}
+
+func (s *priceScale) price(row int) float64 {
+	return s.high - (s.high-s.low)*float64(row)/float64(s.rows-1)
+}
+
+// Print prints the chart to p.
+func (c *Chart) Print(p Printer) {
+	for _, line := range c.Render() {
+		p.Printf("%s\n", line)
+	}
+}
+
+// Render returns the lines of the chart.
+func (c *Chart) Render() []string {
+	if len(c.Bars) == 0 {
+		return []string{"No data to chart"}
+	}
+	height := max(minChartHeight, c.Height)
+	width := c.Width
+	if width <= 0 {
+		width = DefaultChartWidth
+	}
+
+	scale := c.scale(0, len(c.Bars), height)
+	labelWidth := max(len(formatPrice(scale.low)), len(formatPrice(scale.high))) + axisPadding
+	plotWidth := max(1, width-labelWidth)
+	// candles are drawn a column apart when there is room for it
+	step := 1
+	if c.Style != Line && 2*len(c.Bars) <= plotWidth {
+		step = 2
+	}
+	start := max(0, len(c.Bars)-plotWidth/step)
+	// the latest bar is drawn next to the axis
+	l := &layout{start: start, step: step, offset: plotWidth - 1 - (len(c.Bars)-start-1)*step}
+	scale = c.scale(start, len(c.Bars), height)
+
+	prices := newGrid(height, plotWidth)
+	c.drawOverlays(prices, scale, l)
+	if c.Style == Line {
+		c.drawLine(prices, scale, l)
+	} else {
+		c.drawCandles(prices, scale, l)
+	}
+	volumes := newGrid(volumeHeight, plotWidth)
+	maxVolume := c.drawVolumes(volumes, l)
+
+	lines := make([]string, 0, height+volumeHeight+2)
+	for row := range prices {
+		label := ""
+		if row%max(1, height/4) == 0 || row == height-1 {
+			label = formatPrice(scale.price(row))
+		}
+		lines = append(lines, prices.line(row)+" ┤"+label)
+	}
+	for row := range volumes {
+		label := ""
+		if row == 0 {
+			label = formatVolume(maxVolume)
+		}
+		lines = append(lines, volumes.line(row)+" │"+label)
+	}
+	lines = append(lines, c.dates(l, plotWidth))
+	if legend := c.legend(start); legend != "" {
+		lines = append(lines, legend)
+	}
+	return lines
+}
+
+// scale fits the bars between start and end and their overlays in the given rows.
+func (c *Chart) scale(start, end, rows int) *priceScale {
+	s := &priceScale{low: math.Inf(1), high: math.Inf(-1), rows: rows}
+	fit := func(v float64) {
+		if v > 0 {
+			s.low, s.high = min(s.low, v), max(s.high, v)
+		}
+	}
+	for i := start; i < end; i++ {
+		if c.Style == Line {
+			fit(c.Bars[i].Close)
+		} else {
+			fit(c.Bars[i].Low)
+			fit(c.Bars[i].High)
+		}
+		for _, o := range c.Overlays {
+			if i < len(o.Values) {
+				fit(o.Values[i])
+			}
+		}
+	}
+	if math.IsInf(s.low, 1) {
+		s.low, s.high = 0, 1
+	}
+	if s.high == s.low {
+		s.high, s.low = s.high+1, s.low-1
+	}
+	return s
+}
+
+func (c *Chart) drawOverlays(g grid, s *priceScale, l *layout) {
+	for _, o := range c.Overlays {
+		for i := l.start; i < min(len(c.Bars), len(o.Values)); i++ {
+			if o.Values[i] == 0 {
+				continue
+			}
+			for j := range l.step {
+				g.set(s.row(o.Values[i]), l.col(i)+j, o.Mark, o.Color)
+			}
+		}
+	}
+}
+
+func (c *Chart) drawCandles(g grid, s *priceScale, l *layout) {
+	for i := l.start; i < len(c.Bars); i++ {
+		bar, col := c.Bars[i], l.col(i)
+		color := Green
+		if bar.Close < bar.Open {
+			color = Red
+		}
+		for row := s.row(bar.High); row <= s.row(bar.Low); row++ {
+			g.set(row, col, '│', color)
+		}
+		for row := s.row(max(bar.Open, bar.Close)); row <= s.row(min(bar.Open, bar.Close)); row++ {
+			g.set(row, col, '┃', color)
+		}
+	}
+}
+
+func (c *Chart) drawLine(g grid, s *priceScale, l *layout) {
+	previous := -1
+	for i := l.start; i < len(c.Bars); i++ {
+		row := s.row(c.Bars[i].Close)
+		if previous >= 0 {
+			// joins the previous close
+			for r := min(row, previous) + 1; r < max(row, previous); r++ {
+				g.set(r, l.col(i), '│', Blue)
+			}
+		}
+		g.set(row, l.col(i), '•', Blue)
+		previous = row
+	}
+}
+
+// drawVolumes draws the volume of every bar as a bar of eighths of character and returns the highest volume.
+func (c *Chart) drawVolumes(g grid, l *layout) float64 {
+	var highest float64
+	for _, bar := range c.Bars[l.start:] {
+		highest = max(highest, bar.Volume)
+	}
+	if highest == 0 {
+		return 0
+	}
+	levels := len(g) * len(eighths)
+	for i := l.start; i < len(c.Bars); i++ {
+		bar := c.Bars[i]
+		color := Green
+		if bar.Close < bar.Open {
+			color = Red
+		}
+		level := int(math.Round(bar.Volume / highest * float64(levels)))
+		for row := range g {
+			// the rows are filled from the bottom
+			fill := min(len(eighths), level-(len(g)-1-row)*len(eighths))
+			if fill > 0 {
+				g.set(row, l.col(i), eighths[fill-1], color)
+			}
+		}
+	}
+	return highest
+}
+
+// dates labels the first and the last charted bar, below them.
+func (c *Chart) dates(l *layout, width int) string {
+	first, last := c.Bars[l.start].Timestamp, c.Bars[len(c.Bars)-1].Timestamp
+	format := time.DateOnly
+	if !midnight(first) || !midnight(last) {
+		format = "2006-01-02 15:04"
+	}
+	from, to := first.Format(format), last.Format(format)
+	indent := strings.Repeat(" ", l.offset)
+	if gap := width - l.offset - len(from) - len(to); gap > 0 {
+		return indent + from + strings.Repeat(" ", gap) + to
+	}
+	return strings.Repeat(" ", max(0, width-len(to))) + to
+}
+
+func midnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0
+}
+
+// legend names the overlays that are drawn, once for overlays sharing the name.
+func (c *Chart) legend(start int) string {
+	entries := make([]string, 0, len(c.Overlays))
+	named := make(map[string]bool, len(c.Overlays))
+	for _, o := range c.Overlays {
+		if named[o.Name] {
+			continue
+		}
+		for i := start; i < len(o.Values); i++ {
+			if o.Values[i] != 0 {
+				entries = append(entries, WrapInColor(string(o.Mark), o.Color)+" "+o.Name)
+				named[o.Name] = true
+				break
+			}
+		}
+	}
+	return strings.Join(entries, "  ")
+}
+
+func formatPrice(price float64) string {
+	switch {
+	case price >= 1000:
+		return strconv.FormatFloat(price, 'f', 0, 64)
+	case price >= 1:
+		return strconv.FormatFloat(price, 'f', 2, 64)
+	default:
+		return strconv.FormatFloat(price, 'f', 4, 64)
+	}
+}
+
+func formatVolume(volume float64) string {
+	switch {
+	case volume >= 1e9:
+		return strconv.FormatFloat(volume/1e9, 'f', 1, 64) + "B"
+	case volume >= 1e6:
+		return strconv.FormatFloat(volume/1e6, 'f', 1, 64) + "M"
+	case volume >= 1e3:
+		return strconv.FormatFloat(volume/1e3, 'f', 1, 64) + "K"
+	default:
+		return strconv.FormatFloat(volume, 'f', 0, 64)
+	}
+}
+
+// TerminalWidth returns the width of the terminal f is attached to,
+// falling back to the COLUMNS environment variable and then to DefaultChartWidth.
+func TerminalWidth(f *os.File) int {
+	//nolint:gosec // file descriptors fit in an int
+	if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return DefaultChartWidth
+}
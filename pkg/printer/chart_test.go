@@ -0,0 +1,116 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+)
+
+func TestChart_Print(t *testing.T) {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	bars := []*api.OHLCV{
+		{Timestamp: day, Open: 10, High: 12, Low: 9, Close: 11, Volume: 100},
+		{Timestamp: day.AddDate(0, 0, 1), Open: 11, High: 14, Low: 11, Close: 13, Volume: 400},
+		{Timestamp: day.AddDate(0, 0, 2), Open: 13, High: 13, Low: 10, Close: 10, Volume: 200},
+	}
+	sma := []*printer.Overlay{{Name: "SMA", Mark: '·', Values: []float64{0, 11, 12}}}
+
+	type testCase struct {
+		chart *printer.Chart
+		name  string
+		want  []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:  "draws candlesticks next to the axis",
+			chart: &printer.Chart{Style: printer.Candlesticks, Bars: bars, Overlays: sma, Width: 30, Height: 6},
+			want: []string{
+				"                    │   ┤14.00",
+				"                    ┃ ┃ ┤13.00",
+				"                  │ ┃ ┃ ┤12.00",
+				"                  ┃ ┃·┃ ┤11.00",
+				"                  ┃   ┃ ┤10.00",
+				"                  │     ┤9.00",
+				"                    █   │400",
+				"                    █ ▄ │",
+				"                  ▆ █ █ │",
+				"             2025-06-04",
+				"· SMA",
+			},
+		},
+		{
+			name:  "draws a line of the closes",
+			chart: &printer.Chart{Style: printer.Line, Bars: bars, Overlays: sma, Width: 30, Height: 6},
+			want: []string{
+				"                     •  ┤13.00",
+				"                     ││ ┤12.40",
+				"                     ││ ┤11.80",
+				"                    •·│ ┤11.20",
+				"                      │ ┤10.60",
+				"                      • ┤10.00",
+				"                     █  │400",
+				"                     █▄ │",
+				"                    ▆██ │",
+				"             2025-06-04",
+				"· SMA",
+			},
+		},
+		{
+			name:  "draws the latest bars fitting the width",
+			chart: &printer.Chart{Style: printer.Line, Bars: bars, Width: 9, Height: 5},
+			want: []string{
+				"•  ┤13.00",
+				" │ ┤12.25",
+				" │ ┤11.50",
+				" │ ┤10.75",
+				" • ┤10.00",
+				"█  │400",
+				"█▄ │",
+				"██ │",
+				"2025-06-04",
+			},
+		},
+		{
+			name:  "draws nothing without bars",
+			chart: &printer.Chart{Style: printer.Candlesticks},
+			want:  []string{"No data to chart"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b strings.Builder
+			tc.chart.Print(printer.NewStringsPrinter(&b))
+			got := strings.Split(strings.TrimSuffix(printer.CleanOutput(&b), "\n"), "\n")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Print() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseChartStyle(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		want    printer.ChartStyle
+		wantErr bool
+	}{
+		{name: "candles", want: printer.Candlesticks},
+		{name: "LINE", want: printer.Line},
+		{name: "bars", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := printer.ParseChartStyle(tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseChartStyle() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseChartStyle() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
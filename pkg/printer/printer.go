@@ -4,6 +4,7 @@ package printer
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -21,22 +22,35 @@ type Printer interface {
 	CleanLine() Printer
 }
 
-// Standard prints to os.Stdout.
+// Standard prints to os.Stdout, or to the writer it was created with.
 type Standard struct {
+	w         io.Writer
 	cleanLine bool
 }
 
+// NewStandard creates a new Standard printer writing to w, such as os.Stderr.
+func NewStandard(w io.Writer) *Standard {
+	return &Standard{w: w}
+}
+
+func (s *Standard) writer() io.Writer {
+	if s.w == nil {
+		return os.Stdout
+	}
+	return s.w
+}
+
 // Printf the message to std output.
 func (s *Standard) Printf(format string, a ...any) {
 	if s.cleanLine {
 		format += "\033[K"
 	}
-	_, _ = fmt.Fprintf(os.Stdout, format, a...)
+	_, _ = fmt.Fprintf(s.writer(), format, a...)
 }
 
 // Println prints a message followed by a new line.
-func (*Standard) Println(msg string) {
-	_, _ = fmt.Fprintf(os.Stdout, "%s", msg+"\n")
+func (s *Standard) Println(msg string) {
+	_, _ = fmt.Fprintf(s.writer(), "%s", msg+"\n")
 }
 
 // PrintColored prints the message in the given color.
@@ -58,6 +72,6 @@ func (s *Standard) Reset() {
 }
 
 // CleanLine returns the printer with the clean line option enabled.
-func (*Standard) CleanLine() Printer {
-	return &Standard{cleanLine: true}
+func (s *Standard) CleanLine() Printer {
+	return &Standard{w: s.w, cleanLine: true}
 }
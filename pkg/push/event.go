@@ -0,0 +1,139 @@
+package push
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+)
+
+// Type is the kind of an Event.
+type Type string
+
+const (
+	// TypeScan is a scan, with the scores meeting the filters, best opportunities first.
+	TypeScan Type = "scan"
+	// TypeScore is the latest score of a single stock.
+	TypeScore Type = "score"
+	// TypeAlert is an alert raised by a rule.
+	TypeAlert Type = "alert"
+	// TypeHeartbeat is sent when no other event is, carrying the sequence number of the latest event.
+	TypeHeartbeat Type = "heartbeat"
+	// TypeReset is sent when the events following the requested sequence number are lost,
+	// either because too many were published since or because the monitor restarted.
+	TypeReset Type = "reset"
+)
+
+// Event is a message sent to the clients.
+type Event struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Scan      *report.Scan  `json:"scan,omitempty"`
+	Score     *report.Score `json:"score,omitempty"`
+	Alert     *Alert        `json:"alert,omitempty"`
+	Type      Type          `json:"type"`
+	// Seq is the sequence number of the event, increasing by one per published event.
+	// Heartbeats and resets carry the one of the latest event.
+	Seq uint64 `json:"seq"`
+	// MeetsCriteria is true if the score of a score event passed the scan filters.
+	MeetsCriteria bool `json:"meets_criteria,omitempty"`
+}
+
+// Alert is the serialization of an alert.Notification.
+type Alert struct {
+	Rule      string          `json:"rule"`
+	Scores    []*report.Score `json:"scores"`
+	Severity  alert.Severity  `json:"severity"`
+	Escalated bool            `json:"escalated,omitempty"`
+}
+
+// scores returns the scores the event is about.
+func (e *Event) scores() []*report.Score {
+	switch {
+	case e.Scan != nil:
+		return e.Scan.Scores
+	case e.Score != nil:
+		return []*report.Score{e.Score}
+	case e.Alert != nil:
+		return e.Alert.Scores
+	default:
+		return nil
+	}
+}
+
+// Filter selects the events a client receives. Empty fields select everything.
+type Filter struct {
+	Types   []Type
+	Symbols []string
+	// Rules selects the alerts raised by the given rules, leaving the other types of events untouched.
+	Rules []string
+}
+
+// ParseFilter reads a Filter from the comma-separated types, symbols and rules query parameters.
+func ParseFilter(q url.Values) *Filter {
+	f := &Filter{}
+	for _, t := range list(q.Get("types")) {
+		f.Types = append(f.Types, Type(strings.ToLower(t)))
+	}
+	for _, s := range list(q.Get("symbols")) {
+		f.Symbols = append(f.Symbols, strings.ToUpper(s))
+	}
+	f.Rules = list(q.Get("rules"))
+	return f
+}
+
+func list(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Apply returns the part of e selected by the filter, nil if none.
+// The scans and the alerts only keep the scores of the selected symbols.
+func (f *Filter) Apply(e *Event) *Event {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return nil
+	}
+	if e.Alert != nil && len(f.Rules) > 0 && !slices.Contains(f.Rules, e.Alert.Rule) {
+		return nil
+	}
+	if len(f.Symbols) == 0 {
+		return e
+	}
+
+	var scores []*report.Score
+	for _, score := range e.scores() {
+		if slices.Contains(f.Symbols, strings.ToUpper(score.Symbol)) {
+			scores = append(scores, score)
+		}
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+
+	out := *e
+	switch {
+	case e.Scan != nil:
+		out.Scan = &report.Scan{Timestamp: e.Scan.Timestamp, Scores: scores}
+	case e.Alert != nil:
+		a := *e.Alert
+		a.Scores = scores
+		out.Alert = &a
+	}
+	return &out
+}
+
+// parseSeq reads the sequence number to resume from, 0 if none.
+func parseSeq(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
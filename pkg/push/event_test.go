@@ -0,0 +1,73 @@
+package push_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/push"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+)
+
+func TestFilter_Apply(t *testing.T) {
+	ts := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	eni := &report.Score{Symbol: "ENI.MTA", Rank: 1}
+	a2a := &report.Score{Symbol: "A2A.MTA", Rank: 2}
+	scan := &push.Event{Timestamp: ts, Type: push.TypeScan, Seq: 1, Scan: &report.Scan{
+		Timestamp: ts,
+		Scores:    []*report.Score{eni, a2a},
+	}}
+	score := &push.Event{Timestamp: ts, Type: push.TypeScore, Seq: 2, Score: a2a}
+	alert := &push.Event{Timestamp: ts, Type: push.TypeAlert, Seq: 3, Alert: &push.Alert{
+		Rule:   "breakout",
+		Scores: []*report.Score{eni, a2a},
+	}}
+
+	type testCase struct {
+		event *push.Event
+		want  *push.Event
+		name  string
+		query string
+	}
+
+	for _, tc := range []testCase{
+		{name: "selects everything", event: scan, want: scan},
+		{name: "selects the types", query: "types=score,alert", event: score, want: score},
+		{name: "drops the other types", query: "types=score,alert", event: scan},
+		{name: "selects the symbols", query: "symbols=a2a.mta", event: score, want: score},
+		{name: "drops the other symbols", query: "symbols=ENI.MTA", event: score},
+		{
+			name:  "keeps the selected scores of a scan",
+			query: "symbols=ENI.MTA, UNI.MTA",
+			event: scan,
+			want: &push.Event{Timestamp: ts, Type: push.TypeScan, Seq: 1, Scan: &report.Scan{
+				Timestamp: ts,
+				Scores:    []*report.Score{eni},
+			}},
+		},
+		{
+			name:  "keeps the selected scores of an alert",
+			query: "symbols=A2A.MTA&rules=breakout",
+			event: alert,
+			want: &push.Event{Timestamp: ts, Type: push.TypeAlert, Seq: 3, Alert: &push.Alert{
+				Rule:   "breakout",
+				Scores: []*report.Score{a2a},
+			}},
+		},
+		{name: "drops the alerts of the other rules", query: "rules=volume", event: alert},
+		{name: "ignores the rules for the other types", query: "rules=volume", event: score, want: score},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			got := push.ParseFilter(q).Apply(tc.event)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Apply() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
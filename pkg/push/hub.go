@@ -0,0 +1,303 @@
+// Package push broadcasts the scans, the scores and the alerts of the monitor to websocket clients as they happen.
+package push
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+)
+
+const (
+	// DefaultBacklog is how many of the latest events are kept for the clients resuming.
+	DefaultBacklog = 1000
+	// DefaultHeartbeat is how long a client waits for a heartbeat when no event is published.
+	DefaultHeartbeat = 30 * time.Second
+	// subscriberBuffer is how many events a client can fall behind before being disconnected.
+	subscriberBuffer = 256
+	// writeTimeout is how long a client has to receive a message.
+	writeTimeout = 10 * time.Second
+)
+
+// ErrClosed is returned to the clients connecting once the hub is closed.
+var ErrClosed = errors.New("the hub is closed")
+
+// Hub sends the published events to the subscribed clients, keeping a backlog so that clients can resume.
+// A nil *Hub is valid and publishes nothing, so that the callers don't need to check whether pushing is enabled.
+type Hub struct {
+	upgrader    websocket.Upgrader
+	subscribers map[*subscriber]struct{}
+	logger      *slog.Logger
+	backlog     []*Event
+	heartbeat   time.Duration
+	size        int
+	seq         uint64
+	// clients tracks the connections, so that Close can wait for them to be closed.
+	clients sync.WaitGroup
+	mu      sync.Mutex
+	closed  bool
+}
+
+type subscriber struct {
+	filter *Filter
+	events chan *Event
+	// reason is why events was closed, set before closing it.
+	reason string
+	code   int
+}
+
+// NewHub creates a new Hub keeping the latest backlog events and sending heartbeats every heartbeat,
+// DefaultHeartbeat if not positive.
+func NewHub(backlog int, heartbeat time.Duration) *Hub {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+	return &Hub{
+		subscribers: make(map[*subscriber]struct{}),
+		logger:      slog.Default(),
+		heartbeat:   heartbeat,
+		size:        backlog,
+	}
+}
+
+// SetLogger replaces the logger of the diagnostics, slog.Default() unless set.
+func (h *Hub) SetLogger(l *slog.Logger) {
+	h.logger = l
+}
+
+// PublishScan sends the ranked scores of a scan run at timestamp.
+func (h *Hub) PublishScan(timestamp time.Time, ranked []*monitor.StockScore) {
+	if h == nil {
+		return
+	}
+	h.publish(&Event{Timestamp: timestamp, Type: TypeScan, Scan: report.NewScan(timestamp, ranked)})
+}
+
+// PublishScore sends the latest score of a stock, computed at timestamp.
+func (h *Hub) PublishScore(timestamp time.Time, score *monitor.StockScore, meetsCriteria bool) {
+	if h == nil {
+		return
+	}
+	h.publish(&Event{
+		Timestamp:     timestamp,
+		Type:          TypeScore,
+		Score:         report.NewScore(timestamp, 0, score),
+		MeetsCriteria: meetsCriteria,
+	})
+}
+
+// PublishAlert sends an alert being delivered.
+func (h *Hub) PublishAlert(n *alert.Notification) {
+	if h == nil {
+		return
+	}
+	scores := make([]*report.Score, 0, len(n.Scores))
+	for _, score := range n.Scores {
+		scores = append(scores, report.NewScore(n.Timestamp, 0, score))
+	}
+	h.publish(&Event{
+		Timestamp: n.Timestamp,
+		Type:      TypeAlert,
+		Alert:     &Alert{Rule: n.Rule, Scores: scores, Severity: n.Severity, Escalated: n.Escalated},
+	})
+}
+
+func (h *Hub) publish(e *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	h.seq++
+	e.Seq = h.seq
+	h.backlog = append(h.backlog, e)
+	if len(h.backlog) > h.size {
+		h.backlog = slices.Delete(h.backlog, 0, len(h.backlog)-h.size)
+	}
+	for sub := range h.subscribers {
+		out := sub.filter.Apply(e)
+		if out == nil {
+			continue
+		}
+		select {
+		case sub.events <- out:
+		default:
+			// the client can resume from the last event it received
+			h.drop(sub, websocket.CloseTryAgainLater, "too slow, resume from the last sequence number")
+		}
+	}
+}
+
+// Close disconnects every client and stops accepting new ones, returning once the connections are closed.
+func (h *Hub) Close() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	h.closed = true
+	for sub := range h.subscribers {
+		h.drop(sub, websocket.CloseGoingAway, "the monitor is stopping")
+	}
+	h.mu.Unlock()
+	h.clients.Wait()
+}
+
+// drop disconnects sub, h.mu must be held.
+func (h *Hub) drop(sub *subscriber, code int, reason string) {
+	delete(h.subscribers, sub)
+	sub.code, sub.reason = code, reason
+	close(sub.events)
+}
+
+// subscribe registers a client, returning the events it missed if resuming after seq.
+// If some of them are no longer in the backlog, a reset event is returned instead.
+// The client must call h.clients.Done once disconnected.
+func (h *Hub) subscribe(filter *Filter, seq uint64, resume bool) (*subscriber, []*Event, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, nil, ErrClosed
+	}
+
+	sub := &subscriber{filter: filter, events: make(chan *Event, subscriberBuffer)}
+	h.subscribers[sub] = struct{}{}
+	h.clients.Add(1)
+	if !resume || seq == h.seq {
+		return sub, nil, nil
+	}
+	// a sequence number ahead of the latest one was issued before the monitor restarted
+	if seq > h.seq || len(h.backlog) == 0 || h.backlog[0].Seq > seq+1 {
+		return sub, []*Event{{Timestamp: time.Now(), Type: TypeReset, Seq: h.seq}}, nil
+	}
+
+	var missed []*Event
+	for _, e := range h.backlog {
+		if e.Seq <= seq {
+			continue
+		}
+		if out := filter.Apply(e); out != nil {
+			missed = append(missed, out)
+		}
+	}
+	return sub, missed, nil
+}
+
+func (h *Hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		h.drop(sub, websocket.CloseNormalClosure, "")
+	}
+}
+
+// caughtUp returns the sequence number of the latest event, if sub received every event it selected up to it.
+func (h *Hub) caughtUp(sub *subscriber) (uint64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// the events are queued while holding the lock, the ones dequeued are already sent
+	return h.seq, len(sub.events) == 0
+}
+
+// ServeHTTP implements http.Handler, upgrading the request to a websocket receiving the events
+// selected by ParseFilter, after the ones following the since query parameter, if set.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	seq, err := parseSeq(q.Get("since"))
+	if err != nil {
+		http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	sub, missed, err := h.subscribe(ParseFilter(q), seq, q.Has("since"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer h.clients.Done()
+	defer h.unsubscribe(sub)
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	done := make(chan struct{})
+	go h.read(conn, done)
+	if err = h.write(conn, sub, missed, done); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+		h.logger.Debug("websocket client disconnected", "addr", r.RemoteAddr, "error", err)
+	}
+}
+
+// read discards the messages of the client, closing done once it disconnects or stops answering the pings.
+func (h *Hub) read(conn *websocket.Conn, done chan<- struct{}) {
+	defer close(done)
+	conn.SetReadLimit(512)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// write sends the missed events and then the published ones, with heartbeats in between.
+func (h *Hub) write(conn *websocket.Conn, sub *subscriber, missed []*Event, done <-chan struct{}) error {
+	for _, e := range missed {
+		if err := send(conn, e); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case e, ok := <-sub.events:
+			if !ok {
+				msg := websocket.FormatCloseMessage(sub.code, sub.reason)
+				return conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeTimeout))
+			}
+			if err := send(conn, e); err != nil {
+				return err
+			}
+			ticker.Reset(h.heartbeat)
+		case <-ticker.C:
+			deadline := time.Now().Add(writeTimeout)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return err
+			}
+			seq, ok := h.caughtUp(sub)
+			if !ok {
+				// the pending events are sent next
+				continue
+			}
+			if err := send(conn, &Event{Timestamp: time.Now(), Type: TypeHeartbeat, Seq: seq}); err != nil {
+				return err
+			}
+		case <-done:
+			return nil
+		}
+	}
+}
+
+func send(conn *websocket.Conn, e *Event) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(e)
+}
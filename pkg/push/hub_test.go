@@ -0,0 +1,179 @@
+package push_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/push"
+)
+
+var (
+	day = time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	eni = &monitor.StockScore{Symbol: "ENI.MTA", WeightedScore: 2.8, Confidence: 0.4}
+	a2a = &monitor.StockScore{Symbol: "A2A.MTA", WeightedScore: -1, Confidence: 0.2}
+)
+
+func dial(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?" + query
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+// summary is what the tests check of an event.
+type summary struct {
+	Type    push.Type
+	Symbols string
+	Seq     uint64
+}
+
+func read(t *testing.T, conn *websocket.Conn) summary {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	var e push.Event
+	if err := conn.ReadJSON(&e); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	var symbols []string
+	switch {
+	case e.Scan != nil:
+		for _, s := range e.Scan.Scores {
+			symbols = append(symbols, s.Symbol)
+		}
+	case e.Score != nil:
+		symbols = append(symbols, e.Score.Symbol)
+	case e.Alert != nil:
+		for _, s := range e.Alert.Scores {
+			symbols = append(symbols, s.Symbol)
+		}
+	}
+	return summary{Type: e.Type, Symbols: strings.Join(symbols, ","), Seq: e.Seq}
+}
+
+func TestHub_Subscribe(t *testing.T) {
+	hub := push.NewHub(push.DefaultBacklog, 50*time.Millisecond)
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	live := dial(t, server, "symbols=ENI.MTA")
+	hub.PublishScore(day, a2a, false)
+	hub.PublishScan(day, []*monitor.StockScore{eni, a2a})
+	hub.PublishAlert(&alert.Notification{Timestamp: day, Rule: "breakout", Scores: []*monitor.StockScore{eni}})
+
+	want := []summary{
+		{Type: push.TypeScan, Symbols: "ENI.MTA", Seq: 2},
+		{Type: push.TypeAlert, Symbols: "ENI.MTA", Seq: 3},
+		{Type: push.TypeHeartbeat, Seq: 3},
+	}
+	got := []summary{read(t, live), read(t, live), read(t, live)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("received events mismatch (-want +got):\n%s", diff)
+	}
+
+	hub.Close()
+	_ = live.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := live.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseGoingAway {
+		t.Errorf("expected the hub to close the connection, instead got: %v", err)
+	}
+}
+
+func TestHub_Resume(t *testing.T) {
+	hub := push.NewHub(2, 50*time.Millisecond)
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	hub.PublishScore(day, eni, true)
+	hub.PublishScore(day, a2a, false)
+	hub.PublishScan(day, []*monitor.StockScore{eni})
+
+	type testCase struct {
+		name  string
+		query string
+		want  []summary
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:  "sends the missed events",
+			query: "since=1",
+			want: []summary{
+				{Type: push.TypeScore, Symbols: "A2A.MTA", Seq: 2},
+				{Type: push.TypeScan, Symbols: "ENI.MTA", Seq: 3},
+				{Type: push.TypeHeartbeat, Seq: 3},
+			},
+		},
+		{
+			name:  "sends the missed events selected",
+			query: "since=1&types=scan",
+			want: []summary{
+				{Type: push.TypeScan, Symbols: "ENI.MTA", Seq: 3},
+				{Type: push.TypeHeartbeat, Seq: 3},
+			},
+		},
+		{
+			name:  "sends a heartbeat when up to date",
+			query: "since=3",
+			want:  []summary{{Type: push.TypeHeartbeat, Seq: 3}},
+		},
+		{
+			name:  "resets when events are no longer in the backlog",
+			query: "since=0",
+			want:  []summary{{Type: push.TypeReset, Seq: 3}},
+		},
+		{
+			name:  "resets when the monitor restarted",
+			query: "since=42",
+			want:  []summary{{Type: push.TypeReset, Seq: 3}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := dial(t, server, tc.query)
+			got := make([]summary, 0, len(tc.want))
+			for range tc.want {
+				got = append(got, read(t, conn))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("received events mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHub_DefaultHeartbeat(t *testing.T) {
+	hub := push.NewHub(push.DefaultBacklog, 0)
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	conn := dial(t, server, "")
+	hub.PublishScore(day, eni, true)
+	if diff := cmp.Diff(summary{Type: push.TypeScore, Symbols: "ENI.MTA", Seq: 1}, read(t, conn)); diff != "" {
+		t.Errorf("event mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHub_Nil(t *testing.T) {
+	var hub *push.Hub
+	hub.PublishScan(day, []*monitor.StockScore{eni})
+	hub.PublishScore(day, eni, true)
+	hub.PublishAlert(&alert.Notification{Timestamp: day, Rule: "breakout"})
+	hub.Close()
+}
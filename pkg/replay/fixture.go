@@ -0,0 +1,159 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+)
+
+// Fixture holds the responses recorded, in order, for a single request.
+type Fixture struct {
+	Symbol    string      `json:"symbol"`
+	TimeFrame string      `json:"timeframe"`
+	Options   string      `json:"options,omitempty"`
+	Responses []*Response `json:"responses"`
+}
+
+// Response is a single recorded GetOHLCV response.
+type Response struct {
+	Error string `json:"error,omitempty"`
+	Bars  []*Bar `json:"bars"`
+}
+
+// Bar is the serializable form of an api.OHLCV.
+type Bar struct {
+	Timestamp time.Time `json:"timestamp"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+}
+
+// NewFixture creates a Fixture holding a single GetOHLCV response.
+func NewFixture(symbol, timeFrame string, data []*api.OHLCV, err error) *Fixture {
+	f := &Fixture{
+		Symbol:    symbol,
+		TimeFrame: timeFrame,
+	}
+	f.Append(data, err)
+	return f
+}
+
+// Append records a further GetOHLCV response for the same request.
+func (f *Fixture) Append(data []*api.OHLCV, err error) {
+	r := &Response{Bars: make([]*Bar, 0, len(data))}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	for _, d := range data {
+		r.Bars = append(r.Bars, &Bar{
+			Timestamp: d.Timestamp,
+			Open:      d.Open,
+			High:      d.High,
+			Low:       d.Low,
+			Close:     d.Close,
+			Volume:    d.Volume,
+		})
+	}
+	f.Responses = append(f.Responses, r)
+}
+
+// OHLCV returns a fresh copy of the recorded bars, so that callers can't alter the fixture.
+func (r *Response) OHLCV() []*api.OHLCV {
+	out := make([]*api.OHLCV, 0, len(r.Bars))
+	for _, b := range r.Bars {
+		out = append(out, &api.OHLCV{
+			Timestamp: b.Timestamp,
+			Open:      b.Open,
+			High:      b.High,
+			Low:       b.Low,
+			Close:     b.Close,
+			Volume:    b.Volume,
+		})
+	}
+	return out
+}
+
+// FileName returns the name of the file the fixture is stored in.
+func (f *Fixture) FileName() string {
+	return fileName(f.Symbol, f.TimeFrame, f.Options)
+}
+
+// WriteFixture stores f as indented JSON into dir.
+func WriteFixture(dir string, f *Fixture) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, f.FileName()), data, 0o600)
+}
+
+// ReadFixture loads a single fixture from the given path.
+func ReadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var f Fixture
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+	if len(f.Responses) == 0 {
+		return nil, fmt.Errorf("fixture %s has no recorded responses", path)
+	}
+	return &f, nil
+}
+
+// TimeFrameOf extracts the requested time frame from the GetOHLCV options.
+// It defaults to carnost.Daily, as the carnost client does.
+func TimeFrameOf(opts ...api.Option) string {
+	for _, o := range opts {
+		if tf, ok := o.(*carnost.WithTimeframe); ok && tf != nil {
+			return string(tf.TimeFrame)
+		}
+	}
+	return string(carnost.Daily)
+}
+
+// OptionsOf describes the GetOHLCV options other than the time frame,
+// so that requests differing only in those are told apart.
+func OptionsOf(opts ...api.Option) string {
+	parts := make([]string, 0, len(opts))
+	for _, o := range opts {
+		if _, ok := o.(*carnost.WithTimeframe); ok || o == nil {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%T%+v", o, o))
+	}
+	return strings.Join(parts, ";")
+}
+
+func key(symbol, timeFrame, options string) string {
+	k := strings.ToUpper(symbol) + "@" + timeFrame
+	if options != "" {
+		k += "?" + options
+	}
+	return k
+}
+
+func fileName(symbol, timeFrame, options string) string {
+	r := strings.NewReplacer("/", "-", "\\", "-", ":", "-", " ", "-")
+	name := r.Replace(strings.ToUpper(symbol)) + "_" + r.Replace(timeFrame)
+	if options != "" {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(options))
+		name += fmt.Sprintf("_%08x", h.Sum32())
+	}
+	return name + ".json"
+}
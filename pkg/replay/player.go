@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+)
+
+// ErrNoFixture is returned when a Player has no recording for a request.
+var ErrNoFixture = errors.New("no fixture recorded")
+
+// Player is an api.Client that deterministically serves the fixtures saved by a Recorder.
+// The responses recorded for a request are served in order; once they run out,
+// the last one keeps being served.
+type Player struct {
+	fixtures map[string]*Fixture
+	served   map[string]int
+	mu       sync.Mutex
+}
+
+// NewPlayer creates a new Player loading all the fixtures found in dir.
+func NewPlayer(dir string) (*Player, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture directory: %w", err)
+	}
+
+	p := newPlayer(len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		f, err := ReadFixture(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		p.Add(f)
+	}
+
+	return p, nil
+}
+
+// NewPlayerFromFixtures creates a new Player serving the given fixtures.
+func NewPlayerFromFixtures(fixtures ...*Fixture) *Player {
+	p := newPlayer(len(fixtures))
+	for _, f := range fixtures {
+		p.Add(f)
+	}
+	return p
+}
+
+func newPlayer(size int) *Player {
+	return &Player{
+		fixtures: make(map[string]*Fixture, size),
+		served:   make(map[string]int, size),
+	}
+}
+
+// Add registers a fixture, replacing any previous one for the same request.
+func (p *Player) Add(f *Fixture) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k := key(f.Symbol, f.TimeFrame, f.Options)
+	p.fixtures[k] = f
+	delete(p.served, k)
+}
+
+// GetOHLCV returns the next recorded response for the given request.
+func (p *Player) GetOHLCV(ctx context.Context, symbol string, opts ...api.Option) ([]*api.OHLCV, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	timeFrame := TimeFrameOf(opts...)
+	k := key(symbol, timeFrame, OptionsOf(opts...))
+
+	p.mu.Lock()
+	f, ok := p.fixtures[k]
+	if !ok || len(f.Responses) == 0 {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w for %s (%s)", ErrNoFixture, symbol, timeFrame)
+	}
+	r := f.Responses[min(p.served[k], len(f.Responses)-1)]
+	p.served[k]++
+	p.mu.Unlock()
+
+	if r.Error != "" {
+		return nil, errors.New(r.Error)
+	}
+	return r.OHLCV(), nil
+}
@@ -0,0 +1,55 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+)
+
+// Recorder is an api.Client decorator that saves every request/response pair
+// into a fixture directory, so that it can later be served by a Player.
+// Repeated requests are recorded in order, as monitor sessions issue them.
+type Recorder struct {
+	client   api.Client
+	fixtures map[string]*Fixture
+	dir      string
+	mu       sync.Mutex
+}
+
+// NewRecorder creates a new Recorder that stores the fixtures in dir.
+func NewRecorder(cli api.Client, dir string) *Recorder {
+	return &Recorder{
+		client:   cli,
+		fixtures: make(map[string]*Fixture),
+		dir:      dir,
+	}
+}
+
+// GetOHLCV calls the underlying client and records its response.
+func (r *Recorder) GetOHLCV(ctx context.Context, symbol string, opts ...api.Option) ([]*api.OHLCV, error) {
+	data, err := r.client.GetOHLCV(ctx, symbol, opts...)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// cancellations are not a property of the provider, do not replay them
+		return data, err
+	}
+
+	timeFrame, options := TimeFrameOf(opts...), OptionsOf(opts...)
+	k := key(symbol, timeFrame, options)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.fixtures[k]
+	if !ok {
+		f = &Fixture{Symbol: symbol, TimeFrame: timeFrame, Options: options}
+		r.fixtures[k] = f
+	}
+	f.Append(data, err)
+	if wErr := WriteFixture(r.dir, f); wErr != nil {
+		return data, errors.Join(err, fmt.Errorf("recorder: %w", wErr))
+	}
+
+	return data, err
+}
@@ -0,0 +1,152 @@
+package replay_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+)
+
+type fakeClient struct {
+	data  map[string][]*api.OHLCV
+	calls int
+}
+
+func (f *fakeClient) GetOHLCV(_ context.Context, symbol string, _ ...api.Option) ([]*api.OHLCV, error) {
+	f.calls++
+	data, ok := f.data[symbol]
+	if !ok {
+		return nil, errors.New("unknown symbol")
+	}
+	return data, nil
+}
+
+func TestRecorder_ReplaysRecordedResponses(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	live := &fakeClient{data: map[string][]*api.OHLCV{
+		"ENI.MTA": {
+			{Timestamp: ts, Open: 13.1, High: 13.4, Low: 13.0, Close: 13.3, Volume: 1e6},
+			{Timestamp: ts.AddDate(0, 0, 1), Open: 13.3, High: 13.5, Low: 13.2, Close: 13.2, Volume: 2e6},
+		},
+	}}
+	dir := t.TempDir()
+	ctx := context.Background()
+	monthly := &carnost.WithTimeframe{TimeFrame: carnost.TimeFrame("1m")}
+
+	recorder := replay.NewRecorder(live, dir)
+	want, err := recorder.GetOHLCV(ctx, "ENI.MTA", monthly)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	if _, err = recorder.GetOHLCV(ctx, "UNKNOWN.MTA"); err == nil {
+		t.Fatal("expected error, instead got none")
+	}
+
+	player, err := replay.NewPlayer(dir)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	t.Run("serves the recorded bars", func(t *testing.T) {
+		got, err := player.GetOHLCV(ctx, "ENI.MTA", monthly)
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("replayed data mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("serves the recorded errors", func(t *testing.T) {
+		_, err := player.GetOHLCV(ctx, "UNKNOWN.MTA")
+		if err == nil || err.Error() != "unknown symbol" {
+			t.Errorf("expected recorded error, instead got: %v", err)
+		}
+	})
+
+	t.Run("fails for requests that were never recorded", func(t *testing.T) {
+		_, err := player.GetOHLCV(ctx, "ENI.MTA")
+		if !errors.Is(err, replay.ErrNoFixture) {
+			t.Errorf("expected %v, instead got: %v", replay.ErrNoFixture, err)
+		}
+	})
+
+	t.Run("does not call the live client", func(t *testing.T) {
+		if live.calls != 2 {
+			t.Errorf("expected 2 live calls, instead got %d", live.calls)
+		}
+	})
+}
+
+type limit struct {
+	n int
+}
+
+func TestRecorder_ReplaysRepeatedRequestsInOrder(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	ticks := [][]*api.OHLCV{
+		{{Timestamp: ts, Close: 13.1}},
+		{{Timestamp: ts, Close: 13.4}},
+		{{Timestamp: ts, Close: 13.2}},
+	}
+	live := &sequenceClient{responses: ticks}
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder := replay.NewRecorder(live, dir)
+	for range ticks {
+		if _, err := recorder.GetOHLCV(ctx, "ENI.MTA"); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+	}
+	if _, err := recorder.GetOHLCV(ctx, "ENI.MTA", &limit{n: 1}); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	player, err := replay.NewPlayer(dir)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	t.Run("serves the responses in the recorded order", func(t *testing.T) {
+		for i, want := range append(ticks, ticks[len(ticks)-1]) {
+			got, err := player.GetOHLCV(ctx, "ENI.MTA")
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("replayed data mismatch at request %d (-want +got):\n%s", i, diff)
+			}
+		}
+	})
+
+	t.Run("keeps requests with other options apart", func(t *testing.T) {
+		got, err := player.GetOHLCV(ctx, "ENI.MTA", &limit{n: 1})
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		if diff := cmp.Diff(ticks[0], got); diff != "" {
+			t.Errorf("replayed data mismatch (-want +got):\n%s", diff)
+		}
+		if _, err = player.GetOHLCV(ctx, "ENI.MTA", &limit{n: 2}); !errors.Is(err, replay.ErrNoFixture) {
+			t.Errorf("expected %v, instead got: %v", replay.ErrNoFixture, err)
+		}
+	})
+}
+
+type sequenceClient struct {
+	responses [][]*api.OHLCV
+	calls     int
+}
+
+func (s *sequenceClient) GetOHLCV(context.Context, string, ...api.Option) ([]*api.OHLCV, error) {
+	data := s.responses[s.calls%len(s.responses)]
+	s.calls++
+	return data, nil
+}
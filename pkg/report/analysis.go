@@ -0,0 +1,102 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+// Analysis is the serialization of the analysis of a single stock.
+type Analysis struct {
+	Timestamp  time.Time              `json:"timestamp"`
+	Indicators *strategies.Indicators `json:"indicators"`
+	// Signals maps each strategy name to the operation it suggested.
+	Signals map[string]signals.Operation `json:"signals"`
+	// Sentiment counts the strategies suggesting each operation.
+	Sentiment map[signals.Operation]int `json:"sentiment"`
+	Symbol    string                    `json:"symbol"`
+	Timeframe string                    `json:"timeframe"`
+	// Assistant is the suggestion of the AI assistant, if enabled.
+	Assistant  string `json:"assistant,omitempty"`
+	Strategies int    `json:"strategies"`
+}
+
+// operations are the sentiment columns, in order.
+var operations = []signals.Operation{signals.Buy, signals.Sell, signals.Setup, signals.NoOp}
+
+// indicatorNames are the names of the indicators, in the order of indicators.
+var indicatorNames = []string{
+	"close", "vwap", "sma", "deviation", "rsi", "resistance", "support", "volume", "average_volume", "atr",
+	"bollinger_sma", "bollinger_upper", "bollinger_lower", "bollinger_width",
+	"macd_delta", "macd_previous_delta", "momentum_change", "momentum_period",
+}
+
+var analysisHeader = slices.Concat(
+	[]string{"timestamp", "symbol", "timeframe", "strategies", "buy", "sell", "setup", "noop", "signals"},
+	indicatorNames,
+	[]string{"assistant"},
+)
+
+// indicators returns the values of the indicators, in the order of indicatorNames.
+func indicators(in *strategies.Indicators) []float64 {
+	return []float64{
+		in.Close, in.VWAP, in.SMA, in.Deviation, in.RSI, in.Resistance, in.Support, in.Volume, in.AverageVolume,
+		in.ATR, in.BollingerSMA, in.BollingerUpper, in.BollingerLower, in.BollingerWidth,
+		in.MACDDelta, in.MACDPreviousDelta, in.MomentumChange, float64(in.MomentumPeriod),
+	}
+}
+
+// WriteAnalysis writes the analysis of a single stock.
+func (w *Writer) WriteAnalysis(a *Analysis) error {
+	switch w.format {
+	case FormatJSON:
+		return w.writeJSON(a, true)
+	case FormatNDJSON:
+		return w.writeJSON(a, false)
+	case FormatCSV:
+		row := []string{a.Timestamp.Format(time.RFC3339), a.Symbol, a.Timeframe, strconv.Itoa(a.Strategies)}
+		for _, op := range operations {
+			row = append(row, strconv.Itoa(a.Sentiment[op]))
+		}
+		row = append(row, formatSignals(a.Signals))
+		for _, v := range indicators(a.Indicators) {
+			row = append(row, formatFloat(v))
+		}
+		return w.writeCSV(analysisHeader, append(row, a.Assistant))
+	case FormatMarkdown:
+		return writeAnalysisMarkdown(w.w, a)
+	default:
+		return fmt.Errorf("unsupported output format %q", w.format)
+	}
+}
+
+func writeAnalysisMarkdown(w io.Writer, a *Analysis) error {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "## Analysis of %s (%s) at %s\n\n", a.Symbol, a.Timeframe, a.Timestamp.Format(time.DateTime))
+
+	b.WriteString(markdownRow("Indicator", "Value"))
+	b.WriteString(markdownSeparator(2))
+	for i, v := range indicators(a.Indicators) {
+		b.WriteString(markdownRow(indicatorNames[i], strconv.FormatFloat(v, 'f', 3, 64)))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(markdownRow("Sentiment", "Strategies"))
+	b.WriteString(markdownSeparator(2))
+	for _, op := range operations {
+		b.WriteString(markdownRow(strings.ToUpper(string(op)), fmt.Sprintf("%d of %d", a.Sentiment[op], a.Strategies)))
+	}
+	b.WriteString("\nSignals: " + formatSignals(a.Signals) + "\n")
+	if a.Assistant != "" {
+		b.WriteString("\n### Assistant\n\n" + a.Assistant + "\n")
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
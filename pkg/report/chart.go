@@ -0,0 +1,247 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+// The layout of a chart, in pixels.
+const (
+	chartWidth   = 960.0
+	marginLeft   = 10.0
+	marginRight  = 70.0
+	priceTop     = 20.0
+	priceHeight  = 300.0
+	macdTop      = priceTop + priceHeight + 30
+	macdHeight   = 90.0
+	chartHeight  = macdTop + macdHeight + 30
+	priceLines   = 5
+	dateLabels   = 6
+	markerSize   = 5.0
+	candleRatio  = 0.6
+	rangePadding = 0.05
+)
+
+// Chart is the price history of a symbol, with the state of the strategies at each bar.
+type Chart struct {
+	Symbol string
+	Bars   []*api.OHLCV
+	Points []*strategies.Point
+}
+
+// NewChart runs the strategies on data and charts its last bars, all of them if bars is not positive.
+// The strategies run on the whole data, so that the indicators of the first charted bars are complete.
+func NewChart(symbol string, data []*api.OHLCV, bars int, strats ...*strategies.StrategyWeight) *Chart {
+	start := 0
+	if bars > 0 && len(data) > bars {
+		start = len(data) - bars
+	}
+	return &Chart{Symbol: symbol, Bars: data[start:], Points: strategies.SeriesFrom(data, start, strats...)}
+}
+
+// chartScale maps the bars and prices of a chart to coordinates.
+type chartScale struct {
+	step, low, high float64
+}
+
+func (s *chartScale) x(i int) float64 {
+	return marginLeft + (float64(i)+0.5)*s.step
+}
+
+func (s *chartScale) y(price float64) float64 {
+	return priceTop + (s.high-price)/(s.high-s.low)*priceHeight
+}
+
+// SVG renders the chart as an inline SVG: candlesticks with the VWAP, Bollinger bands and breakout levels,
+// markers where the strategies started suggesting to buy or sell, and a panel with the MACD histogram.
+func (c *Chart) SVG() template.HTML {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="chart" viewBox="0 0 %.0f %.0f" role="img">`,
+		chartWidth, chartHeight)
+	_, _ = fmt.Fprintf(&b, "<title>%s</title>", html.EscapeString(c.Symbol))
+	if len(c.Bars) == 0 {
+		_, _ = fmt.Fprintf(&b, `<text x="%.0f" y="%.0f">No data</text></svg>`, chartWidth/2, chartHeight/2)
+		return template.HTML(b.String()) //nolint:gosec // the only text is escaped
+	}
+
+	scale := c.scale()
+	c.writeGrid(&b, scale)
+	c.writeOverlays(&b, scale)
+	c.writeCandles(&b, scale)
+	c.writeMarkers(&b, scale)
+	c.writeMACD(&b, scale)
+	b.WriteString("</svg>")
+	return template.HTML(b.String()) //nolint:gosec // the only text is escaped
+}
+
+// scale fits the bars and the overlays in the price panel.
+func (c *Chart) scale() *chartScale {
+	s := &chartScale{step: (chartWidth - marginLeft - marginRight) / float64(len(c.Bars)), low: math.Inf(1)}
+	fit := func(v float64) {
+		if v > 0 {
+			s.low, s.high = min(s.low, v), max(s.high, v)
+		}
+	}
+	for i, bar := range c.Bars {
+		fit(bar.Low)
+		fit(bar.High)
+		in := c.Points[i].Indicators
+		for _, v := range []float64{in.VWAP, in.BollingerUpper, in.BollingerLower, in.Resistance, in.Support} {
+			fit(v)
+		}
+	}
+	if s.high == s.low {
+		s.high, s.low = s.high+1, s.low-1
+	}
+	padding := (s.high - s.low) * rangePadding
+	s.high, s.low = s.high+padding, s.low-padding
+	return s
+}
+
+func (c *Chart) writeGrid(b *strings.Builder, s *chartScale) {
+	for i := range priceLines {
+		price := s.low + (s.high-s.low)*float64(i)/float64(priceLines-1)
+		y := s.y(price)
+		_, _ = fmt.Fprintf(b, `<line class="grid" x1="%.0f" y1="%.2f" x2="%.0f" y2="%.2f"/>`,
+			marginLeft, y, chartWidth-marginRight, y)
+		_, _ = fmt.Fprintf(b, `<text class="axis" x="%.0f" y="%.2f">%s</text>`,
+			chartWidth-marginRight+5, y+4, formatPrice(price))
+	}
+
+	layout := time.DateOnly
+	if c.Bars[len(c.Bars)-1].Timestamp.Sub(c.Bars[0].Timestamp) < 48*time.Hour {
+		layout = "01-02 15:04"
+	}
+	every := max(1, len(c.Bars)/dateLabels)
+	for i := 0; i < len(c.Bars); i += every {
+		_, _ = fmt.Fprintf(b, `<text class="axis" x="%.2f" y="%.0f" text-anchor="middle">%s</text>`,
+			s.x(i), chartHeight-8, c.Bars[i].Timestamp.Format(layout))
+	}
+}
+
+func (c *Chart) writeOverlays(b *strings.Builder, s *chartScale) {
+	for _, overlay := range []struct {
+		value func(in *strategies.Indicators) float64
+		class string
+	}{
+		{class: "bollinger", value: func(in *strategies.Indicators) float64 { return in.BollingerUpper }},
+		{class: "bollinger", value: func(in *strategies.Indicators) float64 { return in.BollingerLower }},
+		{class: "bollinger-sma", value: func(in *strategies.Indicators) float64 { return in.BollingerSMA }},
+		{class: "resistance", value: func(in *strategies.Indicators) float64 { return in.Resistance }},
+		{class: "support", value: func(in *strategies.Indicators) float64 { return in.Support }},
+		{class: "vwap", value: func(in *strategies.Indicators) float64 { return in.VWAP }},
+	} {
+		// a zero value means the strategy didn't have enough bars yet, which breaks the line
+		var coords []string
+		flush := func() {
+			if len(coords) > 1 {
+				_, _ = fmt.Fprintf(b, `<polyline class="%s" points="%s"/>`, overlay.class, strings.Join(coords, " "))
+			}
+			coords = coords[:0]
+		}
+		for i, point := range c.Points {
+			v := overlay.value(point.Indicators)
+			if v <= 0 {
+				flush()
+				continue
+			}
+			coords = append(coords, fmt.Sprintf("%.2f,%.2f", s.x(i), s.y(v)))
+		}
+		flush()
+	}
+}
+
+func (c *Chart) writeCandles(b *strings.Builder, s *chartScale) {
+	width := max(1, s.step*candleRatio)
+	for i, bar := range c.Bars {
+		class := "up"
+		if bar.Close < bar.Open {
+			class = "down"
+		}
+		top, bottom := s.y(max(bar.Open, bar.Close)), s.y(min(bar.Open, bar.Close))
+		_, _ = fmt.Fprintf(b, `<g class="%s"><title>%s O %s H %s L %s C %s V %.0f</title>`, class,
+			bar.Timestamp.Format(time.DateTime), formatPrice(bar.Open), formatPrice(bar.High),
+			formatPrice(bar.Low), formatPrice(bar.Close), bar.Volume)
+		_, _ = fmt.Fprintf(b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f"/>`,
+			s.x(i), s.y(bar.High), s.x(i), s.y(bar.Low))
+		_, _ = fmt.Fprintf(b, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f"/></g>`,
+			s.x(i)-width/2, top, width, max(1, bottom-top))
+	}
+}
+
+// writeMarkers marks the bars where a strategy started suggesting to buy or to sell.
+func (c *Chart) writeMarkers(b *strings.Builder, s *chartScale) {
+	// the first bar has no previous suggestion to compare with
+	for i := 1; i < len(c.Points); i++ {
+		var buyers, sellers []string
+		for name, op := range c.Points[i].Signals {
+			if c.Points[i-1].Signals[name] == op {
+				continue
+			}
+			switch op {
+			case signals.Buy:
+				buyers = append(buyers, name)
+			case signals.Sell:
+				sellers = append(sellers, name)
+			}
+		}
+		date := c.Bars[i].Timestamp.Format(time.DateTime)
+		if len(buyers) > 0 {
+			slices.Sort(buyers)
+			_, _ = fmt.Fprintf(b, `<path class="buy" d="M%.2f,%.2f l%.1f,%.1f h%.1f z"><title>%s BUY: %s</title></path>`,
+				s.x(i), s.y(c.Bars[i].Low)+markerSize, markerSize, markerSize*2, -markerSize*2,
+				date, html.EscapeString(strings.Join(buyers, ", ")))
+		}
+		if len(sellers) > 0 {
+			slices.Sort(sellers)
+			_, _ = fmt.Fprintf(b, `<path class="sell" d="M%.2f,%.2f l%.1f,%.1f h%.1f z"><title>%s SELL: %s</title></path>`,
+				s.x(i), s.y(c.Bars[i].High)-markerSize, markerSize, -markerSize*2, -markerSize*2,
+				date, html.EscapeString(strings.Join(sellers, ", ")))
+		}
+	}
+}
+
+// writeMACD draws the histogram of the distance between the MACD and its signal line.
+func (c *Chart) writeMACD(b *strings.Builder, s *chartScale) {
+	var extent float64
+	for _, point := range c.Points {
+		extent = max(extent, math.Abs(point.Indicators.MACDDelta))
+	}
+	zero := macdTop + macdHeight/2
+	_, _ = fmt.Fprintf(b, `<text class="axis" x="%.0f" y="%.0f">MACD histogram</text>`, marginLeft, macdTop-6)
+	_, _ = fmt.Fprintf(b, `<line class="grid" x1="%.0f" y1="%.2f" x2="%.0f" y2="%.2f"/>`,
+		marginLeft, zero, chartWidth-marginRight, zero)
+	if extent == 0 {
+		return
+	}
+
+	width := max(1, s.step*candleRatio)
+	for i, point := range c.Points {
+		delta := point.Indicators.MACDDelta
+		height := math.Abs(delta) / extent * macdHeight / 2
+		class, y := "up", zero-height
+		if delta < 0 {
+			class, y = "down", zero
+		}
+		_, _ = fmt.Fprintf(b, `<rect class="macd %s" x="%.2f" y="%.2f" width="%.2f" height="%.2f"/>`,
+			class, s.x(i)-width/2, y, width, height)
+	}
+}
+
+// formatPrice formats a price with a precision fit for both stocks and penny stocks.
+func formatPrice(v float64) string {
+	if math.Abs(v) < 1 {
+		return fmt.Sprintf("%.4f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+//go:embed report.html
+var pageTemplate string
+
+// Page is a standalone HTML report of a scan, or of the analysis of a single stock.
+type Page struct {
+	Timestamp time.Time
+	// Analysis is the analysis of a single stock, nil for a scan.
+	Analysis *Analysis
+	Title    string
+	// Scores are the ranked scores of a scan.
+	Scores []*Score
+	Charts []*Chart
+}
+
+// NewAnalysisPage charts a single stock, with the indicators and the sentiment of its latest bar.
+// The data cannot be empty.
+func NewAnalysisPage(
+	symbol, timeFrame string,
+	data []*api.OHLCV,
+	bars int,
+	strats ...*strategies.StrategyWeight,
+) *Page {
+	chart := NewChart(symbol, data, bars, strats...)
+	latest := chart.Points[len(chart.Points)-1]
+	sentiment := make(map[signals.Operation]int, len(strats))
+	for _, op := range latest.Signals {
+		sentiment[op]++
+	}
+
+	now := time.Now()
+	return &Page{
+		Timestamp: now,
+		Title:     fmt.Sprintf("Analysis of %s (%s)", symbol, timeFrame),
+		Analysis: &Analysis{
+			Timestamp:  now,
+			Indicators: latest.Indicators,
+			Signals:    latest.Signals,
+			Sentiment:  sentiment,
+			Symbol:     symbol,
+			Timeframe:  timeFrame,
+			Strategies: len(strats),
+		},
+		Charts: []*Chart{chart},
+	}
+}
+
+// pageView is the data of the page template.
+type pageView struct {
+	*Page
+	// Charted tells which symbols have a chart.
+	Charted map[string]bool
+}
+
+var page = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(f float64) string { return strconv.FormatFloat(f*100, 'f', 1, 64) + "%" },
+	"price":   formatPrice,
+	"upper":   func(op signals.Operation) string { return strings.ToUpper(string(op)) },
+	"signals": formatSignals,
+	"indicators": func(a *Analysis) [][2]string {
+		values := indicators(a.Indicators)
+		out := make([][2]string, len(values))
+		for i, v := range values {
+			out[i] = [2]string{indicatorNames[i], strconv.FormatFloat(v, 'f', 3, 64)}
+		}
+		return out
+	},
+	"operations": func() []signals.Operation { return operations },
+}).Parse(pageTemplate))
+
+// WriteHTML writes the page as a single HTML document with inline styles, scripts and SVG charts,
+// so that it can be emailed or archived.
+func WriteHTML(w io.Writer, p *Page) error {
+	view := &pageView{Page: p, Charted: make(map[string]bool, len(p.Charts))}
+	for _, chart := range p.Charts {
+		view.Charted[chart.Symbol] = true
+	}
+	if err := page.Execute(w, view); err != nil {
+		return fmt.Errorf("failed to write html report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+func bars(closes ...float64) []*api.OHLCV {
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	out := make([]*api.OHLCV, 0, len(closes))
+	for i, c := range closes {
+		out = append(out, &api.OHLCV{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      c,
+			High:      c + 0.5,
+			Low:       c - 0.5,
+			Close:     c,
+			Volume:    1000,
+		})
+	}
+	return out
+}
+
+func TestWriteHTML(t *testing.T) {
+	ts := time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC)
+	vwap := &strategies.StrategyWeight{Strategy: strategies.NewVWAPStrategy(3), Weight: 1}
+	// the close drops below the VWAP, then rises above it
+	data := bars(10, 10, 9, 8, 9, 11, 12)
+
+	type testCase struct {
+		name    string
+		page    *report.Page
+		want    []string
+		notWant []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "writes the ranking, linking the charted symbols",
+			page: &report.Page{
+				Timestamp: ts,
+				Title:     "Market scan",
+				Scores: report.NewScan(ts, []*monitor.StockScore{
+					{Symbol: "ENI.MTA", LastPrice: 12, Confidence: 0.5, Risk: monitor.RiskHigh},
+					{Symbol: "A2A.MTA", LastPrice: 2},
+				}).Scores,
+				Charts: []*report.Chart{report.NewChart("ENI.MTA", data, 0, vwap)},
+			},
+			want: []string{
+				"<title>Market scan</title>",
+				`<a href="#chart-ENI.MTA">ENI.MTA</a>`,
+				`<td data-sort="2">High</td>`,
+				`<td class="text">A2A.MTA</td>`,
+				`<h2 id="chart-ENI.MTA">ENI.MTA</h2>`,
+				`<polyline class="vwap"`,
+				"SELL: VWAP</title>",
+				"BUY: VWAP</title>",
+			},
+			notWant: []string{"<h2>Indicators</h2>", " src=", "<link"},
+		},
+		{
+			name: "writes an empty ranking",
+			page: &report.Page{Timestamp: ts, Title: "Market scan"},
+			want: []string{"No stock is meeting filter criteria."},
+		},
+		{
+			name: "writes the indicators and the sentiment of an analysis",
+			page: &report.Page{
+				Timestamp: ts,
+				Title:     "Analysis of ENI.MTA (1d)",
+				Analysis: &report.Analysis{
+					Indicators: &strategies.Indicators{VWAP: 10.25},
+					Symbol:     "ENI.MTA",
+					Strategies: 1,
+				},
+				Charts: []*report.Chart{report.NewChart("ENI.MTA", data, 3, vwap)},
+			},
+			want: []string{
+				`<td class="text">vwap</td><td>10.250</td>`,
+				`<td class="text">BUY</td><td>0 of 1</td>`,
+				`<h2 id="chart-ENI.MTA">ENI.MTA</h2>`,
+			},
+			notWant: []string{"<h2>Ranking</h2>"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := report.WriteHTML(&buf, tc.page); err != nil {
+				t.Fatalf("WriteHTML() error = %v", err)
+			}
+			got := buf.String()
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("WriteHTML() is missing %q", want)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("WriteHTML() unexpectedly contains %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestNewChart(t *testing.T) {
+	vwap := &strategies.StrategyWeight{Strategy: strategies.NewVWAPStrategy(3), Weight: 1}
+	data := bars(10, 11, 12, 13, 14)
+
+	chart := report.NewChart("ENI.MTA", data, 2, vwap)
+	if len(chart.Bars) != 2 || len(chart.Points) != 2 {
+		t.Fatalf("expected 2 bars and points, instead got %d and %d", len(chart.Bars), len(chart.Points))
+	}
+	if chart.Bars[0] != data[3] {
+		t.Errorf("expected the chart to start at the fourth bar, instead it starts at %s", chart.Bars[0].Timestamp)
+	}
+	// the indicators are computed on the whole data, not only on the charted bars
+	if got, want := chart.Points[0].Indicators.VWAP, 12.0; got != want {
+		t.Errorf("expected a VWAP of %f at the first charted bar, instead got %f", want, got)
+	}
+}
+
+func TestNewAnalysisPage(t *testing.T) {
+	vwap := &strategies.StrategyWeight{Strategy: strategies.NewVWAPStrategy(3), Weight: 1}
+	page := report.NewAnalysisPage("ENI.MTA", "1d", bars(10, 11, 12, 13, 14), 0, vwap)
+
+	if page.Title != "Analysis of ENI.MTA (1d)" || len(page.Charts) != 1 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+	latest := page.Charts[0].Points[len(page.Charts[0].Points)-1]
+	if diff := cmp.Diff(latest.Signals, page.Analysis.Signals); diff != "" {
+		t.Errorf("signals mismatch (-want +got):\n%s", diff)
+	}
+	if page.Analysis.Strategies != 1 || page.Analysis.Sentiment[latest.Signals["VWAP"]] != 1 {
+		t.Errorf("unexpected analysis: %+v", page.Analysis)
+	}
+}
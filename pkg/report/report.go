@@ -0,0 +1,98 @@
+// Package report serializes scan results and analyses in machine-readable formats.
+//
+// The field names of every format are stable: fields may be added, but are never renamed or removed.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a serialization format.
+type Format string
+
+const (
+	// FormatText is the colored human-readable report.
+	FormatText Format = "text"
+	// FormatJSON writes an indented JSON document per report.
+	FormatJSON Format = "json"
+	// FormatNDJSON writes a JSON object per line, one per score.
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV writes a header, then a row per score.
+	FormatCSV Format = "csv"
+	// FormatMarkdown writes a table per report.
+	FormatMarkdown Format = "markdown"
+)
+
+// ParseFormat returns the Format named s, text if empty.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatNDJSON, FormatCSV, FormatMarkdown:
+		return f, nil
+	case "md":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q, must be one of text, json, ndjson, csv or markdown", s)
+	}
+}
+
+// Writer serializes reports in a Format.
+// Several reports can be written one after the other, as monitor does after every scan:
+// JSON documents are concatenated and the CSV header is only written once.
+type Writer struct {
+	w      io.Writer
+	format Format
+	header bool
+}
+
+// NewWriter creates a new Writer serializing to w.
+func NewWriter(w io.Writer, format Format) *Writer {
+	return &Writer{w: w, format: format}
+}
+
+// Format returns the format the Writer serializes to.
+func (w *Writer) Format() Format {
+	return w.format
+}
+
+func (w *Writer) writeJSON(v any, indent bool) error {
+	encoder := json.NewEncoder(w.w)
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write json: %w", err)
+	}
+	return nil
+}
+
+func (w *Writer) writeCSV(header []string, rows ...[]string) error {
+	out := csv.NewWriter(w.w)
+	if !w.header {
+		if err := out.Write(header); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+		w.header = true
+	}
+	if err := out.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// markdownRow formats cells as a markdown table row, escaping the pipes they contain.
+func markdownRow(cells ...string) string {
+	for i, c := range cells {
+		cells[i] = strings.ReplaceAll(c, "|", `\|`)
+	}
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+func markdownSeparator(columns int) string {
+	return "|" + strings.Repeat("---|", columns) + "\n"
+}
@@ -0,0 +1,195 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+func TestParseFormat(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		want    report.Format
+		wantErr bool
+	}
+
+	for _, tc := range []testCase{
+		{name: "defaults to text", input: "", want: report.FormatText},
+		{name: "is case insensitive", input: "JSON", want: report.FormatJSON},
+		{name: "accepts md as markdown", input: "md", want: report.FormatMarkdown},
+		{name: "rejects unknown formats", input: "xml", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := report.ParseFormat(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseFormat() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseFormat() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteScan(t *testing.T) {
+	ts := time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC)
+	scores := []*monitor.StockScore{
+		{
+			Signals:       map[string]signals.Operation{"VWAP": signals.Buy, "MACD": signals.NoOp},
+			Symbol:        "ENI.MTA",
+			Reasoning:     []string{"VWAP: buy", "MACD: noop"},
+			Confidence:    0.5,
+			HoldSignals:   1,
+			WeightedScore: 1.5,
+			BuySignals:    1,
+			LastPrice:     13.25,
+			Volume:        1000,
+			Risk:          monitor.RiskMedium,
+			Opportunity:   monitor.OpportunityHigh,
+		},
+	}
+
+	type testCase struct {
+		name   string
+		format report.Format
+		scores []*monitor.StockScore
+		want   string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:   "writes a line per score as ndjson",
+			format: report.FormatNDJSON,
+			scores: scores,
+			want: `{"timestamp":"2025-06-02T09:30:00Z","signals":{"MACD":"noop","VWAP":"buy"},"symbol":"ENI.MTA",` +
+				`"reasoning":["VWAP: buy","MACD: noop"],"rank":1,"price":13.25,"weighted_score":1.5,"confidence":0.5,` +
+				`"buy_signals":1,"sell_signals":0,"hold_signals":1,"setup_signals":0,"volume":1000,` +
+				`"risk":"MEDIUM","opportunity":"HIGH"}` + "\n",
+		},
+		{
+			name:   "writes the header once as csv",
+			format: report.FormatCSV,
+			scores: scores,
+			want: "timestamp,rank,symbol,price,weighted_score,confidence,buy_signals,sell_signals,hold_signals," +
+				"setup_signals,volume,risk,opportunity,signals,reasoning\n" +
+				"2025-06-02T09:30:00Z,1,ENI.MTA,13.25,1.5,0.5,1,0,1,0,1000,MEDIUM,HIGH,MACD=noop VWAP=buy," +
+				"VWAP: buy; MACD: noop\n" +
+				"2025-06-02T09:30:00Z,1,ENI.MTA,13.25,1.5,0.5,1,0,1,0,1000,MEDIUM,HIGH,MACD=noop VWAP=buy," +
+				"VWAP: buy; MACD: noop\n",
+		},
+		{
+			name:   "writes an empty scan as json",
+			format: report.FormatJSON,
+			want:   "{\n  \"timestamp\": \"2025-06-02T09:30:00Z\",\n  \"scores\": []\n}\n",
+		},
+		{
+			name:   "writes a table as markdown",
+			format: report.FormatMarkdown,
+			scores: scores,
+			want: "## Scan at 2025-06-02 09:30:00\n\n" +
+				"| Rank | Symbol | Price | Score | Confidence | Buy | Sell | Hold | Setup | Volume | Risk | Opportunity |" +
+				" Signals | Reasoning |\n" +
+				"|---|---|---|---|---|---|---|---|---|---|---|---|---|---|\n" +
+				"| 1 | ENI.MTA | 13.25 | 1.50 | 50.0% | 1 | 0 | 1 | 0 | 1000 | Medium | High |" +
+				" MACD=noop VWAP=buy | VWAP: buy; MACD: noop |\n\n",
+		},
+		{
+			name:   "writes no table for an empty scan as markdown",
+			format: report.FormatMarkdown,
+			want:   "## Scan at 2025-06-02 09:30:00\n\nNo stock is meeting filter criteria.\n\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := report.NewWriter(&buf, tc.format)
+			if err := w.WriteScan(ts, tc.scores); err != nil {
+				t.Fatalf("WriteScan() error = %v", err)
+			}
+			if tc.format == report.FormatCSV {
+				// the header is not repeated by the following scans
+				if err := w.WriteScan(ts, tc.scores); err != nil {
+					t.Fatalf("WriteScan() error = %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, buf.String()); diff != "" {
+				t.Errorf("WriteScan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteAnalysis(t *testing.T) {
+	analysis := &report.Analysis{
+		Timestamp:  time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC),
+		Indicators: &strategies.Indicators{Close: 13.25, RSI: 55.5, MomentumPeriod: 8},
+		Signals:    map[string]signals.Operation{"VWAP": signals.Buy, "RSI|MR": signals.Sell},
+		Sentiment:  map[signals.Operation]int{signals.Buy: 1, signals.Sell: 1},
+		Symbol:     "ENI.MTA",
+		Timeframe:  "1d",
+		Strategies: 2,
+	}
+
+	type testCase struct {
+		name   string
+		format report.Format
+		want   string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:   "writes a single line as ndjson",
+			format: report.FormatNDJSON,
+			want: `{"timestamp":"2025-06-02T09:30:00Z","indicators":{"close":13.25,"vwap":0,"sma":0,"deviation":0,` +
+				`"rsi":55.5,"resistance":0,"support":0,"volume":0,"average_volume":0,"atr":0,"bollinger_sma":0,` +
+				`"bollinger_upper":0,"bollinger_lower":0,"bollinger_width":0,"macd_delta":0,"macd_previous_delta":0,` +
+				`"momentum_change":0,"momentum_period":8},"signals":{"RSI|MR":"sell","VWAP":"buy"},` +
+				`"sentiment":{"buy":1,"sell":1},"symbol":"ENI.MTA","timeframe":"1d","strategies":2}` + "\n",
+		},
+		{
+			name:   "writes a header and a row as csv",
+			format: report.FormatCSV,
+			want: "timestamp,symbol,timeframe,strategies,buy,sell,setup,noop,signals,close,vwap,sma,deviation,rsi," +
+				"resistance,support,volume,average_volume,atr,bollinger_sma,bollinger_upper,bollinger_lower," +
+				"bollinger_width,macd_delta,macd_previous_delta,momentum_change,momentum_period,assistant\n" +
+				"2025-06-02T09:30:00Z,ENI.MTA,1d,2,1,1,0,0,RSI|MR=sell VWAP=buy,13.25,0,0,0,55.5,0,0,0,0,0,0,0,0,0," +
+				"0,0,0,8,\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := report.NewWriter(&buf, tc.format).WriteAnalysis(analysis); err != nil {
+				t.Fatalf("WriteAnalysis() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, buf.String()); diff != "" {
+				t.Errorf("WriteAnalysis() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteAnalysisMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := report.NewWriter(&buf, report.FormatMarkdown).WriteAnalysis(&report.Analysis{
+		Indicators: &strategies.Indicators{},
+		Symbol:     "ENI.MTA",
+		Timeframe:  "1d",
+		Assistant:  "hold | wait",
+	})
+	if err != nil {
+		t.Fatalf("WriteAnalysis() error = %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("### Assistant\n\nhold | wait\n")) {
+		t.Errorf("WriteAnalysis() did not write the assistant section:\n%s", buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("| BUY | 0 of 0 |\n")) {
+		t.Errorf("WriteAnalysis() did not write the sentiment table:\n%s", buf.String())
+	}
+}
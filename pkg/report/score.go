@@ -0,0 +1,154 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+// Score is the serialization of a monitor.StockScore.
+type Score struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Signals maps each strategy name to the operation it suggested.
+	Signals   map[string]signals.Operation `json:"signals"`
+	Symbol    string                       `json:"symbol"`
+	Reasoning []string                     `json:"reasoning"`
+	// Rank is the position of the score, best opportunities first, starting from 1, or 0 if unranked.
+	Rank          int     `json:"rank"`
+	Price         float64 `json:"price"`
+	WeightedScore float64 `json:"weighted_score"`
+	// Confidence is the fraction of strategies agreeing on the signal, from 0 to 1.
+	Confidence   float64                  `json:"confidence"`
+	BuySignals   int                      `json:"buy_signals"`
+	SellSignals  int                      `json:"sell_signals"`
+	HoldSignals  int                      `json:"hold_signals"`
+	SetupSignals int                      `json:"setup_signals"`
+	Volume       float64                  `json:"volume"`
+	Risk         monitor.RiskLevel        `json:"risk"`
+	Opportunity  monitor.OpportunityLevel `json:"opportunity"`
+}
+
+// Scan is the serialization of the scores of a scan.
+type Scan struct {
+	Timestamp time.Time `json:"timestamp"`
+	Scores    []*Score  `json:"scores"`
+}
+
+// NewScan creates a Scan of the ranked scores computed at timestamp.
+func NewScan(timestamp time.Time, scores []*monitor.StockScore) *Scan {
+	out := &Scan{Timestamp: timestamp, Scores: make([]*Score, 0, len(scores))}
+	for i, s := range scores {
+		out.Scores = append(out.Scores, NewScore(timestamp, i+1, s))
+	}
+	return out
+}
+
+// NewScore creates a Score of s computed at timestamp, ranked rank or 0 if unranked.
+func NewScore(timestamp time.Time, rank int, s *monitor.StockScore) *Score {
+	return &Score{
+		Timestamp:     timestamp,
+		Signals:       s.Signals,
+		Symbol:        s.Symbol,
+		Reasoning:     s.Reasoning,
+		Rank:          rank,
+		Price:         s.LastPrice,
+		WeightedScore: s.WeightedScore,
+		Confidence:    s.Confidence,
+		BuySignals:    s.BuySignals,
+		SellSignals:   s.SellSignals,
+		HoldSignals:   s.HoldSignals,
+		SetupSignals:  s.SetupSignals,
+		Volume:        s.Volume,
+		Risk:          s.Risk,
+		Opportunity:   s.Opportunity,
+	}
+}
+
+var scoreHeader = []string{
+	"timestamp", "rank", "symbol", "price", "weighted_score", "confidence",
+	"buy_signals", "sell_signals", "hold_signals", "setup_signals",
+	"volume", "risk", "opportunity", "signals", "reasoning",
+}
+
+// WriteScan writes the ranked scores computed at timestamp.
+func (w *Writer) WriteScan(timestamp time.Time, scores []*monitor.StockScore) error {
+	scan := NewScan(timestamp, scores)
+	switch w.format {
+	case FormatJSON:
+		return w.writeJSON(scan, true)
+	case FormatNDJSON:
+		for _, s := range scan.Scores {
+			if err := w.writeJSON(s, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		rows := make([][]string, 0, len(scan.Scores))
+		for _, s := range scan.Scores {
+			rows = append(rows, []string{
+				s.Timestamp.Format(time.RFC3339), strconv.Itoa(s.Rank), s.Symbol,
+				formatFloat(s.Price), formatFloat(s.WeightedScore), formatFloat(s.Confidence),
+				strconv.Itoa(s.BuySignals), strconv.Itoa(s.SellSignals),
+				strconv.Itoa(s.HoldSignals), strconv.Itoa(s.SetupSignals),
+				formatFloat(s.Volume), strings.ToUpper(s.Risk.String()), strings.ToUpper(s.Opportunity.String()),
+				formatSignals(s.Signals), strings.Join(s.Reasoning, "; "),
+			})
+		}
+		return w.writeCSV(scoreHeader, rows...)
+	case FormatMarkdown:
+		return writeScanMarkdown(w.w, scan)
+	default:
+		return fmt.Errorf("unsupported output format %q", w.format)
+	}
+}
+
+func writeScanMarkdown(w io.Writer, scan *Scan) error {
+	var b strings.Builder
+	b.WriteString("## Scan at " + scan.Timestamp.Format(time.DateTime) + "\n\n")
+	if len(scan.Scores) == 0 {
+		b.WriteString("No stock is meeting filter criteria.\n\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	b.WriteString(markdownRow("Rank", "Symbol", "Price", "Score", "Confidence", "Buy", "Sell", "Hold", "Setup",
+		"Volume", "Risk", "Opportunity", "Signals", "Reasoning"))
+	b.WriteString(markdownSeparator(14))
+	for _, s := range scan.Scores {
+		b.WriteString(markdownRow(strconv.Itoa(s.Rank), s.Symbol,
+			fmt.Sprintf("%.2f", s.Price), fmt.Sprintf("%.2f", s.WeightedScore),
+			fmt.Sprintf("%.1f%%", s.Confidence*100),
+			strconv.Itoa(s.BuySignals), strconv.Itoa(s.SellSignals),
+			strconv.Itoa(s.HoldSignals), strconv.Itoa(s.SetupSignals),
+			fmt.Sprintf("%.0f", s.Volume), s.Risk.String(), s.Opportunity.String(),
+			formatSignals(s.Signals), strings.Join(s.Reasoning, "; ")))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatSignals returns the signals as STRATEGY=OPERATION pairs, sorted by strategy.
+func formatSignals(m map[string]signals.Operation) string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, name+"="+string(m[name]))
+	}
+	return strings.Join(out, " ")
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
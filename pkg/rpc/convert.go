@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"github.com/CanobbioE/stock-market-clients/carnost"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/rpc/scannerv1"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+// timeFrames maps the time frames of the analyses to the ones of the provider.
+var timeFrames = map[scannerv1.TimeFrame]carnost.TimeFrame{
+	scannerv1.TimeFrame_TIME_FRAME_UNSPECIFIED: carnost.Daily,
+	scannerv1.TimeFrame_TIME_FRAME_1D:          carnost.Daily,
+	scannerv1.TimeFrame_TIME_FRAME_1M:          "1m",
+	scannerv1.TimeFrame_TIME_FRAME_3M:          "3m",
+	scannerv1.TimeFrame_TIME_FRAME_6M:          "6m",
+	scannerv1.TimeFrame_TIME_FRAME_1Y:          "1y",
+	scannerv1.TimeFrame_TIME_FRAME_3Y:          "3y",
+	scannerv1.TimeFrame_TIME_FRAME_5Y:          "5y",
+}
+
+var operations = map[signals.Operation]scannerv1.Operation{
+	signals.Buy:   scannerv1.Operation_OPERATION_BUY,
+	signals.Sell:  scannerv1.Operation_OPERATION_SELL,
+	signals.NoOp:  scannerv1.Operation_OPERATION_NOOP,
+	signals.Setup: scannerv1.Operation_OPERATION_SETUP,
+}
+
+var risks = map[monitor.RiskLevel]scannerv1.RiskLevel{
+	monitor.RiskLow:    scannerv1.RiskLevel_RISK_LEVEL_LOW,
+	monitor.RiskMedium: scannerv1.RiskLevel_RISK_LEVEL_MEDIUM,
+	monitor.RiskHigh:   scannerv1.RiskLevel_RISK_LEVEL_HIGH,
+}
+
+var opportunities = map[monitor.OpportunityLevel]scannerv1.OpportunityLevel{
+	monitor.OpportunityLow:    scannerv1.OpportunityLevel_OPPORTUNITY_LEVEL_LOW,
+	monitor.OpportunityMedium: scannerv1.OpportunityLevel_OPPORTUNITY_LEVEL_MEDIUM,
+	monitor.OpportunityHigh:   scannerv1.OpportunityLevel_OPPORTUNITY_LEVEL_HIGH,
+}
+
+// newScanFilters converts f, the unspecified levels accepting any stock.
+func newScanFilters(f *scannerv1.ScanFilters) *monitor.ScanFilters {
+	out := &monitor.ScanFilters{
+		MinConfidence:    f.GetMinConfidence(),
+		MinWeightedScore: f.GetMinWeightedScore(),
+		MaxRisk:          monitor.RiskHigh,
+		MinOpportunity:   monitor.OpportunityLow,
+		MinVolume:        f.GetMinVolume(),
+		RequiredSignals:  int(f.GetRequiredSignals()),
+	}
+	for level, message := range risks {
+		if message == f.GetMaxRisk() {
+			out.MaxRisk = level
+		}
+	}
+	for level, message := range opportunities {
+		if message == f.GetMinOpportunity() {
+			out.MinOpportunity = level
+		}
+	}
+	return out
+}
+
+func newStockScore(s *monitor.StockScore, rank int) *scannerv1.StockScore {
+	return &scannerv1.StockScore{
+		Symbol:        s.Symbol,
+		Signals:       newSignals(s.Signals),
+		Reasoning:     s.Reasoning,
+		Rank:          int32(rank),
+		Price:         s.LastPrice,
+		WeightedScore: s.WeightedScore,
+		Confidence:    s.Confidence,
+		BuySignals:    int32(s.BuySignals),
+		SellSignals:   int32(s.SellSignals),
+		HoldSignals:   int32(s.HoldSignals),
+		SetupSignals:  int32(s.SetupSignals),
+		Volume:        s.Volume,
+		Risk:          risks[s.Risk],
+		Opportunity:   opportunities[s.Opportunity],
+	}
+}
+
+func newSignals(m map[string]signals.Operation) map[string]scannerv1.Operation {
+	out := make(map[string]scannerv1.Operation, len(m))
+	for name, op := range m {
+		out[name] = operations[op]
+	}
+	return out
+}
+
+func newSentiment(m map[string]signals.Operation) *scannerv1.Sentiment {
+	out := &scannerv1.Sentiment{}
+	for _, op := range m {
+		switch op {
+		case signals.Buy:
+			out.Buy++
+		case signals.Sell:
+			out.Sell++
+		case signals.Setup:
+			out.Setup++
+		case signals.NoOp:
+			out.Noop++
+		}
+	}
+	return out
+}
+
+func newIndicators(in *strategies.Indicators) *scannerv1.Indicators {
+	return &scannerv1.Indicators{
+		Close:             in.Close,
+		Vwap:              in.VWAP,
+		Sma:               in.SMA,
+		Deviation:         in.Deviation,
+		Rsi:               in.RSI,
+		Resistance:        in.Resistance,
+		Support:           in.Support,
+		Volume:            in.Volume,
+		AverageVolume:     in.AverageVolume,
+		Atr:               in.ATR,
+		BollingerSma:      in.BollingerSMA,
+		BollingerUpper:    in.BollingerUpper,
+		BollingerLower:    in.BollingerLower,
+		BollingerWidth:    in.BollingerWidth,
+		MacdDelta:         in.MACDDelta,
+		MacdPreviousDelta: in.MACDPreviousDelta,
+		MomentumChange:    in.MomentumChange,
+		MomentumPeriod:    int32(in.MomentumPeriod),
+	}
+}
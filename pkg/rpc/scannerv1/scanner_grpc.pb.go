@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: scanner/v1/scanner.proto
+
+package scannerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScannerService_Scan_FullMethodName           = "/scanner.v1.ScannerService/Scan"
+	ScannerService_Analyse_FullMethodName        = "/scanner.v1.ScannerService/Analyse"
+	ScannerService_StreamScores_FullMethodName   = "/scanner.v1.ScannerService/StreamScores"
+	ScannerService_ListStrategies_FullMethodName = "/scanner.v1.ScannerService/ListStrategies"
+)
+
+// ScannerServiceClient is the client API for ScannerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScannerService exposes the scanning engine: the market scans, the analyses of single stocks and the strategies.
+// The scans and the analyses run the strategies of the configuration of the server.
+type ScannerServiceClient interface {
+	// Scan analyses the stocks of the universe and returns the ones meeting the filters, best opportunities first.
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error)
+	// Analyse returns the indicators and the signal of every strategy for a single stock.
+	Analyse(ctx context.Context, in *AnalyseRequest, opts ...grpc.CallOption) (*AnalyseResponse, error)
+	// StreamScores scans the stocks of the universe every interval, sending the score of every stock scanned,
+	// until the call is cancelled.
+	StreamScores(ctx context.Context, in *StreamScoresRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamScoresResponse], error)
+	// ListStrategies returns the strategies the scans and the analyses run.
+	ListStrategies(ctx context.Context, in *ListStrategiesRequest, opts ...grpc.CallOption) (*ListStrategiesResponse, error)
+}
+
+type scannerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScannerServiceClient(cc grpc.ClientConnInterface) ScannerServiceClient {
+	return &scannerServiceClient{cc}
+}
+
+func (c *scannerServiceClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResponse)
+	err := c.cc.Invoke(ctx, ScannerService_Scan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerServiceClient) Analyse(ctx context.Context, in *AnalyseRequest, opts ...grpc.CallOption) (*AnalyseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyseResponse)
+	err := c.cc.Invoke(ctx, ScannerService_Analyse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerServiceClient) StreamScores(ctx context.Context, in *StreamScoresRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamScoresResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerService_ServiceDesc.Streams[0], ScannerService_StreamScores_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamScoresRequest, StreamScoresResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerService_StreamScoresClient = grpc.ServerStreamingClient[StreamScoresResponse]
+
+func (c *scannerServiceClient) ListStrategies(ctx context.Context, in *ListStrategiesRequest, opts ...grpc.CallOption) (*ListStrategiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStrategiesResponse)
+	err := c.cc.Invoke(ctx, ScannerService_ListStrategies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerServiceServer is the server API for ScannerService service.
+// All implementations must embed UnimplementedScannerServiceServer
+// for forward compatibility.
+//
+// ScannerService exposes the scanning engine: the market scans, the analyses of single stocks and the strategies.
+// The scans and the analyses run the strategies of the configuration of the server.
+type ScannerServiceServer interface {
+	// Scan analyses the stocks of the universe and returns the ones meeting the filters, best opportunities first.
+	Scan(context.Context, *ScanRequest) (*ScanResponse, error)
+	// Analyse returns the indicators and the signal of every strategy for a single stock.
+	Analyse(context.Context, *AnalyseRequest) (*AnalyseResponse, error)
+	// StreamScores scans the stocks of the universe every interval, sending the score of every stock scanned,
+	// until the call is cancelled.
+	StreamScores(*StreamScoresRequest, grpc.ServerStreamingServer[StreamScoresResponse]) error
+	// ListStrategies returns the strategies the scans and the analyses run.
+	ListStrategies(context.Context, *ListStrategiesRequest) (*ListStrategiesResponse, error)
+	mustEmbedUnimplementedScannerServiceServer()
+}
+
+// UnimplementedScannerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScannerServiceServer struct{}
+
+func (UnimplementedScannerServiceServer) Scan(context.Context, *ScanRequest) (*ScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (UnimplementedScannerServiceServer) Analyse(context.Context, *AnalyseRequest) (*AnalyseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analyse not implemented")
+}
+func (UnimplementedScannerServiceServer) StreamScores(*StreamScoresRequest, grpc.ServerStreamingServer[StreamScoresResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamScores not implemented")
+}
+func (UnimplementedScannerServiceServer) ListStrategies(context.Context, *ListStrategiesRequest) (*ListStrategiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStrategies not implemented")
+}
+func (UnimplementedScannerServiceServer) mustEmbedUnimplementedScannerServiceServer() {}
+func (UnimplementedScannerServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeScannerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScannerServiceServer will
+// result in compilation errors.
+type UnsafeScannerServiceServer interface {
+	mustEmbedUnimplementedScannerServiceServer()
+}
+
+func RegisterScannerServiceServer(s grpc.ServiceRegistrar, srv ScannerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScannerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScannerService_ServiceDesc, srv)
+}
+
+func _ScannerService_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_Scan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).Scan(ctx, req.(*ScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerService_Analyse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).Analyse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_Analyse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).Analyse(ctx, req.(*AnalyseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerService_StreamScores_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamScoresRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerServiceServer).StreamScores(m, &grpc.GenericServerStream[StreamScoresRequest, StreamScoresResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerService_StreamScoresServer = grpc.ServerStreamingServer[StreamScoresResponse]
+
+func _ScannerService_ListStrategies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStrategiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).ListStrategies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_ListStrategies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).ListStrategies(ctx, req.(*ListStrategiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScannerService_ServiceDesc is the grpc.ServiceDesc for ScannerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScannerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scanner.v1.ScannerService",
+	HandlerType: (*ScannerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Scan",
+			Handler:    _ScannerService_Scan_Handler,
+		},
+		{
+			MethodName: "Analyse",
+			Handler:    _ScannerService_Analyse_Handler,
+		},
+		{
+			MethodName: "ListStrategies",
+			Handler:    _ScannerService_ListStrategies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamScores",
+			Handler:       _ScannerService_StreamScores_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "scanner/v1/scanner.proto",
+}
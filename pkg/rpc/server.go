@@ -0,0 +1,282 @@
+// Package rpc exposes the scanning engine over gRPC, implementing the scanner.v1 protobuf service.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/rpc/scannerv1"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+const (
+	// DefaultStreamInterval is how long StreamScores waits between the start of two scans, unless requested.
+	DefaultStreamInterval = 10 * time.Minute
+	// MinStreamInterval is the shortest interval StreamScores accepts, sparing the provider.
+	MinStreamInterval = time.Minute
+)
+
+// Server implements scannerv1.ScannerServiceServer.
+type Server struct {
+	scannerv1.UnimplementedScannerServiceServer
+	client api.Client
+	cfg    *config.Config
+	logger *slog.Logger
+	// done is closed by Close, ending the streams.
+	done      chan struct{}
+	closeOnce sync.Once
+	// mu serializes the scans and the analyses running the strategies of the configuration, which keep their state.
+	mu sync.Mutex
+}
+
+// New creates a new Server fetching the market data from cli and running the strategies of cfg.
+func New(cli api.Client, cfg *config.Config) (*Server, error) {
+	if err := checkConfig(cfg); err != nil {
+		return nil, err
+	}
+	return &Server{
+		client: cli,
+		cfg:    cfg,
+		logger: slog.Default(),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// SetLogger replaces the logger of the diagnostics, slog.Default() unless set.
+func (s *Server) SetLogger(l *slog.Logger) {
+	s.logger = l
+}
+
+// Register registers the service on r.
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	scannerv1.RegisterScannerServiceServer(r, s)
+}
+
+// Close ends the streams in progress, which otherwise last until cancelled by the clients,
+// so that the gRPC server can stop gracefully.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// Scan implements scannerv1.ScannerServiceServer.
+func (s *Server) Scan(ctx context.Context, req *scannerv1.ScanRequest) (*scannerv1.ScanResponse, error) {
+	scanner, symbols := s.scanner(req.GetSymbols(), req.GetFilters())
+	started := time.Now()
+	all, ranked, err := s.scan(ctx, scanner)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &scannerv1.ScanResponse{
+		Timestamp:     timestamppb.New(started),
+		FailedSymbols: failedSymbols(symbols, all),
+	}
+	for _, score := range order(all, ranked) {
+		if score.rank == 0 && !req.GetIncludeAll() {
+			break
+		}
+		resp.Scores = append(resp.Scores, score.message())
+	}
+	return resp, nil
+}
+
+// Analyse implements scannerv1.ScannerServiceServer.
+func (s *Server) Analyse(ctx context.Context, req *scannerv1.AnalyseRequest) (*scannerv1.AnalyseResponse, error) {
+	symbol := strings.ToUpper(req.GetSymbol())
+	if symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "no symbol requested")
+	}
+	timeFrame, ok := timeFrames[req.GetTimeFrame()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid time_frame %s", req.GetTimeFrame())
+	}
+
+	opt := &carnost.WithTimeframe{TimeFrame: timeFrame}
+	data, err := s.client.GetOHLCV(ctx, symbol, opt)
+	if err != nil {
+		return nil, s.providerError(ctx, err)
+	}
+	if len(data) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no data for symbol %s", symbol)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	analysis, operations := strategies.Analyse(nil, data, s.cfg.Strategies...)
+	return &scannerv1.AnalyseResponse{
+		Timestamp:  timestamppb.Now(),
+		Symbol:     symbol,
+		TimeFrame:  req.GetTimeFrame(),
+		Indicators: newIndicators(analysis.Indicators()),
+		Signals:    newSignals(operations),
+		Sentiment:  newSentiment(operations),
+		Strategies: int32(len(s.cfg.Strategies)),
+	}, nil
+}
+
+// StreamScores implements scannerv1.ScannerServiceServer.
+func (s *Server) StreamScores(
+	req *scannerv1.StreamScoresRequest,
+	stream grpc.ServerStreamingServer[scannerv1.StreamScoresResponse],
+) error {
+	interval := DefaultStreamInterval
+	if req.GetInterval() != nil {
+		if err := req.GetInterval().CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid interval: %v", err)
+		}
+		interval = req.GetInterval().AsDuration()
+	}
+	if interval < MinStreamInterval {
+		return status.Errorf(codes.InvalidArgument, "interval %s is shorter than %s", interval, MinStreamInterval)
+	}
+
+	ctx := stream.Context()
+	scanner, _ := s.scanner(req.GetSymbols(), req.GetFilters())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for n := int64(1); ; n++ {
+		started := time.Now()
+		all, ranked, err := s.scan(ctx, scanner)
+		if err != nil {
+			return err
+		}
+		for _, score := range order(all, ranked) {
+			if err = stream.Send(&scannerv1.StreamScoresResponse{
+				Timestamp:     timestamppb.New(started),
+				Score:         score.message(),
+				MeetsCriteria: score.rank > 0,
+				Scan:          n,
+			}); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(codes.Unavailable, "the server is stopping")
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListStrategies implements scannerv1.ScannerServiceServer.
+func (s *Server) ListStrategies(
+	context.Context,
+	*scannerv1.ListStrategiesRequest,
+) (*scannerv1.ListStrategiesResponse, error) {
+	resp := &scannerv1.ListStrategiesResponse{Strategies: make([]*scannerv1.Strategy, 0, len(s.cfg.Strategies))}
+	for _, sw := range s.cfg.Strategies {
+		resp.Strategies = append(resp.Strategies, &scannerv1.Strategy{Name: sw.Name(), Weight: sw.Weight})
+	}
+	return resp, nil
+}
+
+// scanner returns a scanner of the requested symbols and filters, falling back to the ones of the configuration,
+// and the symbols it scans.
+func (s *Server) scanner(symbols []string, filters *scannerv1.ScanFilters) (*monitor.MarketScanner, []string) {
+	if len(symbols) == 0 {
+		symbols = s.cfg.StockUniverse
+	}
+	scanFilters := s.cfg.Filters
+	if filters != nil {
+		scanFilters = newScanFilters(filters)
+	}
+	// the scores are returned, nothing is printed
+	scanner := monitor.NewMarketScanner(s.cfg.Strategies, symbols, scanFilters, s.client,
+		printer.NewStringsPrinter(&strings.Builder{}))
+	scanner.SetLogger(s.logger)
+	return scanner, symbols
+}
+
+// scan returns every score of a scan and the ones meeting the filters, best opportunities first.
+func (s *Server) scan(
+	ctx context.Context,
+	scanner *monitor.MarketScanner,
+) (all, ranked []*monitor.StockScore, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err = scanner.ScanAll(ctx)
+	if err != nil {
+		return nil, nil, s.providerError(ctx, err)
+	}
+	return all, scanner.Filter(all), nil
+}
+
+// providerError returns the status of a failure to fetch the market data.
+func (s *Server) providerError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	s.logger.Error("request failed", "error", err)
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// checkConfig reports whether cfg defines what a scan needs, beyond what decoding it already checks.
+func checkConfig(cfg *config.Config) error {
+	if len(cfg.StockUniverse) == 0 {
+		return errors.New("no stock_universe specified")
+	}
+	if cfg.Filters == nil {
+		return errors.New("no scan_filters specified")
+	}
+	return nil
+}
+
+// failedSymbols returns the symbols missing from scores.
+func failedSymbols(symbols []string, scores []*monitor.StockScore) []string {
+	scanned := make(map[string]bool, len(scores))
+	for _, score := range scores {
+		scanned[score.Symbol] = true
+	}
+	var out []string
+	for _, symbol := range symbols {
+		if !scanned[symbol] {
+			out = append(out, symbol)
+		}
+	}
+	return out
+}
+
+// rankedScore is a score and its rank, 0 if unranked.
+type rankedScore struct {
+	*monitor.StockScore
+	rank int
+}
+
+func (s *rankedScore) message() *scannerv1.StockScore {
+	return newStockScore(s.StockScore, s.rank)
+}
+
+// order returns the ranked scores, best opportunities first, followed by the other ones in all.
+func order(all, ranked []*monitor.StockScore) []*rankedScore {
+	out := make([]*rankedScore, 0, len(all))
+	seen := make(map[*monitor.StockScore]bool, len(ranked))
+	for i, score := range ranked {
+		out = append(out, &rankedScore{StockScore: score, rank: i + 1})
+		seen[score] = true
+	}
+	for _, score := range all {
+		if !seen[score] {
+			out = append(out, &rankedScore{StockScore: score})
+		}
+	}
+	return out
+}
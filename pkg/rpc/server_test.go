@@ -0,0 +1,264 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+	"github.com/CanobbioE/algo-trading/pkg/rpc"
+	"github.com/CanobbioE/algo-trading/pkg/rpc/scannerv1"
+)
+
+const testConfig = `{
+	"stock_universe": ["UP.MTA", "DOWN.MTA"],
+	"strategies": [{"strategy": "VWAP", "weight": 1}, {"strategy": "MOMENTUM", "weight": 1.5}],
+	"thresholds": {"min_momentum_return": 0.03},
+	"scan_filters": {"min_weighted_score": 1, "max_risk": "HIGH"},
+	"lookback": 5,
+	"momentum_lookback": 5
+}`
+
+// trend returns n daily bars, starting from 10 and changing by step every day.
+func trend(n int, step float64) []*api.OHLCV {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]*api.OHLCV, 0, n)
+	for i := range n {
+		price := 10 + step*float64(i)
+		out = append(out, &api.OHLCV{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      price - step/2,
+			High:      price + 0.1,
+			Low:       price - 0.1,
+			Close:     price,
+			Volume:    1e6,
+		})
+	}
+	return out
+}
+
+func newTestClient(t *testing.T) scannerv1.ScannerServiceClient {
+	t.Helper()
+	var cfg config.Config
+	if err := json.Unmarshal([]byte(testConfig), &cfg); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	player := replay.NewPlayerFromFixtures(
+		replay.NewFixture("UP.MTA", "1d", trend(30, 0.5), nil),
+		replay.NewFixture("DOWN.MTA", "1d", trend(30, -0.2), nil),
+		replay.NewFixture("UP.MTA", "1y", trend(30, 0.5), nil),
+		replay.NewFixture("EMPTY.MTA", "1d", nil, nil),
+		replay.NewFixture("DOWN.MTA", "1y", nil, errors.New("provider down")),
+	)
+	srv, err := rpc.New(player, &cfg)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	srv.SetLogger(logging.Discard())
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	srv.Register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return scannerv1.NewScannerServiceClient(conn)
+}
+
+// ranks summarizes the scores as their symbol and rank.
+func ranks(scores ...*scannerv1.StockScore) map[string]int32 {
+	out := make(map[string]int32, len(scores))
+	for _, s := range scores {
+		out[s.GetSymbol()] = s.GetRank()
+	}
+	return out
+}
+
+func TestServer_Scan(t *testing.T) {
+	cli := newTestClient(t)
+
+	type testCase struct {
+		req        *scannerv1.ScanRequest
+		want       map[string]int32
+		name       string
+		wantFailed []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "scans with the configuration of the server",
+			req:  &scannerv1.ScanRequest{},
+			want: map[string]int32{"UP.MTA": 1},
+		},
+		{
+			name: "scans with the requested filters",
+			req:  &scannerv1.ScanRequest{Filters: &scannerv1.ScanFilters{MinWeightedScore: -5}},
+			want: map[string]int32{"UP.MTA": 1, "DOWN.MTA": 2},
+		},
+		{
+			name: "returns the unranked stocks",
+			req:  &scannerv1.ScanRequest{IncludeAll: true},
+			want: map[string]int32{"UP.MTA": 1, "DOWN.MTA": 0},
+		},
+		{
+			name:       "scans the requested symbols",
+			req:        &scannerv1.ScanRequest{Symbols: []string{"UP.MTA", "MISSING.MTA"}},
+			want:       map[string]int32{"UP.MTA": 1},
+			wantFailed: []string{"MISSING.MTA"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := cli.Scan(t.Context(), tc.req)
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, ranks(resp.GetScores()...)); diff != "" {
+				t.Errorf("Scan() ranks mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantFailed, resp.GetFailedSymbols()); diff != "" {
+				t.Errorf("Scan() failed symbols mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_Analyse(t *testing.T) {
+	cli := newTestClient(t)
+
+	type testCase struct {
+		req         *scannerv1.AnalyseRequest
+		wantSignals map[string]scannerv1.Operation
+		name        string
+		wantCode    codes.Code
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "analyses the daily data by default",
+			req:  &scannerv1.AnalyseRequest{Symbol: "up.mta"},
+			wantSignals: map[string]scannerv1.Operation{
+				"VWAP":     scannerv1.Operation_OPERATION_BUY,
+				"MOMENTUM": scannerv1.Operation_OPERATION_BUY,
+			},
+		},
+		{
+			name: "analyses the requested time frame",
+			req:  &scannerv1.AnalyseRequest{Symbol: "UP.MTA", TimeFrame: scannerv1.TimeFrame_TIME_FRAME_1Y},
+			wantSignals: map[string]scannerv1.Operation{
+				"VWAP":     scannerv1.Operation_OPERATION_BUY,
+				"MOMENTUM": scannerv1.Operation_OPERATION_BUY,
+			},
+		},
+		{name: "rejects missing symbols", req: &scannerv1.AnalyseRequest{}, wantCode: codes.InvalidArgument},
+		{
+			name:     "rejects unknown time frames",
+			req:      &scannerv1.AnalyseRequest{Symbol: "UP.MTA", TimeFrame: 42},
+			wantCode: codes.InvalidArgument,
+		},
+		{name: "reports missing data", req: &scannerv1.AnalyseRequest{Symbol: "EMPTY.MTA"}, wantCode: codes.NotFound},
+		{
+			name:     "reports provider failures",
+			req:      &scannerv1.AnalyseRequest{Symbol: "DOWN.MTA", TimeFrame: scannerv1.TimeFrame_TIME_FRAME_1Y},
+			wantCode: codes.Unavailable,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := cli.Analyse(t.Context(), tc.req)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("expected code %s, instead got: %v", tc.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantSignals, resp.GetSignals()); diff != "" {
+				t.Errorf("Analyse() signals mismatch (-want +got):\n%s", diff)
+			}
+			if got := resp.GetSentiment().GetBuy(); got != 2 {
+				t.Errorf("expected 2 buy signals, instead got: %d", got)
+			}
+			if resp.GetIndicators().GetClose() == 0 {
+				t.Errorf("expected the indicators to be set, instead got: %v", resp.GetIndicators())
+			}
+		})
+	}
+}
+
+func TestServer_StreamScores(t *testing.T) {
+	cli := newTestClient(t)
+
+	t.Run("sends the scores of every scan", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		stream, err := cli.StreamScores(ctx, &scannerv1.StreamScoresRequest{Interval: durationpb.New(time.Hour)})
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		var got []string
+		for range 2 {
+			resp, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			got = append(got, resp.GetScore().GetSymbol())
+			if resp.GetScan() != 1 || resp.GetMeetsCriteria() != (resp.GetScore().GetRank() > 0) {
+				t.Errorf("unexpected score: %v", resp)
+			}
+		}
+		// the ranked scores come first
+		if diff := cmp.Diff([]string{"UP.MTA", "DOWN.MTA"}, got); diff != "" {
+			t.Errorf("StreamScores() symbols mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("rejects short intervals", func(t *testing.T) {
+		stream, err := cli.StreamScores(t.Context(), &scannerv1.StreamScoresRequest{Interval: durationpb.New(time.Second)})
+		if err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+		if _, err = stream.Recv(); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected code %s, instead got: %v", codes.InvalidArgument, err)
+		}
+	})
+}
+
+func TestServer_ListStrategies(t *testing.T) {
+	cli := newTestClient(t)
+	resp, err := cli.ListStrategies(t.Context(), &scannerv1.ListStrategiesRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	got := make(map[string]float64, len(resp.GetStrategies()))
+	for _, s := range resp.GetStrategies() {
+		got[s.GetName()] = s.GetWeight()
+	}
+	if diff := cmp.Diff(map[string]float64{"VWAP": 1, "MOMENTUM": 1.5}, got); diff != "" {
+		t.Errorf("ListStrategies() mismatch (-want +got):\n%s", diff)
+	}
+}
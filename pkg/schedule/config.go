@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config configures a set of scheduled jobs.
+type Config struct {
+	// Timezone is the default time zone of the cron expressions, the local one if empty.
+	Timezone string `json:"timezone"`
+	// Missed is the default policy for missed runs.
+	Missed MissedPolicy `json:"missed"`
+	Jobs   []*JobConfig `json:"jobs"`
+}
+
+// JobConfig configures a scheduled job.
+type JobConfig struct {
+	Name string `json:"name"`
+	// Config is the path to the configuration file the job runs with.
+	Config string `json:"config"`
+	// Schedule is when the job runs, see Parse.
+	Schedule string `json:"schedule"`
+	// Exchange is the code of the exchange @open and @close schedules refer to,
+	// by default the exchange of the first stock of the configuration.
+	Exchange string `json:"exchange"`
+	// Timezone overrides the default time zone.
+	Timezone string `json:"timezone"`
+	// Missed overrides the default policy for missed runs.
+	Missed MissedPolicy `json:"missed"`
+}
+
+// Validate checks that the jobs are named uniquely and have a configuration and a schedule.
+func (cfg *Config) Validate() error {
+	if len(cfg.Jobs) == 0 {
+		return errors.New("no jobs scheduled")
+	}
+	names := make(map[string]bool, len(cfg.Jobs))
+	for i, job := range cfg.Jobs {
+		switch {
+		case job.Name == "":
+			return fmt.Errorf("job #%d has no name", i+1)
+		case names[job.Name]:
+			return fmt.Errorf("duplicate job %q", job.Name)
+		case job.Config == "":
+			return fmt.Errorf("job %q has no config", job.Name)
+		case job.Schedule == "":
+			return fmt.Errorf("job %q has no schedule", job.Name)
+		}
+		names[job.Name] = true
+	}
+	return nil
+}
+
+// Location returns the time zone of the cron expressions of job.
+func (cfg *Config) Location(job *JobConfig) (*time.Location, error) {
+	name := cfg.Timezone
+	if job.Timezone != "" {
+		name = job.Timezone
+	}
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("job %q: invalid timezone %q: %w", job.Name, name, err)
+	}
+	return loc, nil
+}
+
+// MissedPolicy returns the policy for the missed runs of job.
+func (cfg *Config) MissedPolicy(job *JobConfig) MissedPolicy {
+	if job.Missed != "" {
+		return job.Missed
+	}
+	return cfg.Missed
+}
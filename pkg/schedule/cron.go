@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds how far ahead Next looks for a matching time.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	dayNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+	macros   = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// field is the set of values a cron field matches.
+type field uint64
+
+func (f field) has(v int) bool {
+	return f&(1<<uint(v)) != 0
+}
+
+// Cron is a standard five fields cron expression: minute, hour, day of month, month and day of week,
+// evaluated on the wall clock of its time zone.
+type Cron struct {
+	loc    *time.Location
+	source string
+	minute field
+	hour   field
+	dom    field
+	month  field
+	dow    field
+	// anyDay is set when either day field is a wildcard, in which case both must match,
+	// otherwise matching either of them is enough.
+	anyDay bool
+}
+
+// ParseCron parses a cron expression, or one of the @yearly, @monthly, @weekly, @daily and @hourly macros,
+// to be evaluated in loc.
+func ParseCron(expr string, loc *time.Location) (*Cron, error) {
+	source := strings.TrimSpace(expr)
+	if macro, ok := macros[strings.ToLower(source)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", source, len(fields))
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	c := &Cron{loc: loc, source: source}
+	var err error
+	if c.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute in cron expression %q: %w", source, err)
+	}
+	if c.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour in cron expression %q: %w", source, err)
+	}
+	if c.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month in cron expression %q: %w", source, err)
+	}
+	if c.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month in cron expression %q: %w", source, err)
+	}
+	if c.dow, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week in cron expression %q: %w", source, err)
+	}
+	// 7 is Sunday too
+	if c.dow.has(7) {
+		c.dow |= 1
+	}
+	c.anyDay = fields[2] == "*" || fields[4] == "*"
+	return c, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps, such as 1,15-17,*/10.
+func parseField(s string, lowest, highest int, names map[string]int) (field, error) {
+	var out field
+	for _, part := range strings.Split(s, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+		every := 1
+		if hasStep {
+			var err error
+			if every, err = strconv.Atoi(step); err != nil || every <= 0 {
+				return 0, fmt.Errorf("invalid step %q", step)
+			}
+		}
+
+		from, to := lowest, highest
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = parseValue(first, names); err != nil {
+				return 0, err
+			}
+			to = from
+			if isRange {
+				if to, err = parseValue(last, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// 5/15 means from 5 to the end, every 15
+				to = highest
+			}
+		}
+		if from < lowest || to > highest || from > to {
+			return 0, fmt.Errorf("%q is out of the range %d-%d", part, lowest, highest)
+		}
+
+		for v := from; v <= to; v += every {
+			out |= 1 << uint(v)
+		}
+	}
+	return out, nil
+}
+
+func parseValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t matching the expression, or the zero time if there is none.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(c.loc)
+	limit := t.Add(maxSearch)
+	// start from the next whole minute
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, c.loc)
+
+	for t.Before(limit) {
+		switch {
+		case !c.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+		case !c.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+		case !c.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom, dow := c.dom.has(t.Day()), c.dow.has(int(t.Weekday()))
+	if c.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// String returns the expression the Cron was parsed from.
+func (c *Cron) String() string {
+	return c.source
+}
@@ -0,0 +1,145 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
+)
+
+// maxSessionDays bounds how many days ahead a session schedule looks for the next trading day.
+const maxSessionDays = 30
+
+// Schedule tells when a job runs.
+type Schedule interface {
+	// Next returns the first run after t, or the zero time if the job never runs again.
+	Next(t time.Time) time.Time
+	// String returns the specification the schedule was parsed from.
+	String() string
+}
+
+// Parse parses a schedule specification, which is one of:
+//   - a cron expression or macro, evaluated in loc (see ParseCron);
+//   - @every followed by a duration, such as "@every 30m";
+//   - @open or @close followed by an optional offset, such as "@open+15m" or "@close-30m",
+//     relative to the trading sessions of exchange.
+func Parse(spec string, loc *time.Location, exchange *calendar.Exchange) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	lower := strings.ToLower(spec)
+	switch {
+	case strings.HasPrefix(lower, "@every"):
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len("@every"):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid schedule %q: the interval must be positive", spec)
+		}
+		return &Every{Interval: d}, nil
+	case strings.HasPrefix(lower, "@open"), strings.HasPrefix(lower, "@close"):
+		return parseSession(spec, exchange)
+	default:
+		return ParseCron(spec, loc)
+	}
+}
+
+// Every runs a job at a fixed interval from its previous run.
+type Every struct {
+	Interval time.Duration
+}
+
+// Next returns t plus the interval.
+func (e *Every) Next(t time.Time) time.Time {
+	return t.Add(e.Interval)
+}
+
+// String returns the specification of the schedule.
+func (e *Every) String() string {
+	return "@every " + e.Interval.String()
+}
+
+// SessionSchedule runs a job once a trading day, at an offset from the first open or the last close
+// of the exchange sessions. Weekends, holidays and early closes are taken into account.
+type SessionSchedule struct {
+	exchange *calendar.Exchange
+	source   string
+	Offset   time.Duration
+	// Close anchors the schedule to the close instead of the open.
+	Close bool
+}
+
+func parseSession(spec string, exchange *calendar.Exchange) (*SessionSchedule, error) {
+	if exchange == nil || len(exchange.Sessions) == 0 {
+		return nil, fmt.Errorf("invalid schedule %q: an exchange with trading sessions is required", spec)
+	}
+	s := &SessionSchedule{exchange: exchange, source: spec}
+	offset := spec[len("@open"):]
+	if strings.HasPrefix(strings.ToLower(spec), "@close") {
+		s.Close = true
+		offset = spec[len("@close"):]
+	}
+	if offset = strings.TrimSpace(offset); offset == "" {
+		return s, nil
+	}
+	if offset[0] != '+' && offset[0] != '-' {
+		return nil, fmt.Errorf("invalid schedule %q: expected an offset such as +15m", spec)
+	}
+	var err error
+	if s.Offset, err = time.ParseDuration(strings.ReplaceAll(offset, " ", "")); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return s, nil
+}
+
+// Next returns the first run after t.
+func (s *SessionSchedule) Next(t time.Time) time.Time {
+	day := t.In(s.exchange.Location)
+	for range maxSessionDays {
+		if run, ok := s.on(day); ok && run.After(t) {
+			return run
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, s.exchange.Location)
+	}
+	return time.Time{}
+}
+
+// on returns the run on the day of t, if it's a trading day.
+func (s *SessionSchedule) on(t time.Time) (time.Time, bool) {
+	sessions := s.exchange.SessionsOn(t)
+	if len(sessions) == 0 {
+		return time.Time{}, false
+	}
+	if s.Close {
+		return sessions[len(sessions)-1].Close.Add(s.Offset), true
+	}
+	return sessions[0].Open.Add(s.Offset), true
+}
+
+// String returns the specification the schedule was parsed from.
+func (s *SessionSchedule) String() string {
+	return s.source
+}
+
+// MissedPolicy tells what to do with the runs missed while a job couldn't run,
+// because the process was not running, the machine was asleep, or a previous run took too long.
+// The zero value skips them.
+type MissedPolicy string
+
+const (
+	// MissedSkip ignores the missed runs, waiting for the next one.
+	MissedSkip MissedPolicy = "skip"
+	// MissedRunOnce runs the job once as soon as possible, however many runs were missed.
+	MissedRunOnce MissedPolicy = "run_once"
+)
+
+// UnmarshalText implements a custom encoding.TextUnmarshaler.
+func (m *MissedPolicy) UnmarshalText(data []byte) error {
+	switch p := MissedPolicy(strings.ToLower(string(data))); p {
+	case MissedSkip, MissedRunOnce, "":
+		*m = p
+	default:
+		return fmt.Errorf("invalid missed runs policy %s, must be one of skip or run_once", data)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/calendar"
+	"github.com/CanobbioE/algo-trading/pkg/schedule"
+)
+
+func at(t *testing.T, value string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func mustExchange(t *testing.T, code string) *calendar.Exchange {
+	t.Helper()
+	c, err := calendar.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := c.Exchange(code)
+	if !ok {
+		t.Fatalf("exchange %s not found", code)
+	}
+	return e
+}
+
+func TestParse_Next(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		spec     string
+		exchange string
+		at       string
+		want     string
+	}{
+		{spec: "15 9 * * 1-5", at: "2025-06-03T08:00:00+02:00", want: "2025-06-03T09:15:00+02:00"},
+		{spec: "15 9 * * 1-5", at: "2025-06-03T09:15:00+02:00", want: "2025-06-04T09:15:00+02:00"},
+		{spec: "15 9 * * MON-FRI", at: "2025-06-06T10:00:00+02:00", want: "2025-06-09T09:15:00+02:00"},
+		{spec: "*/20 9-10 * * *", at: "2025-06-03T10:41:00+02:00", want: "2025-06-04T09:00:00+02:00"},
+		{spec: "0 12,17 * * *", at: "2025-06-03T12:00:30+02:00", want: "2025-06-03T17:00:00+02:00"},
+		{spec: "30 8 * * *", at: "2025-06-03T08:00:00Z", want: "2025-06-04T08:30:00+02:00"},
+		{spec: "0 0 1 * *", at: "2025-02-14T10:00:00+01:00", want: "2025-03-01T00:00:00+01:00"},
+		{spec: "@monthly", at: "2025-12-14T10:00:00+01:00", want: "2026-01-01T00:00:00+01:00"},
+		{spec: "0 0 29 2 *", at: "2025-01-01T00:00:00+01:00", want: "2028-02-29T00:00:00+01:00"},
+		// either the day of month or the day of week
+		{spec: "0 9 1 * 5", at: "2025-06-02T10:00:00+02:00", want: "2025-06-06T09:00:00+02:00"},
+		{spec: "0 9 * * 7", at: "2025-06-02T10:00:00+02:00", want: "2025-06-08T09:00:00+02:00"},
+		// the wall clock is kept across daylight saving changes, skipping the hour that doesn't exist
+		{spec: "0 9 * * *", at: "2025-03-29T10:00:00+01:00", want: "2025-03-30T09:00:00+02:00"},
+		{spec: "30 2 * * *", at: "2025-03-30T01:00:00+01:00", want: "2025-03-31T02:30:00+02:00"},
+		{spec: "@every 90m", at: "2025-06-03T08:10:00+02:00", want: "2025-06-03T09:40:00+02:00"},
+		{spec: "@open+15m", exchange: "MTA", at: "2025-06-03T08:00:00+02:00", want: "2025-06-03T09:15:00+02:00"},
+		{spec: "@open+15m", exchange: "MTA", at: "2025-06-06T10:00:00+02:00", want: "2025-06-09T09:15:00+02:00"},
+		{spec: "@open + 15m", exchange: "MTA", at: "2025-12-23T10:00:00+01:00", want: "2025-12-29T09:15:00+01:00"},
+		{spec: "@close-30m", exchange: "MTA", at: "2025-06-03T17:00:00+02:00", want: "2025-06-04T17:00:00+02:00"},
+		{spec: "@close-30m", exchange: "NYSE", at: "2025-11-26T16:00:00-05:00", want: "2025-11-28T12:30:00-05:00"},
+		{spec: "@close", exchange: "NYSE", at: "2025-06-03T12:00:00-04:00", want: "2025-06-03T16:00:00-04:00"},
+	} {
+		t.Run(tc.spec+" "+tc.at, func(t *testing.T) {
+			var exchange *calendar.Exchange
+			if tc.exchange != "" {
+				exchange = mustExchange(t, tc.exchange)
+			}
+			s, err := schedule.Parse(tc.spec, rome, exchange)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := s.Next(at(t, tc.at)), at(t, tc.want); !got.Equal(want) {
+				t.Errorf("expected %v, instead got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		spec string
+		want string
+	}{
+		{spec: "0 9 * *", want: `invalid cron expression "0 9 * *": expected 5 fields, got 4`},
+		{spec: "60 9 * * *", want: `invalid minute in cron expression "60 9 * * *": "60" is out of the range 0-59`},
+		{spec: "0 9 * JAX *", want: `invalid month in cron expression "0 9 * JAX *": invalid value "JAX"`},
+		{spec: "0 9 * * */0", want: `invalid day of week in cron expression "0 9 * * */0": invalid step "0"`},
+		{spec: "0 17-9 * * *", want: `invalid hour in cron expression "0 17-9 * * *": "17-9" is out of the range 0-23`},
+		{spec: "@every soon", want: `invalid schedule "@every soon": time: invalid duration "soon"`},
+		{spec: "@every -1m", want: `invalid schedule "@every -1m": the interval must be positive`},
+		{spec: "@open+15m", want: `invalid schedule "@open+15m": an exchange with trading sessions is required`},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			_, err := schedule.Parse(tc.spec, time.UTC, nil)
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("expected error %q, instead got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+)
+
+const (
+	// lateTolerance is how late a run can be noticed before it counts as missed.
+	lateTolerance = time.Minute
+	// maxSleep bounds each wait, so that the wall clock is checked again after the machine was asleep.
+	maxSleep = time.Minute
+)
+
+// Job is a task run on a schedule.
+type Job struct {
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+	// LastRun is when the job last ran, to handle the runs missed before the scheduler started.
+	LastRun time.Time
+	Name    string
+	Missed  MissedPolicy
+}
+
+// Upcoming returns the next n runs of the job after t.
+func (j *Job) Upcoming(t time.Time, n int) []time.Time {
+	out := make([]time.Time, 0, n)
+	for range n {
+		if t = j.Schedule.Next(t); t.IsZero() {
+			break
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// first returns the first run of the job, which is overdue if a run was missed since LastRun.
+func (j *Job) first(now time.Time) time.Time {
+	if !j.LastRun.IsZero() {
+		if due := j.Schedule.Next(j.LastRun); !due.IsZero() && !due.After(now) {
+			return due
+		}
+	}
+	return j.Schedule.Next(now)
+}
+
+// JobSummary reports what a job did while the scheduler ran.
+type JobSummary struct {
+	Name string
+	// Runs counts the completed runs, including the failed ones.
+	Runs     int
+	Failures int
+	// Missed counts the runs that were skipped, or coalesced into a single catch-up run.
+	Missed int
+}
+
+// Scheduler runs jobs on their schedules. Jobs run one at a time, in the order they become due,
+// so that their output doesn't interleave.
+type Scheduler struct {
+	p    printer.Printer
+	jobs []*Job
+	// mu is held while a job runs or the scheduler prints.
+	mu sync.Mutex
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(p printer.Printer, jobs ...*Job) *Scheduler {
+	return &Scheduler{
+		p:    p,
+		jobs: jobs,
+	}
+}
+
+// Run runs the jobs on their schedules until ctx is done, then returns a summary for each job.
+// A job in flight when ctx is done is interrupted.
+func (s *Scheduler) Run(ctx context.Context) []*JobSummary {
+	summaries := make([]*JobSummary, len(s.jobs))
+	var wg sync.WaitGroup
+	for i, job := range s.jobs {
+		summaries[i] = &JobSummary{Name: job.Name}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, job, summaries[i])
+		}()
+	}
+	wg.Wait()
+	return summaries
+}
+
+func (s *Scheduler) loop(ctx context.Context, job *Job, summary *JobSummary) {
+	next := job.first(time.Now())
+	for {
+		if next.IsZero() {
+			s.print(printer.Yellow, "No more runs of %s are scheduled\n", job.Name)
+			return
+		}
+		s.print(printer.Blue, "Next run of %s (%s) at %s\n",
+			job.Name, job.Schedule, next.Local().Format(time.DateTime))
+		if !sleepUntil(ctx, next) {
+			return
+		}
+
+		// every run that came due while waiting, the last one included
+		now := time.Now()
+		overdue, last := 0, next
+		for t := next; !t.IsZero() && !t.After(now); t = job.Schedule.Next(t) {
+			overdue++
+			last = t
+		}
+
+		run := true
+		switch {
+		case now.Sub(last) <= lateTolerance:
+			summary.Missed += overdue - 1
+		case job.Missed == MissedRunOnce:
+			summary.Missed += overdue - 1
+			s.print(printer.Yellow, "Catching up on %d missed runs of %s\n", overdue, job.Name)
+		default:
+			summary.Missed += overdue
+			run = false
+			s.print(printer.Yellow, "Skipping %d missed runs of %s\n", overdue, job.Name)
+		}
+
+		if run && !s.run(ctx, job, summary) {
+			return
+		}
+		next = job.Schedule.Next(last)
+	}
+}
+
+// run runs job once no other job is running, reporting whether the scheduler should go on.
+func (s *Scheduler) run(ctx context.Context, job *Job, summary *JobSummary) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return false
+	}
+
+	s.p.PrintColored(printer.Blue, "Running %s\n", job.Name)
+	job.LastRun = time.Now()
+	err := job.Run(ctx)
+	if ctx.Err() != nil {
+		s.p.PrintColored(printer.Yellow, "Run of %s interrupted\n", job.Name)
+		return false
+	}
+	summary.Runs++
+	if err != nil {
+		summary.Failures++
+		s.p.PrintColored(printer.Red, "Run of %s failed: %v\n", job.Name, err)
+	}
+	return true
+}
+
+// print prints a message of the scheduler, between the runs of the jobs.
+func (s *Scheduler) print(c printer.Color, format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.p.PrintColored(c, format, args...)
+}
+
+// sleepUntil waits until t on the wall clock, reporting false if ctx is done first.
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	for {
+		d := time.Until(t)
+		if d <= 0 {
+			return true
+		}
+		timer := time.NewTimer(min(d, maxSleep))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package schedule_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/schedule"
+)
+
+func TestScheduler_MissedRuns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		missed schedule.MissedPolicy
+		want   []*schedule.JobSummary
+	}{
+		{
+			name:   "skip",
+			missed: schedule.MissedSkip,
+			want:   []*schedule.JobSummary{{Name: "scan", Missed: 6}},
+		},
+		{
+			name:   "run once",
+			missed: schedule.MissedRunOnce,
+			want:   []*schedule.JobSummary{{Name: "scan", Runs: 1, Missed: 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			// six runs were due while the scheduler was not running
+			job := &schedule.Job{
+				Schedule: &schedule.Every{Interval: 10 * time.Minute},
+				Run:      func(context.Context) error { return nil },
+				LastRun:  time.Now().Add(-65 * time.Minute),
+				Name:     "scan",
+				Missed:   tt.missed,
+			}
+			got := schedule.NewScheduler(printer.NewStringsPrinter(&strings.Builder{}), job).Run(ctx)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScheduler_OneJobAtATime(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var running, overlaps atomic.Int32
+	run := func(context.Context) error {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		defer running.Add(-1)
+		time.Sleep(15 * time.Millisecond)
+		return nil
+	}
+	jobs := []*schedule.Job{
+		{Schedule: &schedule.Every{Interval: 10 * time.Millisecond}, Run: run, Name: "first"},
+		{Schedule: &schedule.Every{Interval: 10 * time.Millisecond}, Run: run, Name: "second"},
+	}
+
+	summaries := schedule.NewScheduler(printer.NewStringsPrinter(&strings.Builder{}), jobs...).Run(ctx)
+	if overlaps.Load() > 0 {
+		t.Errorf("expected jobs to run one at a time, %d runs overlapped", overlaps.Load())
+	}
+	for _, s := range summaries {
+		if s.Runs == 0 {
+			t.Errorf("expected %s to run", s.Name)
+		}
+	}
+}
@@ -0,0 +1,535 @@
+// Package server exposes the scans, the analyses and the history over an HTTP API.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/CanobbioE/stock-market-clients/carnost"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/history"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+)
+
+const (
+	// maxConfigSize is the largest configuration accepted in a request body.
+	maxConfigSize = 1 << 20
+	// defaultBars is how many of the latest bars the reports chart, unless requested.
+	defaultBars = 120
+	// defaultAlerts is how many of the latest alerts are returned, unless requested.
+	defaultAlerts = 100
+)
+
+// timeFrames are the time frames the analyses can be requested for.
+var timeFrames = []string{"1d", "1m", "3m", "6m", "1y", "3y", "5y"}
+
+// mediaTypes maps each configuration format to the media type of its documents.
+var mediaTypes = map[config.Format]string{
+	config.FormatJSON: "application/json",
+	config.FormatYAML: "application/yaml",
+	config.FormatTOML: "application/toml",
+}
+
+// Server serves the HTTP API.
+type Server struct {
+	client api.Client
+	cfg    *config.Config
+	store  *history.Store
+	logger *slog.Logger
+	// loader decodes and validates the posted configurations.
+	loader *config.Loader
+	// configs is where the configurations offered by the web UI are read from, nil if none.
+	configs fs.FS
+	ui      bool
+	// mu serializes the scans and the analyses running the strategies of the configuration, which keep their state.
+	mu sync.Mutex
+}
+
+// New creates a new Server fetching the market data from cli.
+// The strategies of cfg run the analyses and the scans posted without a configuration,
+// store is where the history is read from, nil if disabled.
+func New(cli api.Client, cfg *config.Config, store *history.Store) (*Server, error) {
+	if err := checkConfig(cfg); err != nil {
+		return nil, err
+	}
+	return &Server{
+		client: cli,
+		cfg:    cfg,
+		store:  store,
+		logger: slog.Default(),
+		loader: &config.Loader{FS: noConfigs{}},
+	}, nil
+}
+
+// SetLogger replaces the logger of the diagnostics, slog.Default() unless set.
+func (s *Server) SetLogger(l *slog.Logger) {
+	s.logger = l
+	s.loader.Logger = l
+}
+
+// SetStrict rejects the posted configurations with unknown fields, which are only logged unless set.
+func (s *Server) SetStrict(strict bool) {
+	s.loader.Strict = strict
+}
+
+// SetConfigs sets the directory the configuration files listed at /configs are read from, none unless set.
+// The posted configurations can extend and include the files of the directory.
+func (s *Server) SetConfigs(configs fs.FS) {
+	s.configs = configs
+	s.loader.FS = configs
+}
+
+// EnableUI serves the web UI at the root path, next to the API routes.
+func (s *Server) EnableUI() {
+	s.ui = true
+}
+
+// Handler returns the handler of the API routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /scan", s.scan)
+	mux.HandleFunc("GET /analyse/{ticker}", s.analyse)
+	mux.HandleFunc("GET /report/{ticker}", s.report)
+	mux.HandleFunc("GET /history/{ticker}", s.history)
+	mux.HandleFunc("GET /alerts", s.alerts)
+	mux.HandleFunc("POST /config/validate", s.validate)
+	mux.HandleFunc("GET /configs", s.listConfigs)
+	mux.HandleFunc("GET /configs/{name}", s.readConfigFile)
+	if s.ui {
+		mux.Handle("GET /", http.FileServerFS(uiFiles))
+	}
+	return mux
+}
+
+// Error is the body of the responses of the failed requests.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// Validation is the body of the responses to the configuration validations.
+type Validation struct {
+	Error string `json:"error,omitempty"`
+	// Problems are the ones found validating the configuration, each with the path of its field.
+	Problems []*config.FieldError `json:"problems,omitempty"`
+	Valid    bool                 `json:"valid"`
+}
+
+// Alert is the serialization of a delivered alert.Notification, with the scores as in the scans.
+type Alert struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Rule      string          `json:"rule"`
+	Severity  alert.Severity  `json:"severity"`
+	Scores    []*report.Score `json:"scores"`
+	Notifiers []string        `json:"notifiers,omitempty"`
+	Escalated bool            `json:"escalated,omitempty"`
+}
+
+// newAlert creates the Alert of n.
+func newAlert(n *alert.Notification) *Alert {
+	scores := make([]*report.Score, 0, len(n.Scores))
+	for _, score := range n.Scores {
+		scores = append(scores, report.NewScore(n.Timestamp, 0, score))
+	}
+	return &Alert{
+		Timestamp: n.Timestamp,
+		Rule:      n.Rule,
+		Severity:  n.Severity,
+		Scores:    scores,
+		Notifiers: n.Notifiers,
+		Escalated: n.Escalated,
+	}
+}
+
+// scan runs a market scan with the posted configuration, or the one of the server if the body is empty,
+// and returns the scores meeting its filters, best opportunities first.
+func (s *Server) scan(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.readConfig(w, r, s.cfg)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if cfg == s.cfg {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	// the reports are serialized, nothing is printed
+	scanner := monitor.NewMarketScanner(cfg.Strategies, cfg.StockUniverse, cfg.Filters, s.client,
+		printer.NewStringsPrinter(&strings.Builder{}))
+	scanner.SetLogger(s.logger)
+	started := time.Now()
+	all, err := scanner.ScanAll(r.Context())
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, report.NewScan(started, scanner.Filter(all)))
+}
+
+// analyse returns the indicators and the signal of every strategy for a single stock.
+func (s *Server) analyse(w http.ResponseWriter, r *http.Request) {
+	ticker, timeFrame, data, ok := s.ohlcv(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	analysis, operations := strategies.Analyse(nil, data, s.cfg.Strategies...)
+	sentiment := make(map[signals.Operation]int, len(operations))
+	for _, op := range operations {
+		sentiment[op]++
+	}
+	s.writeJSON(w, http.StatusOK, &report.Analysis{
+		Timestamp:  time.Now(),
+		Indicators: analysis.Indicators(),
+		Signals:    operations,
+		Sentiment:  sentiment,
+		Symbol:     ticker,
+		Timeframe:  timeFrame,
+		Strategies: len(s.cfg.Strategies),
+	})
+}
+
+// report returns the HTML report of a single stock, charting the requested number of its latest bars.
+func (s *Server) report(w http.ResponseWriter, r *http.Request) {
+	bars := defaultBars
+	if v := r.URL.Query().Get("bars"); v != "" {
+		var err error
+		if bars, err = strconv.Atoi(v); err != nil || bars < 0 {
+			s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid bars %q, expected a non-negative integer", v))
+			return
+		}
+	}
+	ticker, timeFrame, data, ok := s.ohlcv(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	page := report.NewAnalysisPage(ticker, timeFrame, data, bars, s.cfg.Strategies...)
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := report.WriteHTML(w, page); err != nil {
+		s.logger.Error("failed to write response", "error", err)
+	}
+}
+
+// ohlcv returns the market data of the ticker and the time frame requested, writing the error response
+// and returning false if they cannot be fetched.
+func (s *Server) ohlcv(w http.ResponseWriter, r *http.Request) (ticker, timeFrame string, data []*api.OHLCV, ok bool) {
+	ticker = strings.ToUpper(r.PathValue("ticker"))
+	timeFrame = r.URL.Query().Get("timeframe")
+	if timeFrame == "" {
+		timeFrame = string(carnost.Daily)
+	}
+	if !slices.Contains(timeFrames, timeFrame) {
+		s.writeError(w, http.StatusBadRequest,
+			fmt.Errorf("invalid timeframe %q, expected one of %s", timeFrame, strings.Join(timeFrames, ", ")))
+		return "", "", nil, false
+	}
+
+	opt := &carnost.WithTimeframe{TimeFrame: carnost.TimeFrame(timeFrame)}
+	data, err := s.client.GetOHLCV(r.Context(), ticker, opt)
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, err)
+		return "", "", nil, false
+	}
+	if len(data) == 0 {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("no data for ticker %s", ticker))
+		return "", "", nil, false
+	}
+	return ticker, timeFrame, data, true
+}
+
+// history returns the scores of a stock saved by the scans, oldest first,
+// optionally limited to the ones newer than the since duration.
+func (s *Server) history(w http.ResponseWriter, r *http.Request) {
+	if s.store == nil {
+		s.writeError(w, http.StatusNotFound, errors.New("the history is disabled"))
+		return
+	}
+	var from time.Time
+	if since := r.URL.Query().Get("since"); since != "" {
+		d, err := time.ParseDuration(since)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid since: %w", err))
+			return
+		}
+		from = time.Now().Add(-d)
+	}
+
+	records, err := s.store.Timeline(r.PathValue("ticker"), from, time.Time{})
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if records == nil {
+		records = []*history.Record{}
+	}
+	s.writeJSON(w, http.StatusOK, records)
+}
+
+// alerts returns the latest delivered alerts, most recent first, up to the limit requested.
+func (s *Server) alerts(w http.ResponseWriter, r *http.Request) {
+	if s.store == nil {
+		s.writeError(w, http.StatusNotFound, errors.New("the history is disabled"))
+		return
+	}
+	limit := defaultAlerts
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q, expected a non-negative integer", v))
+			return
+		}
+	}
+
+	alerts, err := s.store.Alerts(limit)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]*Alert, 0, len(alerts))
+	for _, n := range alerts {
+		out = append(out, newAlert(n))
+	}
+	s.writeJSON(w, http.StatusOK, out)
+}
+
+// listConfigs returns the names of the configuration files, sorted.
+func (s *Server) listConfigs(w http.ResponseWriter, _ *http.Request) {
+	if s.configs == nil {
+		s.writeError(w, http.StatusNotFound, errors.New("no configurations directory"))
+		return
+	}
+	entries, err := fs.ReadDir(s.configs, ".")
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list configurations: %w", err))
+		return
+	}
+	names := []string{}
+	for _, e := range entries {
+		if e.Type().IsRegular() && isConfigFile(e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+	s.writeJSON(w, http.StatusOK, names)
+}
+
+// readConfigFile returns the content of a configuration file, as it is unless it holds the secrets of notifiers,
+// which are blanked.
+func (s *Server) readConfigFile(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if s.configs == nil || !isConfigFile(name) {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("no configuration %q", name))
+		return
+	}
+	data, err := fs.ReadFile(s.configs, name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("no configuration %q", name))
+		return
+	case err != nil:
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to read configuration: %w", err))
+		return
+	}
+	if data, err = redact(data, config.FormatOf(name)); err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("invalid configuration %q: %w", name, err))
+		return
+	}
+	w.Header().Set("Content-Type", mediaTypes[config.FormatOf(name)])
+	if _, err = w.Write(data); err != nil {
+		s.logger.Error("failed to write response", "error", err)
+	}
+}
+
+// redact blanks the secrets of the notifiers in the configuration document data in the format f:
+// the SMTP passwords, the webhook headers and the paths and queries of the webhook URLs, which carry the tokens
+// of services such as Slack, Discord and Telegram. The documents without secrets are returned as they are.
+func redact(data []byte, f config.Format) ([]byte, error) {
+	doc, err := config.ToJSON(data, f)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	var values map[string]any
+	if err = decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+
+	notifiers, _ := values["notifiers"].([]any)
+	redacted := false
+	for _, n := range notifiers {
+		if notifier, ok := n.(map[string]any); ok && redactNotifier(notifier) {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return data, nil
+	}
+
+	var out bytes.Buffer
+	if err = config.Encode(&out, f, values); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// redactNotifier blanks the secrets of a notifier, reporting whether it had any.
+func redactNotifier(notifier map[string]any) bool {
+	redacted := false
+	if smtp, ok := notifier["smtp"].(map[string]any); ok && smtp["password"] != nil && smtp["password"] != "" {
+		smtp["password"] = ""
+		redacted = true
+	}
+	webhook, ok := notifier["webhook"].(map[string]any)
+	if !ok {
+		return redacted
+	}
+	if headers, ok := webhook["headers"].(map[string]any); ok {
+		for name := range headers {
+			headers[name] = ""
+			redacted = true
+		}
+	}
+	if raw, ok := webhook["url"].(string); ok {
+		if safe := redactURL(raw); safe != raw {
+			webhook["url"] = safe
+			redacted = true
+		}
+	}
+	return redacted
+}
+
+// redactURL returns the scheme and the host of raw, dropping the credentials, the path and the query,
+// or nothing if it's invalid, as it may still hold a token.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if u.User == nil && (u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == "" {
+		return raw
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}).String()
+}
+
+// validate reports whether the posted configuration can be scanned with.
+func (s *Server) validate(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.readConfig(w, r, nil); err != nil {
+		v := &Validation{Error: err.Error()}
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			v.Problems = verr.Problems
+		}
+		s.writeJSON(w, http.StatusOK, v)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, &Validation{Valid: true})
+}
+
+// readConfig decodes and validates the configuration in the request body, in the format of its Content-Type
+// and with the profile of the query, if any,
+// returning fallback if the body is empty and fallback is not nil.
+func (s *Server) readConfig(w http.ResponseWriter, r *http.Request, fallback *config.Config) (*config.Config, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConfigSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if fallback == nil {
+			return nil, errors.New("no configuration posted")
+		}
+		return fallback, nil
+	}
+
+	l := *s.loader
+	l.Profile = r.URL.Query().Get("profile")
+	cfg, err := l.Parse(body, bodyFormat(r))
+	if err != nil {
+		return nil, err
+	}
+	if err = checkConfig(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// checkConfig reports whether cfg defines what a scan needs, beyond what decoding it already checks.
+func checkConfig(cfg *config.Config) error {
+	if len(cfg.StockUniverse) == 0 {
+		return errors.New("no stock_universe specified")
+	}
+	if cfg.Filters == nil {
+		return errors.New("no scan_filters specified")
+	}
+	return nil
+}
+
+// bodyFormat returns the configuration format of the Content-Type of r, JSON unless YAML or TOML.
+func bodyFormat(r *http.Request) config.Format {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return config.FormatYAML
+	case "application/toml", "text/toml":
+		return config.FormatTOML
+	default:
+		return config.FormatJSON
+	}
+}
+
+// noConfigs is the file system of the posted configurations without a configurations directory,
+// so that they can't read the files of the server.
+type noConfigs struct{}
+
+func (noConfigs) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// isConfigFile reports whether name is a configuration file in the configurations directory.
+func isConfigFile(name string) bool {
+	return fs.ValidPath(name) && !strings.Contains(name, "/") && config.FormatOf(name) != config.FormatAuto
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil && !errors.Is(err, context.Canceled) {
+		s.logger.Error("failed to write response", "error", err)
+	}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	if errors.Is(err, context.Canceled) {
+		// the client is gone, nobody is reading the response
+		return
+	}
+	if status >= http.StatusInternalServerError {
+		s.logger.Error("request failed", "status", status, "error", err)
+	}
+	s.writeJSON(w, status, &Error{Error: err.Error()})
+}
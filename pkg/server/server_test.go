@@ -0,0 +1,633 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/alert"
+	"github.com/CanobbioE/algo-trading/pkg/config"
+	"github.com/CanobbioE/algo-trading/pkg/history"
+	"github.com/CanobbioE/algo-trading/pkg/logging"
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+	"github.com/CanobbioE/algo-trading/pkg/report"
+	"github.com/CanobbioE/algo-trading/pkg/server"
+)
+
+const testConfig = `{
+	"stock_universe": ["UP.MTA", "DOWN.MTA"],
+	"strategies": [{"strategy": "VWAP", "weight": 1}, {"strategy": "MOMENTUM", "weight": 1}],
+	"thresholds": {"min_momentum_return": 0.03},
+	"scan_filters": {"min_weighted_score": 1, "max_risk": "HIGH"},
+	"lookback": 5,
+	"momentum_lookback": 5
+}`
+
+// trend returns n daily bars, starting from 10 and changing by step every day.
+func trend(n int, step float64) []*api.OHLCV {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]*api.OHLCV, 0, n)
+	for i := range n {
+		price := 10 + step*float64(i)
+		out = append(out, &api.OHLCV{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      price - step/2,
+			High:      price + 0.1,
+			Low:       price - 0.1,
+			Close:     price,
+			Volume:    1e6,
+		})
+	}
+	return out
+}
+
+func newTestServer(t *testing.T, store *history.Store, setup ...func(*server.Server)) *httptest.Server {
+	t.Helper()
+	var cfg config.Config
+	if err := json.Unmarshal([]byte(testConfig), &cfg); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	player := replay.NewPlayerFromFixtures(
+		replay.NewFixture("UP.MTA", "1d", trend(30, 0.5), nil),
+		replay.NewFixture("DOWN.MTA", "1d", trend(30, -0.2), nil),
+		replay.NewFixture("UP.MTA", "1y", trend(30, 0.5), nil),
+	)
+	srv, err := server.New(player, &cfg, store)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	srv.SetLogger(logging.Discard())
+	for _, fn := range setup {
+		fn(srv)
+	}
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// do sends a request with the body, of the content type if given, and returns the status and the body of the response.
+func do(t *testing.T, ts *httptest.Server, method, path, body string, contentType ...string) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	for _, ct := range contentType {
+		req.Header.Set("Content-Type", ct)
+	}
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	return resp.StatusCode, data
+}
+
+func TestServer_Scan(t *testing.T) {
+	type testCase struct {
+		name        string
+		body        string
+		contentType string
+		wantSymbols []string
+		wantError   string
+		wantStatus  int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:        "scans with the configuration of the server",
+			wantStatus:  http.StatusOK,
+			wantSymbols: []string{"UP.MTA"},
+		},
+		{
+			name:        "scans with the posted configuration",
+			body:        strings.Replace(testConfig, `"min_weighted_score": 1`, `"min_weighted_score": -5`, 1),
+			wantStatus:  http.StatusOK,
+			wantSymbols: []string{"UP.MTA", "DOWN.MTA"},
+		},
+		{
+			name: "scans with the posted yaml configuration",
+			body: "stock_universe: [DOWN.MTA]\n" +
+				"strategies: [{strategy: VWAP, weight: 1}]\n" +
+				"thresholds: {}\n" +
+				"scan_filters: {min_weighted_score: -5, max_risk: HIGH}\n" +
+				"lookback: 5",
+			contentType: "application/yaml",
+			wantStatus:  http.StatusOK,
+			wantSymbols: []string{"DOWN.MTA"},
+		},
+		{
+			name:        "decodes the body in the format of its content type",
+			body:        testConfig,
+			contentType: "application/toml",
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "failed to parse toml",
+		},
+		{
+			name:       "rejects malformed configurations",
+			body:       `{"stock_universe": [`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "failed to decode configuration",
+		},
+		{
+			name:       "rejects configurations without universe",
+			body:       strings.Replace(testConfig, `["UP.MTA", "DOWN.MTA"]`, `[]`, 1),
+			wantStatus: http.StatusBadRequest,
+			wantError:  "no stock_universe specified",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := do(t, newTestServer(t, nil), http.MethodPost, "/scan", tc.body, tc.contentType)
+			if status != tc.wantStatus {
+				t.Fatalf("expected status %d, instead got %d: %s", tc.wantStatus, status, body)
+			}
+			if tc.wantError != "" {
+				var got server.Error
+				if err := json.Unmarshal(body, &got); err != nil || !strings.Contains(got.Error, tc.wantError) {
+					t.Errorf("expected error containing %q, instead got: %s", tc.wantError, body)
+				}
+				return
+			}
+
+			var scan report.Scan
+			if err := json.Unmarshal(body, &scan); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			got := make([]string, 0, len(scan.Scores))
+			for _, s := range scan.Scores {
+				got = append(got, s.Symbol)
+			}
+			if diff := cmp.Diff(tc.wantSymbols, got); diff != "" {
+				t.Errorf("scanned symbols mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_Analyse(t *testing.T) {
+	type testCase struct {
+		name          string
+		path          string
+		wantTimeframe string
+		wantStatus    int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "defaults to the daily bars",
+			path:          "/analyse/up.mta",
+			wantStatus:    http.StatusOK,
+			wantTimeframe: "1d",
+		},
+		{
+			name:          "uses the timeframe",
+			path:          "/analyse/UP.MTA?timeframe=1y",
+			wantStatus:    http.StatusOK,
+			wantTimeframe: "1y",
+		},
+		{
+			name:       "rejects unknown timeframes",
+			path:       "/analyse/UP.MTA?timeframe=2w",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails without data",
+			path:       "/analyse/DOWN.MTA?timeframe=5y",
+			wantStatus: http.StatusBadGateway,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := do(t, newTestServer(t, nil), http.MethodGet, tc.path, "")
+			if status != tc.wantStatus {
+				t.Fatalf("expected status %d, instead got %d: %s", tc.wantStatus, status, body)
+			}
+			if status != http.StatusOK {
+				return
+			}
+
+			var got report.Analysis
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if got.Symbol != "UP.MTA" || got.Timeframe != tc.wantTimeframe || got.Strategies != 2 {
+				t.Errorf("unexpected analysis of %s (%s) with %d strategies",
+					got.Symbol, got.Timeframe, got.Strategies)
+			}
+			if len(got.Signals) != 2 || got.Indicators == nil || got.Indicators.Close != 24.5 {
+				t.Errorf("expected the signals of 2 strategies and the indicators, instead got: %s", body)
+			}
+		})
+	}
+}
+
+func TestServer_History(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+	run := &history.Run{StartedAt: time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), Command: "scan"}
+	score := &monitor.StockScore{Symbol: "UP.MTA", WeightedScore: 2, Confidence: 0.5, LastPrice: 24.5}
+	if err := store.SaveRun(run, []*history.Record{history.NewRecord(run, score, true)}); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	type testCase struct {
+		store      *history.Store
+		name       string
+		path       string
+		wantScores []float64
+		wantStatus int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:       "returns the records",
+			store:      store,
+			path:       "/history/up.mta",
+			wantStatus: http.StatusOK,
+			wantScores: []float64{2},
+		},
+		{
+			name:       "returns no records",
+			store:      store,
+			path:       "/history/DOWN.MTA",
+			wantStatus: http.StatusOK,
+			wantScores: []float64{},
+		},
+		{
+			name:       "filters old records",
+			store:      store,
+			path:       "/history/UP.MTA?since=1h",
+			wantStatus: http.StatusOK,
+			wantScores: []float64{},
+		},
+		{
+			name:       "rejects invalid durations",
+			store:      store,
+			path:       "/history/UP.MTA?since=week",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails when disabled",
+			path:       "/history/UP.MTA",
+			wantStatus: http.StatusNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := do(t, newTestServer(t, tc.store), http.MethodGet, tc.path, "")
+			if status != tc.wantStatus {
+				t.Fatalf("expected status %d, instead got %d: %s", tc.wantStatus, status, body)
+			}
+			if status != http.StatusOK {
+				return
+			}
+
+			var records []*history.Record
+			if err := json.Unmarshal(body, &records); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			got := make([]float64, 0, len(records))
+			for _, r := range records {
+				got = append(got, r.WeightedScore)
+			}
+			if diff := cmp.Diff(tc.wantScores, got); diff != "" {
+				t.Errorf("history mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_Validate(t *testing.T) {
+	type testCase struct {
+		configs fstest.MapFS
+		name    string
+		query   string
+		body    string
+		want    server.Validation
+		strict  bool
+	}
+
+	for _, tc := range []testCase{
+		{name: "valid", body: testConfig, want: server.Validation{Valid: true}},
+		{name: "empty", want: server.Validation{Error: "no configuration posted"}},
+		{
+			name: "unknown strategy",
+			body: strings.Replace(testConfig, `"VWAP"`, `"RANDOM"`, 1),
+			want: server.Validation{
+				Error:    "invalid configuration: strategies[0].strategy: unknown strategy RANDOM",
+				Problems: []*config.FieldError{{Path: "strategies[0].strategy", Message: "unknown strategy RANDOM"}},
+			},
+		},
+		{
+			name: "every problem at once",
+			body: strings.NewReplacer(`"scan_filters"`, `"filters"`, `"weight": 1}`, `"weight": 0}`).Replace(testConfig),
+			want: server.Validation{
+				Error: "invalid configuration: 3 problems found:\n  - strategies[0].weight: must be positive, 0 given" +
+					"\n  - strategies[1].weight: must be positive, 0 given\n  - scan_filters: required",
+				Problems: []*config.FieldError{
+					{Path: "strategies[0].weight", Message: "must be positive, 0 given"},
+					{Path: "strategies[1].weight", Message: "must be positive, 0 given"},
+					{Path: "scan_filters", Message: "required"},
+				},
+			},
+		},
+		{
+			name: "ignores unknown fields",
+			body: strings.Replace(testConfig, `"lookback"`, `"look_back": 3, "lookback"`, 1),
+			want: server.Validation{Valid: true},
+		},
+		{
+			name:   "rejects unknown fields when strict",
+			body:   strings.Replace(testConfig, `"lookback"`, `"look_back": 3, "lookback"`, 1),
+			strict: true,
+			want: server.Validation{
+				Error:    "invalid configuration: look_back: unknown field",
+				Problems: []*config.FieldError{{Path: "look_back", Message: "unknown field"}},
+			},
+		},
+		{
+			name:    "extends the configuration files",
+			configs: fstest.MapFS{"base.json": {Data: []byte(testConfig)}},
+			body:    `{"extends": "base.json", "lookback": 3}`,
+			want:    server.Validation{Valid: true},
+		},
+		{
+			name: "cannot extend files without configuration files",
+			body: `{"extends": "base.json", "lookback": 3}`,
+			want: server.Validation{Error: "failed to extend base.json: open base.json: file does not exist"},
+		},
+		{
+			name:  "applies the profile of the query",
+			query: "?profile=loose",
+			body:  strings.Replace(testConfig, `"lookback"`, `"profiles": {"loose": {"lookback": -1}}, "lookback"`, 1),
+			want: server.Validation{
+				Error: "invalid configuration: lookback: must be positive for the VWAP strategy, -1 given",
+				Problems: []*config.FieldError{
+					{Path: "lookback", Message: "must be positive for the VWAP strategy, -1 given"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t, nil, func(srv *server.Server) {
+				srv.SetStrict(tc.strict)
+				if tc.configs != nil {
+					srv.SetConfigs(tc.configs)
+				}
+			})
+			status, body := do(t, ts, http.MethodPost, "/config/validate"+tc.query, tc.body)
+			if status != http.StatusOK {
+				t.Fatalf("expected status %d, instead got %d: %s", http.StatusOK, status, body)
+			}
+			var got server.Validation
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("validation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_Report(t *testing.T) {
+	type testCase struct {
+		name       string
+		path       string
+		wantTitle  string
+		wantStatus int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:       "charts the daily data by default",
+			path:       "/report/up.mta",
+			wantStatus: http.StatusOK,
+			wantTitle:  "Analysis of UP.MTA (1d)",
+		},
+		{
+			name:       "charts the requested time frame and bars",
+			path:       "/report/UP.MTA?timeframe=1y&bars=10",
+			wantStatus: http.StatusOK,
+			wantTitle:  "Analysis of UP.MTA (1y)",
+		},
+		{name: "rejects invalid bars", path: "/report/UP.MTA?bars=-1", wantStatus: http.StatusBadRequest},
+		{name: "rejects invalid time frames", path: "/report/UP.MTA?timeframe=2w", wantStatus: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := do(t, newTestServer(t, nil), http.MethodGet, tc.path, "")
+			if status != tc.wantStatus {
+				t.Fatalf("expected status %d, instead got %d: %s", tc.wantStatus, status, body)
+			}
+			if tc.wantTitle != "" && !strings.Contains(string(body), "<title>"+tc.wantTitle+"</title>") {
+				t.Errorf("expected the report to be titled %q, instead got: %s", tc.wantTitle, body)
+			}
+		})
+	}
+}
+
+func TestServer_Alerts(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	for i, rule := range []string{"breakout", "oversold"} {
+		n := &alert.Notification{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			Rule:      rule,
+			Scores:    []*monitor.StockScore{{Symbol: "UP.MTA"}},
+		}
+		if err := store.SaveAlert(n); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+	}
+
+	type testCase struct {
+		store      *history.Store
+		name       string
+		path       string
+		wantRules  []string
+		wantStatus int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:       "returns the latest alerts first",
+			store:      store,
+			path:       "/alerts",
+			wantStatus: http.StatusOK,
+			wantRules:  []string{"oversold", "breakout"},
+		},
+		{
+			name:       "limits the alerts",
+			store:      store,
+			path:       "/alerts?limit=1",
+			wantStatus: http.StatusOK,
+			wantRules:  []string{"oversold"},
+		},
+		{
+			name:       "returns no alerts",
+			store:      history.NewStore(filepath.Join(t.TempDir(), "empty.db")),
+			path:       "/alerts",
+			wantStatus: http.StatusOK,
+			wantRules:  []string{},
+		},
+		{name: "rejects invalid limits", store: store, path: "/alerts?limit=all", wantStatus: http.StatusBadRequest},
+		{name: "fails when disabled", path: "/alerts", wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := do(t, newTestServer(t, tc.store), http.MethodGet, tc.path, "")
+			if status != tc.wantStatus {
+				t.Fatalf("expected status %d, instead got %d: %s", tc.wantStatus, status, body)
+			}
+			if status != http.StatusOK {
+				return
+			}
+
+			var alerts []*server.Alert
+			if err := json.Unmarshal(body, &alerts); err != nil {
+				t.Fatalf("expected no error, instead got: %v", err)
+			}
+			got := make([]string, 0, len(alerts))
+			for _, a := range alerts {
+				if len(a.Scores) != 1 || a.Scores[0].Symbol != "UP.MTA" {
+					t.Errorf("expected the score of UP.MTA, instead got %+v", a.Scores)
+				}
+				got = append(got, a.Rule)
+			}
+			if len(alerts) > 0 && !strings.Contains(string(body), `"symbol":"UP.MTA"`) {
+				t.Errorf("expected the scores in snake case, instead got %s", body)
+			}
+			if diff := cmp.Diff(tc.wantRules, got); diff != "" {
+				t.Errorf("alerts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_Configs(t *testing.T) {
+	configs := fstest.MapFS{
+		"value.json":     {Data: []byte(testConfig)},
+		"momentum.json":  {Data: []byte(`{}`)},
+		"notes.txt":      {Data: []byte("notes")},
+		"growth.yaml":    {Data: []byte("lookback: 3")},
+		"old/value.json": {Data: []byte(`{}`)},
+		"notify.yaml": {Data: []byte(`notifiers:
+  - name: mail
+    type: smtp
+    smtp: {host: smtp.example.com, username: me, password: s3cr3t}
+  - name: slack
+    type: slack
+    webhook:
+      url: https://hooks.slack.com/services/T000/B000/t0k3n
+      headers: {Authorization: Bearer t0k3n}
+`)},
+	}
+	withConfigs := func(s *server.Server) {
+		s.SetConfigs(configs)
+	}
+
+	type testCase struct {
+		setup      func(*server.Server)
+		name       string
+		path       string
+		want       string
+		wantStatus int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:       "lists the configuration files",
+			setup:      withConfigs,
+			path:       "/configs",
+			wantStatus: http.StatusOK,
+			want:       `["growth.yaml","momentum.json","notify.yaml","value.json"]` + "\n",
+		},
+		{
+			name:       "returns a configuration file",
+			setup:      withConfigs,
+			path:       "/configs/value.json",
+			wantStatus: http.StatusOK,
+			want:       testConfig,
+		},
+		{
+			name:       "blanks the secrets of the notifiers",
+			setup:      withConfigs,
+			path:       "/configs/notify.yaml",
+			wantStatus: http.StatusOK,
+			want: `notifiers:
+  - name: mail
+    smtp:
+      host: smtp.example.com
+      password: ""
+      username: me
+    type: smtp
+  - name: slack
+    type: slack
+    webhook:
+      headers:
+        Authorization: ""
+      url: https://hooks.slack.com/
+`,
+		},
+		{name: "ignores other files", setup: withConfigs, path: "/configs/notes.txt", wantStatus: http.StatusNotFound},
+		{name: "reports missing files", setup: withConfigs, path: "/configs/x.json", wantStatus: http.StatusNotFound},
+		{
+			name:       "does not leave the directory",
+			setup:      withConfigs,
+			path:       "/configs/..%2Fvalue.json",
+			wantStatus: http.StatusNotFound,
+		},
+		{name: "fails when disabled", path: "/configs", wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var setup []func(*server.Server)
+			if tc.setup != nil {
+				setup = append(setup, tc.setup)
+			}
+			status, body := do(t, newTestServer(t, nil, setup...), http.MethodGet, tc.path, "")
+			if status != tc.wantStatus {
+				t.Fatalf("expected status %d, instead got %d: %s", tc.wantStatus, status, body)
+			}
+			if tc.want != "" {
+				if diff := cmp.Diff(tc.want, string(body)); diff != "" {
+					t.Errorf("body mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_UI(t *testing.T) {
+	t.Run("is disabled by default", func(t *testing.T) {
+		if status, _ := do(t, newTestServer(t, nil), http.MethodGet, "/", ""); status != http.StatusNotFound {
+			t.Errorf("expected status %d, instead got %d", http.StatusNotFound, status)
+		}
+	})
+
+	ts := newTestServer(t, nil, (*server.Server).EnableUI)
+	for _, path := range []string{"/", "/app.js", "/style.css"} {
+		t.Run("serves "+path, func(t *testing.T) {
+			if status, body := do(t, ts, http.MethodGet, path, ""); status != http.StatusOK || len(body) == 0 {
+				t.Errorf("expected status %d and a body, instead got %d: %s", http.StatusOK, status, body)
+			}
+		})
+	}
+
+	t.Run("keeps serving the API", func(t *testing.T) {
+		if status, body := do(t, ts, http.MethodGet, "/analyse/UP.MTA", ""); status != http.StatusOK {
+			t.Errorf("expected status %d, instead got %d: %s", http.StatusOK, status, body)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+//go:embed ui
+var uiFS embed.FS
+
+// uiFiles are the static files of the web UI, which only talks to the API routes.
+var uiFiles = utilities.MustReturn(fs.Sub(uiFS, "ui"))
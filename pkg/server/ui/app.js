@@ -0,0 +1,285 @@
+// The web UI of the serve command, it only talks to the API routes of the same server.
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+
+// api fetches a route of the API, throwing the error in the body of the failed responses.
+async function api(path, options) {
+  const resp = await fetch(path, options);
+  const body = await resp.json().catch(() => null);
+  if (!resp.ok) {
+    throw new Error((body && body.error) || resp.statusText);
+  }
+  return body;
+}
+
+function setStatus(id, text, kind) {
+  const el = $(id);
+  el.textContent = text;
+  el.className = "status" + (kind ? " " + kind : "");
+}
+
+function cell(text, cls) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  if (cls) {
+    td.className = cls;
+  }
+  return td;
+}
+
+function fixed(v, digits) {
+  return Number(v).toFixed(digits);
+}
+
+function percent(v) {
+  return fixed(v * 100, 1) + "%";
+}
+
+function time(v) {
+  return new Date(v).toLocaleString();
+}
+
+// Views.
+
+function show(view) {
+  document.querySelectorAll(".view").forEach((el) => {
+    el.hidden = el.id !== view;
+  });
+  document.querySelectorAll("nav a").forEach((a) => {
+    a.classList.toggle("active", a.dataset.view === view);
+  });
+  if (view === "alerts") {
+    loadAlerts();
+  }
+}
+
+function route() {
+  const [view, symbol] = location.hash.slice(1).split("/");
+  if (view === "ticker" && symbol) {
+    $("symbol").value = decodeURIComponent(symbol);
+    analyse();
+  }
+  show(["scan", "ticker", "alerts"].includes(view) ? view : "scan");
+}
+
+// Configurations.
+
+async function loadConfigs() {
+  let names;
+  try {
+    names = await api("configs");
+  } catch {
+    // no configurations directory, only the configuration of the server can be scanned with
+    return;
+  }
+  for (const name of names) {
+    const option = document.createElement("option");
+    option.value = option.textContent = name;
+    $("config-file").append(option);
+  }
+}
+
+async function pickConfig() {
+  const name = $("config-file").value;
+  setStatus("config-status", "");
+  showProblems([]);
+  if (!name) {
+    $("config").value = "";
+    return;
+  }
+  try {
+    const resp = await fetch("configs/" + encodeURIComponent(name));
+    if (!resp.ok) {
+      throw new Error(resp.statusText);
+    }
+    $("config").value = await resp.text();
+    $("config-format").value = resp.headers.get("Content-Type");
+  } catch (err) {
+    setStatus("config-status", "Failed to load " + name + ": " + err.message, "error");
+  }
+}
+
+async function validate() {
+  showProblems([]);
+  if (!$("config").value.trim()) {
+    setStatus("config-status", "The configuration of the server is used.", "ok");
+    return;
+  }
+  try {
+    const result = await api("config/validate", postConfig());
+    if (result.valid) {
+      setStatus("config-status", "The configuration is valid.", "ok");
+    } else if (result.problems) {
+      setStatus("config-status", "The configuration is invalid:", "error");
+      showProblems(result.problems);
+    } else {
+      setStatus("config-status", result.error, "error");
+    }
+  } catch (err) {
+    setStatus("config-status", err.message, "error");
+  }
+}
+
+// showProblems lists the problems of the configuration, each with the path of its field.
+function showProblems(problems) {
+  const items = problems.map((p) => {
+    const li = document.createElement("li");
+    li.textContent = p.path ? p.path + ": " + p.message : p.message;
+    return li;
+  });
+  $("config-problems").replaceChildren(...items);
+  $("config-problems").hidden = items.length === 0;
+}
+
+// postConfig returns the options posting the configuration being edited, in its format.
+function postConfig() {
+  return {
+    method: "POST",
+    headers: { "Content-Type": $("config-format").value },
+    body: $("config").value,
+  };
+}
+
+// Scans.
+
+let scores = [];
+
+async function scan() {
+  const button = $("run-scan");
+  button.disabled = true;
+  setStatus("scan-status", "Scanning...");
+  try {
+    const result = await api("scan", postConfig());
+    scores = result.scores || [];
+    renderRanking();
+    setStatus("scan-status", scores.length + " stocks meet the filters, scanned at " + time(result.timestamp) + ".");
+  } catch (err) {
+    setStatus("scan-status", "Scan failed: " + err.message, "error");
+  } finally {
+    button.disabled = false;
+  }
+}
+
+function renderRanking() {
+  const rows = scores.map((s) => {
+    const tr = document.createElement("tr");
+    const symbol = cell("", "text");
+    const link = document.createElement("a");
+    link.textContent = s.symbol;
+    link.href = "#ticker/" + encodeURIComponent(s.symbol);
+    symbol.append(link);
+    tr.append(
+      cell(s.rank), symbol, cell(fixed(s.price, 3)), cell(fixed(s.weighted_score, 2)), cell(percent(s.confidence)),
+      cell(s.buy_signals), cell(s.sell_signals), cell(s.hold_signals), cell(s.setup_signals),
+      cell(Math.round(s.volume)), cell(s.risk, "text"), cell(s.opportunity, "text"),
+    );
+    return tr;
+  });
+  $("ranking").tBodies[0].replaceChildren(...rows);
+}
+
+function sortRanking(th) {
+  const order = th.dataset.order === "asc" ? "desc" : "asc";
+  th.parentElement.querySelectorAll("th").forEach((el) => delete el.dataset.order);
+  th.dataset.order = order;
+  const key = th.dataset.key;
+  const numeric = th.dataset.type === "number";
+  scores.sort((a, b) => {
+    const cmp = numeric ? a[key] - b[key] : String(a[key]).localeCompare(String(b[key]));
+    return order === "asc" ? cmp : -cmp;
+  });
+  renderRanking();
+}
+
+// Tickers.
+
+async function analyse() {
+  const symbol = $("symbol").value.trim().toUpperCase();
+  if (!symbol) {
+    return;
+  }
+  const path = encodeURIComponent(symbol);
+  const timeframe = $("timeframe").value;
+
+  // the report is checked first, so that its failures are reported next to the form
+  setStatus("ticker-status", "Analysing...");
+  try {
+    const resp = await fetch("report/" + path + "?timeframe=" + timeframe);
+    if (!resp.ok) {
+      const body = await resp.json().catch(() => null);
+      throw new Error((body && body.error) || resp.statusText);
+    }
+    $("report").srcdoc = await resp.text();
+    $("report").hidden = false;
+    setStatus("ticker-status", "");
+  } catch (err) {
+    $("report").hidden = true;
+    setStatus("ticker-status", "Analysis failed: " + err.message, "error");
+  }
+
+  try {
+    const records = await api("history/" + path + "?since=720h");
+    const rows = records.reverse().map((r) => {
+      const tr = document.createElement("tr");
+      tr.append(
+        cell(time(r.timestamp), "text"), cell(fixed(r.last_price, 3)), cell(fixed(r.weighted_score, 2)),
+        cell(percent(r.confidence)), cell(r.risk, "text"), cell(r.opportunity, "text"),
+        cell(r.meets_criteria ? "yes" : "no", "text"),
+      );
+      return tr;
+    });
+    $("history").tBodies[0].replaceChildren(...rows);
+    setStatus("history-status", rows.length ? "" : "No scores of " + symbol + " saved in the last 30 days.");
+  } catch (err) {
+    $("history").tBodies[0].replaceChildren();
+    setStatus("history-status", "No history: " + err.message);
+  }
+}
+
+// Alerts.
+
+async function loadAlerts() {
+  try {
+    const alerts = await api("alerts?limit=100");
+    const rows = alerts.map((a) => {
+      const tr = document.createElement("tr");
+      const severity = (a.severity || "").toLowerCase();
+      tr.append(
+        cell(time(a.timestamp), "text"), cell(a.rule, "text"),
+        cell(a.severity + (a.escalated ? " (escalated)" : ""), "text " + severity),
+        cell((a.scores || []).map((s) => s.symbol).join(", "), "text"),
+        cell((a.notifiers || []).join(", "), "text"),
+      );
+      return tr;
+    });
+    $("alert-log").tBodies[0].replaceChildren(...rows);
+    setStatus("alerts-status", rows.length ? "" : "No alerts delivered yet.");
+  } catch (err) {
+    setStatus("alerts-status", "No alerts: " + err.message, "error");
+  }
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  $("config-file").addEventListener("change", pickConfig);
+  $("validate").addEventListener("click", validate);
+  $("run-scan").addEventListener("click", scan);
+  $("refresh-alerts").addEventListener("click", loadAlerts);
+  $("ranking").tHead.addEventListener("click", (e) => {
+    if (e.target.dataset.key) {
+      sortRanking(e.target);
+    }
+  });
+  $("ticker-form").addEventListener("submit", (e) => {
+    e.preventDefault();
+    const hash = "#ticker/" + encodeURIComponent($("symbol").value.trim().toUpperCase());
+    if (location.hash === hash) {
+      analyse();
+    } else {
+      location.hash = hash;
+    }
+  });
+  window.addEventListener("hashchange", route);
+  loadConfigs();
+  route();
+});
@@ -37,6 +37,26 @@ type StrategyWeight struct {
 	Weight   float64
 }
 
+// Name returns the canonical name of the wrapped strategy, as used in the configuration.
+func (sw *StrategyWeight) Name() string {
+	switch sw.Strategy.(type) {
+	case *BreakoutStrategy:
+		return "BREAKOUT"
+	case *VWAPStrategy:
+		return "VWAP"
+	case *MeanReversionStrategy:
+		return "MEANREVERSION"
+	case *BollingerBandSqueezeStrategy:
+		return "BOLLINGER"
+	case *MACDStrategy:
+		return "MACD"
+	case *MomentumStrategy:
+		return "MOMENTUM"
+	default:
+		return fmt.Sprintf("%T", sw.Strategy)
+	}
+}
+
 // Analysis the input parameters to perform a market analysis.
 type Analysis struct {
 	*vwapAnalysis
@@ -49,9 +69,16 @@ type Analysis struct {
 }
 
 // NewAnalysisInput populate a new Analysis using the results from the given strategies.
+// The values of the strategies that are not given are zero.
 func NewAnalysisInput(p printer.Printer, results ...*StrategyWeight) *Analysis {
 	var out = &Analysis{
-		p: p,
+		vwapAnalysis:     &vwapAnalysis{},
+		mrAnalysis:       &mrAnalysis{},
+		breakoutAnalysis: &breakoutAnalysis{},
+		bbAnalysis:       &bbAnalysis{},
+		macdAnalysis:     &macdAnalysis{},
+		momentumAnalysis: &momentumAnalysis{},
+		p:                p,
 	}
 	for _, r := range results {
 		switch s := r.Strategy.(type) {
@@ -73,6 +100,54 @@ func NewAnalysisInput(p printer.Printer, results ...*StrategyWeight) *Analysis {
 	return out
 }
 
+// Indicators are the values computed by the strategies of an Analysis,
+// the ones of a strategy that was not run are zero.
+type Indicators struct {
+	Close             float64 `json:"close"`
+	VWAP              float64 `json:"vwap"`
+	SMA               float64 `json:"sma"`
+	Deviation         float64 `json:"deviation"`
+	RSI               float64 `json:"rsi"`
+	Resistance        float64 `json:"resistance"`
+	Support           float64 `json:"support"`
+	Volume            float64 `json:"volume"`
+	AverageVolume     float64 `json:"average_volume"`
+	ATR               float64 `json:"atr"`
+	BollingerSMA      float64 `json:"bollinger_sma"`
+	BollingerUpper    float64 `json:"bollinger_upper"`
+	BollingerLower    float64 `json:"bollinger_lower"`
+	BollingerWidth    float64 `json:"bollinger_width"`
+	MACDDelta         float64 `json:"macd_delta"`
+	MACDPreviousDelta float64 `json:"macd_previous_delta"`
+	MomentumChange    float64 `json:"momentum_change"`
+	MomentumPeriod    int     `json:"momentum_period"`
+}
+
+// Indicators returns the values computed by the strategies.
+func (in *Analysis) Indicators() *Indicators {
+	out := &Indicators{}
+	if a := in.vwapAnalysis; a != nil {
+		out.Close, out.VWAP = a.closePrice, a.vwap
+	}
+	if a := in.mrAnalysis; a != nil {
+		out.SMA, out.Deviation, out.RSI = a.sma, a.deviation, a.rsi
+	}
+	if a := in.breakoutAnalysis; a != nil {
+		out.Resistance, out.Support, out.ATR = a.resistance, a.support, a.atr
+		out.Volume, out.AverageVolume = a.latestVolume, a.avgVolume
+	}
+	if a := in.bbAnalysis; a != nil {
+		out.BollingerSMA, out.BollingerUpper, out.BollingerLower, out.BollingerWidth = a.bbSMA, a.upper, a.lower, a.width
+	}
+	if a := in.macdAnalysis; a != nil {
+		out.MACDDelta, out.MACDPreviousDelta = a.delta, a.prevDelta
+	}
+	if a := in.momentumAnalysis; a != nil {
+		out.MomentumChange, out.MomentumPeriod = a.change, a.period
+	}
+	return out
+}
+
 // GenerateAnalysis pretty prints a human-readable report from the given Analysis.
 func (in *Analysis) GenerateAnalysis() {
 	in.p.Println("Key Technical Signals:")
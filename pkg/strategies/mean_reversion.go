@@ -8,6 +8,9 @@ import (
 	"github.com/CanobbioE/algo-trading/pkg/signals"
 )
 
+// defaultRSIPeriod is the period commonly used to compute the RSI.
+const defaultRSIPeriod = 14
+
 type mrAnalysis struct {
 	rsi       float64
 	deviation float64
@@ -26,6 +29,7 @@ type MeanReversionStrategy struct {
 func NewMeanReversionStrategy(lookBack int, deviationThreshold float64) *MeanReversionStrategy {
 	return &MeanReversionStrategy{
 		lookBack:           lookBack,
+		rsiPeriod:          defaultRSIPeriod,
 		deviationThreshold: deviationThreshold,
 	}
 }
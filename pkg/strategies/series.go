@@ -0,0 +1,67 @@
+package strategies
+
+import (
+	"github.com/CanobbioE/stock-market-clients/api"
+
+	"github.com/CanobbioE/algo-trading/pkg/printer"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+)
+
+// Point is the state of the strategies at a bar.
+type Point struct {
+	Indicators *Indicators
+	// Signals maps each strategy name to the operation it suggested at the bar.
+	Signals map[string]signals.Operation
+}
+
+// Series runs the strategies on every prefix of data, as they would have run when each bar closed,
+// and returns a Point per bar. The strategies are left with the analysis of the whole data.
+func Series(data []*api.OHLCV, strats ...*StrategyWeight) []*Point {
+	return SeriesFrom(data, 0, strats...)
+}
+
+// SeriesFrom is like Series, but only returns the Points of the bars from start on,
+// sparing the runs of the strategies on the earlier prefixes.
+func SeriesFrom(data []*api.OHLCV, start int, strats ...*StrategyWeight) []*Point {
+	start = max(start, 0)
+	out := make([]*Point, 0, max(len(data)-start, 0))
+	for i := start; i < len(data); i++ {
+		analysis, operations := Analyse(nil, data[:i+1], strats...)
+		out = append(out, &Point{Indicators: analysis.Indicators(), Signals: operations})
+	}
+	return out
+}
+
+// Analyse runs the strategies on data, forgetting the analysis of their previous executions,
+// and returns the Analysis printed to p along with the operation suggested by each strategy.
+func Analyse(
+	p printer.Printer,
+	data []*api.OHLCV,
+	strats ...*StrategyWeight,
+) (*Analysis, map[string]signals.Operation) {
+	operations := make(map[string]signals.Operation, len(strats))
+	for _, sw := range strats {
+		reset(sw.Strategy)
+		operations[sw.Name()] = sw.Strategy.Execute(data)
+	}
+	return NewAnalysisInput(p, strats...), operations
+}
+
+// reset forgets the analysis of the previous execution,
+// so that a strategy without enough data doesn't report stale values.
+func reset(s Strategy) {
+	switch s := s.(type) {
+	case *VWAPStrategy:
+		s.analysis = nil
+	case *MeanReversionStrategy:
+		s.analysis = nil
+	case *BreakoutStrategy:
+		s.analysis = nil
+	case *BollingerBandSqueezeStrategy:
+		s.analysis = nil
+	case *MACDStrategy:
+		s.analysis = nil
+	case *MomentumStrategy:
+		s.analysis = nil
+	}
+}
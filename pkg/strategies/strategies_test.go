@@ -0,0 +1,178 @@
+package strategies_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/replay"
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+var thresholds = &strategies.Thresholds{
+	AtrPeriod:         3,
+	LowATRThreshold:   0.07,
+	HighATRThreshold:  0.4,
+	LowLookback:       8,
+	HighLookback:      3,
+	VolumeThreshold:   1.0,
+	Deviation:         0.03,
+	Squeeze:           0.07,
+	MinMomentumReturn: 0.03,
+}
+
+func TestStrategy_Execute(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+
+	type testCase struct {
+		strategy strategies.Strategy
+		want     map[string]signals.Operation
+	}
+
+	for name, tc := range map[string]testCase{
+		"breakout": {
+			strategy: strategies.NewBreakoutStrategy(thresholds),
+			want: map[string]signals.Operation{
+				"UPTREND.MTA":   signals.NoOp,
+				"DOWNTREND.MTA": signals.NoOp,
+				"FLAT.MTA":      signals.NoOp,
+				"THIN.ETF":      signals.NoOp,
+			},
+		},
+		"vwap": {
+			strategy: strategies.NewVWAPStrategy(3),
+			want: map[string]signals.Operation{
+				"UPTREND.MTA":   signals.Buy,
+				"DOWNTREND.MTA": signals.Sell,
+				"FLAT.MTA":      signals.Buy,
+				"THIN.ETF":      signals.Sell,
+			},
+		},
+		"mean reversion": {
+			strategy: strategies.NewMeanReversionStrategy(3, thresholds.Deviation),
+			want: map[string]signals.Operation{
+				"UPTREND.MTA":   signals.Sell,
+				"DOWNTREND.MTA": signals.Buy,
+				"FLAT.MTA":      signals.NoOp,
+				"THIN.ETF":      signals.NoOp,
+			},
+		},
+		"bollinger": {
+			strategy: strategies.NewBollingerBandSqueezeStrategy(3, 1.8, thresholds.Squeeze),
+			want: map[string]signals.Operation{
+				"UPTREND.MTA":   signals.NoOp,
+				"DOWNTREND.MTA": signals.NoOp,
+				"FLAT.MTA":      signals.NoOp,
+				"THIN.ETF":      signals.NoOp,
+			},
+		},
+		"momentum": {
+			strategy: strategies.NewMomentumStrategy(8, thresholds),
+			want: map[string]signals.Operation{
+				"UPTREND.MTA":   signals.Buy,
+				"DOWNTREND.MTA": signals.Sell,
+				"FLAT.MTA":      signals.NoOp,
+				"THIN.ETF":      signals.NoOp,
+			},
+		},
+		"macd": {
+			strategy: strategies.NewMACDStrategy(&strategies.MACDParams{
+				FastPeriod:      6,
+				SlowPeriod:      13,
+				SignalPeriod:    5,
+				TriggerDistance: 0.005,
+			}),
+			want: map[string]signals.Operation{
+				"UPTREND.MTA":   signals.NoOp,
+				"DOWNTREND.MTA": signals.NoOp,
+				"FLAT.MTA":      signals.NoOp,
+				"THIN.ETF":      signals.Sell,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for symbol, want := range tc.want {
+				data := utilities.MustReturn(player.GetOHLCV(context.Background(), symbol))
+				got := tc.strategy.Execute(data)
+				if got != want {
+					t.Errorf("%s: expected %s, instead got %s", symbol, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSeries(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+	data := utilities.MustReturn(player.GetOHLCV(context.Background(), "UPTREND.MTA"))
+	momentum := &strategies.StrategyWeight{Strategy: strategies.NewMomentumStrategy(8, thresholds), Weight: 1}
+	vwap := &strategies.StrategyWeight{Strategy: strategies.NewVWAPStrategy(3), Weight: 1}
+
+	points := strategies.Series(data, momentum, vwap)
+	if len(points) != len(data) {
+		t.Fatalf("expected %d points, instead got %d", len(data), len(points))
+	}
+
+	// momentum needs 9 bars, the ones before it report no indicators
+	if got := points[7].Indicators.MomentumPeriod; got != 0 {
+		t.Errorf("expected no momentum before enough bars, instead got a period of %d", got)
+	}
+	if got := points[8].Indicators.MomentumPeriod; got != 8 {
+		t.Errorf("expected a momentum period of 8, instead got %d", got)
+	}
+
+	last := points[len(points)-1]
+	for _, sw := range []*strategies.StrategyWeight{momentum, vwap} {
+		if want, got := sw.Strategy.Execute(data), last.Signals[sw.Name()]; got != want {
+			t.Errorf("%s: expected %s at the last bar, instead got %s", sw.Name(), want, got)
+		}
+	}
+	if want, got := data[len(data)-1].Close, last.Indicators.Close; got != want {
+		t.Errorf("expected a close of %f at the last bar, instead got %f", want, got)
+	}
+}
+
+func TestSeriesFrom(t *testing.T) {
+	player := utilities.MustReturn(replay.NewPlayer("../replay/testdata/fixtures"))
+	data := utilities.MustReturn(player.GetOHLCV(context.Background(), "UPTREND.MTA"))
+	momentum := &strategies.StrategyWeight{Strategy: strategies.NewMomentumStrategy(8, thresholds), Weight: 1}
+
+	want := strategies.Series(data, momentum)
+	for _, start := range []int{-1, 0, 5, len(data) - 1, len(data)} {
+		got := strategies.SeriesFrom(data, start, momentum)
+		if diff := cmp.Diff(want[max(start, 0):], got); diff != "" {
+			t.Errorf("points from %d mismatch (-want +got):\n%s", start, diff)
+		}
+	}
+}
+
+func TestMeanReversionStrategy_ConfirmsWithRSI(t *testing.T) {
+	closes := func(from float64, step float64, n int) []*api.OHLCV {
+		data := make([]*api.OHLCV, 0, n)
+		for i := range n {
+			c := from + step*float64(i)
+			data = append(data, &api.OHLCV{Open: c, High: c, Low: c, Close: c, Volume: 1000})
+		}
+		return data
+	}
+
+	for name, tc := range map[string]struct {
+		data []*api.OHLCV
+		want signals.Operation
+	}{
+		"buys an oversold stock below its mean":    {data: closes(20, -0.5, 20), want: signals.Buy},
+		"sells an overbought stock above its mean": {data: closes(20, 0.5, 20), want: signals.Sell},
+		"waits for enough bars to compute the RSI": {data: closes(20, -0.5, 14), want: signals.NoOp},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := strategies.NewMeanReversionStrategy(5, 0.03)
+			if got := s.Execute(tc.data); got != tc.want {
+				t.Errorf("expected %s, instead got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Server is a local websocket stand-in for a live data provider:
+// clients subscribe to symbols and receive the updates passed to Publish.
+type Server struct {
+	clients  map[*websocket.Conn]map[string]bool
+	upgrader websocket.Upgrader
+	mu       sync.Mutex
+}
+
+// NewServer creates a new Server.
+func NewServer() *Server {
+	return &Server{
+		clients: make(map[*websocket.Conn]map[string]bool),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	for {
+		var sub subscription
+		if err = conn.ReadJSON(&sub); err != nil {
+			return
+		}
+		s.mu.Lock()
+		symbols, ok := s.clients[conn]
+		if !ok {
+			symbols = make(map[string]bool)
+			s.clients[conn] = symbols
+		}
+		for _, sym := range sub.Symbols {
+			symbols[strings.ToUpper(sym)] = sub.Action != "unsubscribe"
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Subscribers returns the number of connected clients subscribed to symbol.
+func (s *Server) Subscribers(symbol string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int
+	for _, symbols := range s.clients {
+		if symbols[strings.ToUpper(symbol)] {
+			n++
+		}
+	}
+	return n
+}
+
+// Publish sends u to every client subscribed to its symbol.
+func (s *Server) Publish(u *Update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := newMessage(u)
+	for conn, symbols := range s.clients {
+		if !symbols[strings.ToUpper(u.Symbol)] {
+			continue
+		}
+		if err := conn.WriteJSON(m); err != nil {
+			_ = conn.Close()
+			delete(s.clients, conn)
+		}
+	}
+}
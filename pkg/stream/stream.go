@@ -0,0 +1,90 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+)
+
+// Update is an incremental bar update for a single symbol.
+type Update struct {
+	Bar    *api.OHLCV
+	Symbol string
+	// Final is true once the bar is closed and won't be updated anymore.
+	Final bool
+}
+
+// Streamer delivers live bar updates, alongside the historical data served by an api.Client.
+type Streamer interface {
+	// Subscribe starts streaming the updates for the given symbols.
+	// The returned channel is closed when ctx is done or the stream ends.
+	Subscribe(ctx context.Context, symbols ...string) (<-chan *Update, error)
+}
+
+// message is the wire format of an Update.
+type message struct {
+	Timestamp time.Time `json:"timestamp"`
+	Symbol    string    `json:"symbol"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	Final     bool      `json:"final"`
+}
+
+// subscription is the wire format of a subscription request.
+type subscription struct {
+	Action  string   `json:"action"`
+	Symbols []string `json:"symbols"`
+}
+
+func newMessage(u *Update) *message {
+	return &message{
+		Timestamp: u.Bar.Timestamp,
+		Symbol:    u.Symbol,
+		Open:      u.Bar.Open,
+		High:      u.Bar.High,
+		Low:       u.Bar.Low,
+		Close:     u.Bar.Close,
+		Volume:    u.Bar.Volume,
+		Final:     u.Final,
+	}
+}
+
+func (m *message) toUpdate() *Update {
+	return &Update{
+		Symbol: m.Symbol,
+		Final:  m.Final,
+		Bar: &api.OHLCV{
+			Timestamp: m.Timestamp,
+			Open:      m.Open,
+			High:      m.High,
+			Low:       m.Low,
+			Close:     m.Close,
+			Volume:    m.Volume,
+		},
+	}
+}
+
+// Merge applies u to the bars of its symbol: a bar with the same timestamp as the
+// last one replaces it (the bar is still forming), a newer bar is appended.
+// At most maxBars are kept, 0 meaning unlimited.
+func Merge(bars []*api.OHLCV, u *Update, maxBars int) []*api.OHLCV {
+	n := len(bars)
+	switch {
+	case n > 0 && u.Bar.Timestamp.Equal(bars[n-1].Timestamp):
+		bars[n-1] = u.Bar
+	case n > 0 && u.Bar.Timestamp.Before(bars[n-1].Timestamp):
+		// late update for a bar that was already superseded
+		return bars
+	default:
+		bars = append(bars, u.Bar)
+	}
+
+	if maxBars > 0 && len(bars) > maxBars {
+		bars = bars[len(bars)-maxBars:]
+	}
+	return bars
+}
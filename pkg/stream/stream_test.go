@@ -0,0 +1,108 @@
+package stream_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/stream"
+)
+
+var day = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func bar(offset int, c float64) *api.OHLCV {
+	return &api.OHLCV{Timestamp: day.AddDate(0, 0, offset), Open: c, High: c, Low: c, Close: c, Volume: 100}
+}
+
+func TestMerge(t *testing.T) {
+	type testCase struct {
+		name    string
+		bars    []*api.OHLCV
+		update  *api.OHLCV
+		want    []*api.OHLCV
+		maxBars int
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:   "appends a new bar",
+			bars:   []*api.OHLCV{bar(0, 1), bar(1, 2)},
+			update: bar(2, 3),
+			want:   []*api.OHLCV{bar(0, 1), bar(1, 2), bar(2, 3)},
+		},
+		{
+			name:   "replaces the bar still forming",
+			bars:   []*api.OHLCV{bar(0, 1), bar(1, 2)},
+			update: bar(1, 5),
+			want:   []*api.OHLCV{bar(0, 1), bar(1, 5)},
+		},
+		{
+			name:   "ignores late updates",
+			bars:   []*api.OHLCV{bar(0, 1), bar(1, 2)},
+			update: bar(0, 5),
+			want:   []*api.OHLCV{bar(0, 1), bar(1, 2)},
+		},
+		{
+			name:   "appends to an empty history",
+			update: bar(0, 1),
+			want:   []*api.OHLCV{bar(0, 1)},
+		},
+		{
+			name:    "drops the oldest bars",
+			bars:    []*api.OHLCV{bar(0, 1), bar(1, 2)},
+			update:  bar(2, 3),
+			maxBars: 2,
+			want:    []*api.OHLCV{bar(1, 2), bar(2, 3)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := stream.Merge(tc.bars, &stream.Update{Symbol: "ENI.MTA", Bar: tc.update}, tc.maxBars)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("merge result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebsocket_Subscribe(t *testing.T) {
+	srv := stream.NewServer()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updates, err := stream.NewWebsocket("ws"+strings.TrimPrefix(ts.URL, "http")).Subscribe(ctx, "ENI.MTA")
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	for srv.Subscribers("ENI.MTA") == 0 {
+		if ctx.Err() != nil {
+			t.Fatal("timed out waiting for the subscription")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	want := &stream.Update{Symbol: "ENI.MTA", Bar: bar(0, 13.2), Final: true}
+	srv.Publish(&stream.Update{Symbol: "A2A.MTA", Bar: bar(0, 2.1)})
+	srv.Publish(want)
+
+	select {
+	case got := <-updates:
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("update mismatch (-want +got):\n%s", diff)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the update")
+	}
+
+	cancel()
+	for range updates {
+		// drain until the stream is closed
+	}
+}
@@ -0,0 +1,66 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// Websocket streams bar updates from a websocket endpoint.
+type Websocket struct {
+	dialer *websocket.Dialer
+	url    string
+}
+
+// NewWebsocket creates a new Websocket streamer connecting to url.
+func NewWebsocket(url string) *Websocket {
+	return &Websocket{
+		url:    url,
+		dialer: websocket.DefaultDialer,
+	}
+}
+
+// Subscribe implements Streamer.
+func (w *Websocket) Subscribe(ctx context.Context, symbols ...string) (<-chan *Update, error) {
+	conn, resp, err := w.dialer.DialContext(ctx, w.url, nil)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", w.url, err)
+	}
+
+	if err = conn.WriteJSON(&subscription{Action: "subscribe", Symbols: symbols}); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	updates := make(chan *Update)
+	// unblocks ReadJSON below once ctx is done
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	go func() {
+		defer close(updates)
+		defer func() {
+			if stop() {
+				// the stream ended on its own, release the connection
+				_ = conn.Close()
+			}
+		}()
+		for {
+			var m message
+			if err := conn.ReadJSON(&m); err != nil {
+				return
+			}
+			select {
+			case updates <- m.toUpdate():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
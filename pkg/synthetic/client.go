@@ -0,0 +1,36 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+)
+
+// Client is an api.Client serving synthetic series, without any provider.
+type Client struct {
+	series map[string]*Series
+}
+
+// NewClient creates a new Client serving the given series.
+func NewClient(series ...*Series) *Client {
+	c := &Client{series: make(map[string]*Series, len(series))}
+	for _, s := range series {
+		c.series[strings.ToUpper(s.Symbol)] = s
+	}
+	return c
+}
+
+// GetOHLCV generates the series registered for symbol.
+// The requested options are ignored: the series defines its own interval.
+func (c *Client) GetOHLCV(ctx context.Context, symbol string, _ ...api.Option) ([]*api.OHLCV, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, ok := c.series[strings.ToUpper(symbol)]
+	if !ok {
+		return nil, fmt.Errorf("no synthetic series for %s", symbol)
+	}
+	return s.Generate(), nil
+}
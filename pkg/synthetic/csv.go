@@ -0,0 +1,36 @@
+package synthetic
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+)
+
+// WriteCSV writes data as CSV with a timestamp,open,high,low,close,volume header.
+func WriteCSV(w io.Writer, data []*api.OHLCV) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "open", "high", "low", "close", "volume"}); err != nil {
+		return err
+	}
+	for _, d := range data {
+		if err := cw.Write([]string{
+			d.Timestamp.Format(time.RFC3339),
+			formatFloat(d.Open),
+			formatFloat(d.High),
+			formatFloat(d.Low),
+			formatFloat(d.Close),
+			formatFloat(d.Volume),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
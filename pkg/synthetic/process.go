@@ -0,0 +1,79 @@
+package synthetic
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Process describes how the close price evolves from one bar to the next.
+type Process interface {
+	// Next returns the price following prev, drawing randomness from rng only.
+	Next(rng *rand.Rand, prev float64) float64
+}
+
+// forker is implemented by the processes that keep a state between bars.
+// Every series generation runs on its own fork, so that concurrent generations don't share the state.
+type forker interface {
+	fork() Process
+}
+
+// forkOf returns a fresh copy of p to generate a series with.
+func forkOf(p Process) Process {
+	if f, ok := p.(forker); ok {
+		return f.fork()
+	}
+	return p
+}
+
+// GBM is a geometric Brownian motion: log returns are normally distributed
+// with the given per-bar drift and volatility.
+type GBM struct {
+	Drift      float64 `json:"drift"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Next implements Process.
+func (g *GBM) Next(rng *rand.Rand, prev float64) float64 {
+	return prev * math.Exp(g.Drift-g.Volatility*g.Volatility/2+g.Volatility*rng.NormFloat64())
+}
+
+// OrnsteinUhlenbeck is a mean-reverting process: the price is pulled towards Mean
+// with the given Speed, while Volatility is relative to the current price.
+type OrnsteinUhlenbeck struct {
+	Mean       float64 `json:"mean"`
+	Speed      float64 `json:"speed"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Next implements Process.
+func (o *OrnsteinUhlenbeck) Next(rng *rand.Rand, prev float64) float64 {
+	next := prev + o.Speed*(o.Mean-prev) + o.Volatility*prev*rng.NormFloat64()
+	return math.Max(next, minPrice)
+}
+
+// RegimeSwitching alternates between the given processes: after every bar,
+// the current regime is abandoned with probability SwitchProbability.
+type RegimeSwitching struct {
+	Regimes           []Process
+	SwitchProbability float64
+	current           int
+}
+
+// Next implements Process.
+func (r *RegimeSwitching) Next(rng *rand.Rand, prev float64) float64 {
+	if len(r.Regimes) == 0 {
+		return prev
+	}
+	if len(r.Regimes) > 1 && rng.Float64() < r.SwitchProbability {
+		r.current = (r.current + 1 + rng.IntN(len(r.Regimes)-1)) % len(r.Regimes)
+	}
+	return r.Regimes[r.current].Next(rng, prev)
+}
+
+func (r *RegimeSwitching) fork() Process {
+	regimes := make([]Process, 0, len(r.Regimes))
+	for _, p := range r.Regimes {
+		regimes = append(regimes, forkOf(p))
+	}
+	return &RegimeSwitching{Regimes: regimes, SwitchProbability: r.SwitchProbability}
+}
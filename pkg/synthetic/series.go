@@ -0,0 +1,111 @@
+package synthetic
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/CanobbioE/stock-market-clients/api"
+)
+
+// minPrice prevents processes from producing non-positive prices.
+const minPrice = 0.0001
+
+// Gap opens the bar At with a price jump of Size (e.g. -0.05 for a 5% gap down).
+type Gap struct {
+	At   int     `json:"at"`
+	Size float64 `json:"size"`
+}
+
+// Trend adds a constant per-bar Drift to every bar in [From, To).
+type Trend struct {
+	From  int     `json:"from"`
+	To    int     `json:"to"`
+	Drift float64 `json:"drift"`
+}
+
+// VolumeSpike multiplies the volume of the bar At by Multiplier.
+type VolumeSpike struct {
+	At         int     `json:"at"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Series describes a reproducible synthetic OHLCV series.
+type Series struct {
+	Start        time.Time
+	Process      Process
+	Symbol       string
+	Gaps         []Gap
+	Trends       []Trend
+	VolumeSpikes []VolumeSpike
+	Seed         uint64
+	Bars         int
+	Interval     time.Duration
+	InitialPrice float64
+	BaseVolume   float64
+}
+
+// Generate produces the OHLCV bars described by s.
+// The same Series always produces the same bars.
+func (s *Series) Generate() []*api.OHLCV {
+	//nolint:gosec // synthetic data must be reproducible, not secure
+	rng := rand.New(rand.NewPCG(s.Seed, s.Seed))
+	process := forkOf(s.Process)
+
+	gaps := make(map[int]float64, len(s.Gaps))
+	for _, g := range s.Gaps {
+		gaps[g.At] += g.Size
+	}
+	spikes := make(map[int]float64, len(s.VolumeSpikes))
+	for _, v := range s.VolumeSpikes {
+		spikes[v.At] = v.Multiplier
+	}
+
+	data := make([]*api.OHLCV, 0, s.Bars)
+	prevClose := s.InitialPrice
+	for i := range s.Bars {
+		open := prevClose * (1 + gaps[i])
+		closePrice := open
+		if process != nil {
+			closePrice = process.Next(rng, open)
+		}
+		closePrice = math.Max(closePrice*(1+s.drift(i)), minPrice)
+
+		// intra-bar excursions proportional to the bar body
+		body := math.Abs(closePrice-open) / open
+		high := math.Max(open, closePrice) * (1 + math.Abs(rng.NormFloat64())*body/2)
+		low := math.Min(open, closePrice) * (1 - math.Abs(rng.NormFloat64())*body/2)
+
+		// larger moves attract larger volume
+		volume := s.BaseVolume * (1 + 0.3*math.Abs(rng.NormFloat64()) + 10*body)
+		if m, ok := spikes[i]; ok {
+			volume *= m
+		}
+
+		data = append(data, &api.OHLCV{
+			Timestamp: s.Start.Add(time.Duration(i) * s.Interval),
+			Open:      round(open),
+			High:      round(high),
+			Low:       round(math.Max(low, minPrice)),
+			Close:     round(closePrice),
+			Volume:    math.Round(volume),
+		})
+		prevClose = closePrice
+	}
+
+	return data
+}
+
+func (s *Series) drift(i int) float64 {
+	var d float64
+	for _, t := range s.Trends {
+		if i >= t.From && i < t.To {
+			d += t.Drift
+		}
+	}
+	return d
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,139 @@
+package synthetic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type (
+	// Spec is the JSON description of a set of synthetic series.
+	Spec struct {
+		Series []*Series
+	}
+	rawSpec struct {
+		Series []*rawSeries `json:"series"`
+	}
+	rawSeries struct {
+		Start        time.Time     `json:"start"`
+		Process      *rawProcess   `json:"process"`
+		Symbol       string        `json:"symbol"`
+		Interval     string        `json:"interval"`
+		Gaps         []Gap         `json:"gaps"`
+		Trends       []Trend       `json:"trends"`
+		VolumeSpikes []VolumeSpike `json:"volume_spikes"`
+		Seed         uint64        `json:"seed"`
+		Bars         int           `json:"bars"`
+		InitialPrice float64       `json:"initial_price"`
+		BaseVolume   float64       `json:"base_volume"`
+	}
+	rawProcess struct {
+		Type              string        `json:"type"`
+		Regimes           []*rawProcess `json:"regimes"`
+		Drift             float64       `json:"drift"`
+		Volatility        float64       `json:"volatility"`
+		Mean              float64       `json:"mean"`
+		Speed             float64       `json:"speed"`
+		SwitchProbability float64       `json:"switch_probability"`
+	}
+)
+
+const (
+	defaultBars         = 250
+	defaultInitialPrice = 100
+	defaultBaseVolume   = 500000
+)
+
+// UnmarshalJSON implements a custom json.Unmarshaler.
+func (s *Spec) UnmarshalJSON(data []byte) error {
+	var raw rawSpec
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse json raw: %w", err)
+	}
+	if len(raw.Series) == 0 {
+		return errors.New("at least one series must be specified")
+	}
+
+	s.Series = make([]*Series, 0, len(raw.Series))
+	for _, r := range raw.Series {
+		series, err := r.toSeries()
+		if err != nil {
+			return fmt.Errorf("series %q: %w", r.Symbol, err)
+		}
+		s.Series = append(s.Series, series)
+	}
+
+	return nil
+}
+
+func (r *rawSeries) toSeries() (*Series, error) {
+	if r.Symbol == "" {
+		return nil, errors.New("no symbol specified")
+	}
+	if r.Process == nil {
+		return nil, errors.New("no process specified")
+	}
+	process, err := r.Process.toProcess()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Series{
+		Start:        r.Start,
+		Process:      process,
+		Symbol:       r.Symbol,
+		Gaps:         r.Gaps,
+		Trends:       r.Trends,
+		VolumeSpikes: r.VolumeSpikes,
+		Seed:         r.Seed,
+		Bars:         r.Bars,
+		Interval:     24 * time.Hour,
+		InitialPrice: r.InitialPrice,
+		BaseVolume:   r.BaseVolume,
+	}
+	if r.Interval != "" {
+		if s.Interval, err = time.ParseDuration(r.Interval); err != nil {
+			return nil, fmt.Errorf("invalid interval: %w", err)
+		}
+	}
+	if s.Start.IsZero() {
+		s.Start = time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)
+	}
+	if s.Bars == 0 {
+		s.Bars = defaultBars
+	}
+	if s.InitialPrice == 0 {
+		s.InitialPrice = defaultInitialPrice
+	}
+	if s.BaseVolume == 0 {
+		s.BaseVolume = defaultBaseVolume
+	}
+
+	return s, nil
+}
+
+func (r *rawProcess) toProcess() (Process, error) {
+	switch strings.ToUpper(r.Type) {
+	case "GBM":
+		return &GBM{Drift: r.Drift, Volatility: r.Volatility}, nil
+	case "OU", "ORNSTEIN-UHLENBECK", "MEANREVERTING", "MEAN-REVERTING":
+		return &OrnsteinUhlenbeck{Mean: r.Mean, Speed: r.Speed, Volatility: r.Volatility}, nil
+	case "REGIME", "REGIME-SWITCHING":
+		if len(r.Regimes) == 0 {
+			return nil, errors.New("at least one regime must be specified")
+		}
+		rs := &RegimeSwitching{SwitchProbability: r.SwitchProbability}
+		for _, regime := range r.Regimes {
+			p, err := regime.toProcess()
+			if err != nil {
+				return nil, err
+			}
+			rs.Regimes = append(rs.Regimes, p)
+		}
+		return rs, nil
+	default:
+		return nil, fmt.Errorf("unknown process %s", r.Type)
+	}
+}
@@ -0,0 +1,137 @@
+package synthetic_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/CanobbioE/algo-trading/pkg/signals"
+	"github.com/CanobbioE/algo-trading/pkg/strategies"
+	"github.com/CanobbioE/algo-trading/pkg/synthetic"
+	"github.com/CanobbioE/algo-trading/pkg/utilities"
+)
+
+func loadSpec(t *testing.T) *synthetic.Spec {
+	t.Helper()
+	var spec synthetic.Spec
+	if err := json.Unmarshal(utilities.MustReturn(os.ReadFile("./testdata/spec.json")), &spec); err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+	return &spec
+}
+
+func TestSpec_UnmarshalJSON(t *testing.T) {
+	spec := loadSpec(t)
+	if len(spec.Series) != 2 {
+		t.Fatalf("expected 2 series, instead got %d", len(spec.Series))
+	}
+
+	regime := spec.Series[1]
+	if _, ok := regime.Process.(*synthetic.RegimeSwitching); !ok {
+		t.Errorf("expected *synthetic.RegimeSwitching, instead got %T", regime.Process)
+	}
+	if regime.Interval != time.Hour || regime.Bars != 250 || regime.InitialPrice != 100 {
+		t.Errorf("unexpected defaults: interval %s, bars %d, initial price %.2f",
+			regime.Interval, regime.Bars, regime.InitialPrice)
+	}
+
+	for name, data := range map[string]string{
+		"fails with no series":        `{"series": []}`,
+		"fails with unknown process":  `{"series": [{"symbol": "X", "process": {"type": "what's this?"}}]}`,
+		"fails with missing process":  `{"series": [{"symbol": "X"}]}`,
+		"fails with invalid interval": `{"series": [{"symbol": "X", "interval": "1x", "process": {"type": "gbm"}}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var s synthetic.Spec
+			if err := json.Unmarshal([]byte(data), &s); err == nil {
+				t.Error("expected error, instead got none")
+			}
+		})
+	}
+}
+
+func TestSeries_Generate(t *testing.T) {
+	spec := loadSpec(t)
+	ou := spec.Series[0]
+
+	t.Run("is deterministic for a given seed", func(t *testing.T) {
+		for _, s := range spec.Series {
+			if diff := cmp.Diff(s.Generate(), s.Generate()); diff != "" {
+				t.Errorf("%s: generated data mismatch (-first +second):\n%s", s.Symbol, diff)
+			}
+		}
+	})
+
+	t.Run("is deterministic across concurrent generations", func(t *testing.T) {
+		regime := spec.Series[1]
+		want := regime.Generate()
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if diff := cmp.Diff(want, regime.Generate()); diff != "" {
+					t.Errorf("generated data mismatch (-want +got):\n%s", diff)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("produces different data for different seeds", func(t *testing.T) {
+		other := *ou
+		other.Seed++
+		if cmp.Equal(ou.Generate(), other.Generate()) {
+			t.Error("expected different data for different seeds")
+		}
+	})
+
+	t.Run("produces consistent bars", func(t *testing.T) {
+		data := ou.Generate()
+		if len(data) != ou.Bars {
+			t.Fatalf("expected %d bars, instead got %d", ou.Bars, len(data))
+		}
+		for i, d := range data {
+			if d.High < max(d.Open, d.Close) || d.Low > min(d.Open, d.Close) || d.Low <= 0 {
+				t.Errorf("bar %d is inconsistent: %+v", i, d)
+			}
+			if !d.Timestamp.Equal(ou.Start.Add(time.Duration(i) * ou.Interval)) {
+				t.Errorf("bar %d has unexpected timestamp %s", i, d.Timestamp)
+			}
+		}
+	})
+
+	t.Run("injects gaps and volume spikes", func(t *testing.T) {
+		data := ou.Generate()
+		gap := data[250].Open/data[249].Close - 1
+		if gap > -0.07 || gap < -0.09 {
+			t.Errorf("expected a -8%% gap, instead got %.4f", gap)
+		}
+		if data[250].Volume < 4*data[249].Volume {
+			t.Errorf("expected a volume spike, instead got %.0f after %.0f", data[250].Volume, data[249].Volume)
+		}
+	})
+}
+
+func TestMeanReversionStrategy_OnMeanRevertingSeries(t *testing.T) {
+	cli := synthetic.NewClient(loadSpec(t).Series...)
+	data, err := cli.GetOHLCV(context.Background(), "ou.syn")
+	if err != nil {
+		t.Fatalf("expected no error, instead got: %v", err)
+	}
+
+	s := strategies.NewMeanReversionStrategy(5, 0.03)
+	got := make(map[signals.Operation]int)
+	for i := 30; i <= len(data); i++ {
+		got[s.Execute(data[:i])]++
+	}
+
+	if got[signals.Buy] == 0 || got[signals.Sell] == 0 {
+		t.Errorf("expected the strategy to both buy and sell, instead got %v", got)
+	}
+}
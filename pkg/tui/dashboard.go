@@ -0,0 +1,345 @@
+// Package tui implements an interactive terminal dashboard of the market scans.
+package tui
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+// Source provides the data shown by the dashboard.
+type Source interface {
+	// Scan scans the market, returning every score, whether or not it meets the filter criteria.
+	Scan(ctx context.Context) ([]*monitor.StockScore, error)
+	// Detail returns the analysis of a single stock.
+	Detail(ctx context.Context, symbol string) (*Detail, error)
+}
+
+// Detail is the analysis of a single stock.
+type Detail struct {
+	// Report is the human-readable analysis, without colors.
+	Report string
+	// Closes are the latest close prices, oldest first.
+	Closes []float64
+}
+
+// Options configures the dashboard.
+type Options struct {
+	// Filters are the initial filters, which can be changed from the dashboard.
+	Filters *monitor.ScanFilters
+	// Sessions, if set, pause the automatic scans outside the trading sessions.
+	Sessions monitor.Sessions
+	// Log, if set, is shown in the log pane.
+	Log     *Log
+	Refresh time.Duration
+}
+
+type (
+	tickMsg time.Time
+	scanMsg struct {
+		err    error
+		scores []*monitor.StockScore
+	}
+	detailMsg struct {
+		err    error
+		detail *Detail
+		symbol string
+	}
+)
+
+// Model is the state of the dashboard.
+type Model struct {
+	ctx      context.Context //nolint:containedctx // the commands outlive the Update call that creates them
+	source   Source
+	sessions monitor.Sessions
+	log      *Log
+	lastErr  error
+	summary  *monitor.Summary
+	details  map[string]*Detail
+	// ranks maps the shown symbols to their position in the ranking, best opportunities first.
+	ranks map[string]int
+	// initial are the filters the dashboard started with, filters the current ones.
+	initial, filters *monitor.ScanFilters
+	// scans tracks the scans in flight, which must complete before the state of the monitor is saved.
+	scans     *sync.WaitGroup
+	lastScan  time.Time
+	nextScan  time.Time
+	now       time.Time
+	loading   string
+	all, rows []*monitor.StockScore
+	refresh   time.Duration
+	selected  int
+	offset    int
+	sortBy    int
+	width     int
+	height    int
+	scanning  bool
+	paused    bool
+	unfilter  bool
+	reverse   bool
+	help      bool
+	showLog   bool
+}
+
+// New creates the dashboard of the scans of source, which runs a scan as soon as it starts.
+func New(ctx context.Context, source Source, opts *Options) *Model {
+	initial := *opts.Filters
+	filters := initial
+	now := time.Now()
+	return &Model{
+		ctx:      ctx,
+		source:   source,
+		sessions: opts.Sessions,
+		log:      opts.Log,
+		summary:  &monitor.Summary{Started: now},
+		details:  make(map[string]*Detail),
+		ranks:    make(map[string]int),
+		initial:  &initial,
+		filters:  &filters,
+		scans:    &sync.WaitGroup{},
+		nextScan: now,
+		now:      now,
+		refresh:  opts.Refresh,
+		width:    defaultWidth,
+		height:   defaultHeight,
+	}
+}
+
+// Run shows the dashboard until the user quits or ctx is done, then returns a summary of the scans.
+// A scan in flight is interrupted.
+func Run(ctx context.Context, source Source, opts *Options) (*monitor.Summary, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m := New(ctx, source, opts)
+	_, err := tea.NewProgram(m, tea.WithContext(ctx), tea.WithAltScreen(), tea.WithoutSignalHandler()).Run()
+	if m.scanning {
+		m.summary.Interrupted = true
+	}
+	cancel()
+	m.scans.Wait()
+	m.summary.Duration = time.Since(m.summary.Started)
+
+	// being stopped by a signal or by the end of the lifespan is not a failure
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		err = nil
+	}
+	return m.summary, err
+}
+
+// Summary reports the scans run so far.
+func (m *Model) Summary() *monitor.Summary {
+	return m.summary
+}
+
+// Init starts the clock of the dashboard and the first scan.
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(tick(), m.due())
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// Update handles the keys, the clock and the results of the scans.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+		return m, nil
+	case tea.KeyMsg:
+		return m.key(msg)
+	case tickMsg:
+		m.now = time.Time(msg)
+		return m, tea.Batch(tick(), m.due())
+	case scanMsg:
+		m.scanning = false
+		m.summary.Runs++
+		m.lastScan = m.now
+		m.nextScan = m.now.Add(m.refresh)
+		if msg.err != nil {
+			m.summary.Failures++
+			m.lastErr = msg.err
+			return m, nil
+		}
+		m.lastErr = nil
+		m.all = msg.scores
+		clear(m.details)
+		return m, m.apply()
+	case detailMsg:
+		m.loading = ""
+		if msg.err != nil {
+			// not retried until the next scan
+			msg.detail = &Detail{Report: "Failed to analyse " + msg.symbol + ": " + msg.err.Error()}
+		}
+		m.details[msg.symbol] = msg.detail
+		return m, m.loadDetail()
+	}
+	return m, nil
+}
+
+// due starts a scan when the next one is due, unless the dashboard is paused or the market is closed.
+func (m *Model) due() tea.Cmd {
+	if m.paused || m.scanning || m.now.Before(m.nextScan) {
+		return nil
+	}
+	if m.sessions != nil && !m.sessions.IsOpen(m.now) {
+		m.summary.Skipped++
+		m.nextScan = m.now.Add(m.refresh)
+		return nil
+	}
+	return m.scan()
+}
+
+// scan starts a scan, unless one is in flight.
+func (m *Model) scan() tea.Cmd {
+	if m.scanning {
+		return nil
+	}
+	m.scanning = true
+	m.scans.Add(1)
+	return func() tea.Msg {
+		defer m.scans.Done()
+		scores, err := m.source.Scan(m.ctx)
+		return scanMsg{scores: scores, err: err}
+	}
+}
+
+func (m *Model) key(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.selected--
+	case "down", "j":
+		m.selected++
+	case "pgup":
+		m.selected -= m.tableHeight()
+	case "pgdown":
+		m.selected += m.tableHeight()
+	case "home", "g":
+		m.selected = 0
+	case "end", "G":
+		m.selected = len(m.rows) - 1
+	case "s", "right":
+		m.sortBy = (m.sortBy + 1) % len(columns)
+		return m, m.apply()
+	case "S", "left":
+		m.sortBy = (m.sortBy + len(columns) - 1) % len(columns)
+		return m, m.apply()
+	case "i":
+		m.reverse = !m.reverse
+		return m, m.apply()
+	case "r":
+		return m, m.scan()
+	case "p":
+		m.paused = !m.paused
+		if !m.paused && m.nextScan.Before(m.now) {
+			m.nextScan = m.now
+		}
+	case "f":
+		m.unfilter = !m.unfilter
+		return m, m.apply()
+	case "c", "C":
+		step := confidenceStep
+		if msg.String() == "C" {
+			step = -step
+		}
+		m.filters.MinConfidence = math.Round(min(1, max(0, m.filters.MinConfidence+step))*100) / 100
+		return m, m.apply()
+	case "w", "W":
+		step := scoreStep
+		if msg.String() == "W" {
+			step = -step
+		}
+		m.filters.MinWeightedScore += step
+		return m, m.apply()
+	case "x":
+		m.filters.MaxRisk = (m.filters.MaxRisk + 1) % (monitor.RiskHigh + 1)
+		return m, m.apply()
+	case "o":
+		m.filters.MinOpportunity = (m.filters.MinOpportunity + 1) % (monitor.OpportunityHigh + 1)
+		return m, m.apply()
+	case "0":
+		*m.filters = *m.initial
+		return m, m.apply()
+	case "l":
+		m.showLog = !m.showLog
+	case "?":
+		m.help = !m.help
+	}
+	m.scroll()
+	return m, m.loadDetail()
+}
+
+// The steps the filters change by.
+const (
+	confidenceStep = 0.05
+	scoreStep      = 0.5
+)
+
+// apply filters and sorts the scores of the last scan, keeping the selected symbol if it's still shown.
+func (m *Model) apply() tea.Cmd {
+	var symbol string
+	if m.selected >= 0 && m.selected < len(m.rows) {
+		symbol = m.rows[m.selected].Symbol
+	}
+
+	filters := m.filters
+	if m.unfilter {
+		filters = &monitor.ScanFilters{MinWeightedScore: math.Inf(-1), MaxRisk: monitor.RiskHigh}
+	}
+	m.rows = filters.Rank(m.all)
+	clear(m.ranks)
+	for i, score := range m.rows {
+		m.ranks[score.Symbol] = i + 1
+	}
+	m.rows = sortRows(m.rows, m.sortBy, m.reverse)
+
+	m.selected = 0
+	for i, score := range m.rows {
+		if score.Symbol == symbol {
+			m.selected = i
+		}
+	}
+	m.scroll()
+	return m.loadDetail()
+}
+
+// scroll keeps the selected row within the table.
+func (m *Model) scroll() {
+	m.selected = max(0, min(m.selected, len(m.rows)-1))
+	height := m.tableHeight()
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+height {
+		m.offset = m.selected - height + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.rows)-height))
+}
+
+// loadDetail loads the analysis of the selected symbol, unless it's known or another one is loading.
+func (m *Model) loadDetail() tea.Cmd {
+	if m.loading != "" || len(m.rows) == 0 {
+		return nil
+	}
+	symbol := m.rows[m.selected].Symbol
+	if _, ok := m.details[symbol]; ok {
+		return nil
+	}
+	m.loading = symbol
+	return func() tea.Msg {
+		detail, err := m.source.Detail(m.ctx, symbol)
+		return detailMsg{symbol: symbol, detail: detail, err: err}
+	}
+}
@@ -0,0 +1,210 @@
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+	"github.com/CanobbioE/algo-trading/pkg/tui"
+)
+
+type stubSource struct {
+	err     error
+	scores  []*monitor.StockScore
+	details []string
+}
+
+func (s *stubSource) Scan(context.Context) ([]*monitor.StockScore, error) {
+	return s.scores, s.err
+}
+
+func (s *stubSource) Detail(_ context.Context, symbol string) (*tui.Detail, error) {
+	s.details = append(s.details, symbol)
+	return &tui.Detail{Report: "analysis of " + symbol, Closes: []float64{1, 2, 3}}, nil
+}
+
+// press sends the keys to m, running the commands they return as the program would, except the batched ones.
+func press(m *tui.Model, keys ...string) {
+	for _, key := range keys {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		for cmd != nil {
+			msg := cmd()
+			if _, ok := msg.(tea.QuitMsg); ok {
+				return
+			}
+			_, cmd = m.Update(msg)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	type testCase struct {
+		name   string
+		want   string
+		values []float64
+		width  int
+	}
+
+	for _, tc := range []testCase{
+		{name: "scales the values", values: []float64{1, 2, 3, 4, 5, 6, 7, 8}, width: 10, want: "▁▂▃▄▅▆▇█"},
+		{name: "keeps the last values", values: []float64{8, 1, 8}, width: 2, want: "▁█"},
+		{name: "draws flat values in the middle", values: []float64{3, 3}, width: 10, want: "▅▅"},
+		{name: "draws nothing without values", width: 10},
+		{name: "draws nothing without room", values: []float64{1, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tui.Sparkline(tc.values, tc.width); got != tc.want {
+				t.Errorf("Sparkline() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestModel_Update(t *testing.T) {
+	scores := []*monitor.StockScore{
+		{Symbol: "UNI.MTA", WeightedScore: 2, Confidence: 0.5, Opportunity: monitor.OpportunityMedium},
+		{Symbol: "LDO.MTA", WeightedScore: -1, Confidence: 0.1},
+		{Symbol: "ENI.MTA", WeightedScore: 3, Confidence: 0.6, Opportunity: monitor.OpportunityMedium},
+	}
+	filters := &monitor.ScanFilters{MinConfidence: 0.3, MaxRisk: monitor.RiskHigh}
+
+	type testCase struct {
+		err         error
+		want        *monitor.Summary
+		name        string
+		keys        []string
+		contains    []string
+		notContains []string
+		wantDetails []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:        "shows the stocks meeting the filters, analysing the best one",
+			keys:        []string{"r"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"2 of 3 stocks shown", "ENI.MTA", "UNI.MTA", "analysis of ENI.MTA"},
+			notContains: []string{"LDO.MTA"},
+			wantDetails: []string{"ENI.MTA"},
+		},
+		{
+			name:        "analyses the selected stock once",
+			keys:        []string{"r", "j", "k", "j"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"analysis of UNI.MTA"},
+			wantDetails: []string{"ENI.MTA", "UNI.MTA"},
+		},
+		{
+			name:        "shows every stock without filters",
+			keys:        []string{"r", "f", "G"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"3 of 3 stocks shown", "Filters: off", "analysis of LDO.MTA"},
+			wantDetails: []string{"ENI.MTA", "LDO.MTA"},
+		},
+		{
+			name:        "changes the filters",
+			keys:        []string{"r", "c", "c", "c", "c", "c"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"1 of 3 stocks shown", "confidence >= 55%"},
+			wantDetails: []string{"ENI.MTA"},
+		},
+		{
+			name:        "restores the filters",
+			keys:        []string{"r", "c", "c", "c", "c", "c", "0"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"2 of 3 stocks shown", "confidence >= 30%"},
+			wantDetails: []string{"ENI.MTA"},
+		},
+		{
+			name:        "keeps the selected stock when sorting",
+			keys:        []string{"r", "j", "s", "i"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"Symbol▲", "analysis of UNI.MTA"},
+			wantDetails: []string{"ENI.MTA", "UNI.MTA"},
+		},
+		{
+			name:        "pauses the scans",
+			keys:        []string{"r", "p"},
+			want:        &monitor.Summary{Runs: 1},
+			contains:    []string{"PAUSED"},
+			wantDetails: []string{"ENI.MTA"},
+		},
+		{
+			name:     "reports the failed scans",
+			err:      errors.New("boom"),
+			keys:     []string{"r", "r"},
+			want:     &monitor.Summary{Runs: 2, Failures: 2},
+			contains: []string{"Last scan failed: boom", "Waiting for the first scan"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source := &stubSource{scores: scores, err: tc.err}
+			m := tui.New(context.Background(), source, &tui.Options{Filters: filters, Refresh: time.Minute})
+			press(m, tc.keys...)
+
+			opts := cmpopts.IgnoreFields(monitor.Summary{}, "Started", "Duration")
+			if diff := cmp.Diff(tc.want, m.Summary(), opts); diff != "" {
+				t.Errorf("Summary() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDetails, source.details); diff != "" {
+				t.Errorf("Detail() calls mismatch (-want +got):\n%s", diff)
+			}
+			view := m.View()
+			for _, s := range tc.contains {
+				if !strings.Contains(view, s) {
+					t.Errorf("View() doesn't contain %q:\n%s", s, view)
+				}
+			}
+			for _, s := range tc.notContains {
+				if strings.Contains(view, s) {
+					t.Errorf("View() contains %q:\n%s", s, view)
+				}
+			}
+			if *filters != (monitor.ScanFilters{MinConfidence: 0.3, MaxRisk: monitor.RiskHigh}) {
+				t.Errorf("the filters of the options changed: %+v", filters)
+			}
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	log := &tui.Log{}
+	for _, s := range []string{"scanning stocks\n", "\x1b[31mNo stocks meet", " current criteria\x1b[0m\n\n", "partial"} {
+		if _, err := log.Write([]byte(s)); err != nil {
+			t.Fatalf("expected no error, instead got: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"scanning stocks", "No stocks meet current criteria"}, log.Lines(10)); diff != "" {
+		t.Errorf("Lines() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"No stocks meet current criteria"}, log.Lines(1)); diff != "" {
+		t.Errorf("Lines() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestModel_ShowsTheLog(t *testing.T) {
+	log := &tui.Log{}
+	source := &stubSource{}
+	m := tui.New(context.Background(), source, &tui.Options{Filters: &monitor.ScanFilters{}, Log: log})
+	press(m, "l")
+	if view := m.View(); !strings.Contains(view, "Nothing logged yet.") {
+		t.Errorf("View() doesn't contain the empty log:\n%s", view)
+	}
+
+	_, _ = log.Write([]byte("level=ERROR msg=\"failed to analyse stock\" symbol=BROKEN.MTA\n"))
+	if view := m.View(); !strings.Contains(view, "symbol=BROKEN.MTA") {
+		t.Errorf("View() doesn't contain the log:\n%s", view)
+	}
+
+	press(m, "l")
+	if view := m.View(); strings.Contains(view, "symbol=BROKEN.MTA") {
+		t.Errorf("View() contains the hidden log:\n%s", view)
+	}
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// logLines is how many of the latest lines a Log keeps.
+const logLines = 500
+
+// escapes matches the terminal escape sequences, such as the colors of the printers.
+var escapes = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+// Log collects the messages and the logs written while the dashboard runs, which would otherwise garble it,
+// keeping the latest lines to show them in the log pane. It's safe for concurrent use.
+type Log struct {
+	lines []string
+	// partial is the last line written, until its newline is.
+	partial string
+	mu      sync.Mutex
+}
+
+// Write implements io.Writer, dropping the terminal escape sequences.
+func (l *Log) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lines := strings.Split(l.partial+escapes.ReplaceAllString(string(p), ""), "\n")
+	l.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		if line = strings.TrimRight(line, " \r"); line != "" {
+			l.lines = append(l.lines, line)
+		}
+	}
+	if len(l.lines) > logLines {
+		l.lines = slices.Clone(l.lines[len(l.lines)-logLines:])
+	}
+	return len(p), nil
+}
+
+// Lines returns the latest n complete lines, oldest first.
+func (l *Log) Lines(n int) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.lines[max(0, len(l.lines)-n):])
+}
@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws the last width values as a line of block characters, scaled between their minimum and maximum.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	values = values[max(0, len(values)-width):]
+	low, high := slices.Min(values), slices.Max(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		i := len(sparks) / 2
+		if high > low {
+			i = int((v - low) / (high - low) * float64(len(sparks)-1))
+		}
+		b.WriteRune(sparks[i])
+	}
+	return b.String()
+}
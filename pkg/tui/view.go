@@ -0,0 +1,308 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/CanobbioE/algo-trading/pkg/monitor"
+)
+
+const (
+	defaultWidth  = 120
+	defaultHeight = 40
+	// chrome counts the lines that are not part of the table or of the detail pane:
+	// the title, the table header, the separator, the status bar and the help line.
+	chrome = 6
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	headerStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	pausedStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+	sparkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+// column is a column of the table. Its order puts the best opportunities first.
+type column struct {
+	value func(s *monitor.StockScore) string
+	order func(a, b *monitor.StockScore) int
+	title string
+	width int
+}
+
+func descending[T cmp.Ordered](field func(s *monitor.StockScore) T) func(a, b *monitor.StockScore) int {
+	return func(a, b *monitor.StockScore) int {
+		return cmp.Compare(field(b), field(a))
+	}
+}
+
+// columns are the columns of the table. The rank one has no order, keeping the one of monitor.ScanFilters.Rank.
+var columns = []*column{
+	{title: "Rank", width: 5},
+	{
+		title: "Symbol", width: 12,
+		value: func(s *monitor.StockScore) string { return s.Symbol },
+		order: func(a, b *monitor.StockScore) int { return strings.Compare(a.Symbol, b.Symbol) },
+	},
+	{
+		title: "Price", width: 10,
+		value: func(s *monitor.StockScore) string { return fmt.Sprintf("%.2f", s.LastPrice) },
+		order: descending(func(s *monitor.StockScore) float64 { return s.LastPrice }),
+	},
+	{
+		title: "Score", width: 7,
+		value: func(s *monitor.StockScore) string { return fmt.Sprintf("%.2f", s.WeightedScore) },
+		order: descending(func(s *monitor.StockScore) float64 { return s.WeightedScore }),
+	},
+	{
+		title: "Conf", width: 7,
+		value: func(s *monitor.StockScore) string { return fmt.Sprintf("%.1f%%", s.Confidence*100) },
+		order: descending(func(s *monitor.StockScore) float64 { return s.Confidence }),
+	},
+	{
+		title: "Buy", width: 4,
+		value: func(s *monitor.StockScore) string { return strconv.Itoa(s.BuySignals) },
+		order: descending(func(s *monitor.StockScore) int { return s.BuySignals }),
+	},
+	{
+		title: "Sell", width: 5,
+		value: func(s *monitor.StockScore) string { return strconv.Itoa(s.SellSignals) },
+		order: descending(func(s *monitor.StockScore) int { return s.SellSignals }),
+	},
+	{
+		title: "Hold", width: 5,
+		value: func(s *monitor.StockScore) string { return strconv.Itoa(s.HoldSignals) },
+		order: descending(func(s *monitor.StockScore) int { return s.HoldSignals }),
+	},
+	{
+		title: "Setup", width: 6,
+		value: func(s *monitor.StockScore) string { return strconv.Itoa(s.SetupSignals) },
+		order: descending(func(s *monitor.StockScore) int { return s.SetupSignals }),
+	},
+	{
+		title: "Volume", width: 12,
+		value: func(s *monitor.StockScore) string { return fmt.Sprintf("%.0f", s.Volume) },
+		order: descending(func(s *monitor.StockScore) float64 { return s.Volume }),
+	},
+	{
+		title: "Risk", width: 7,
+		value: func(s *monitor.StockScore) string { return s.Risk.String() },
+		order: func(a, b *monitor.StockScore) int { return cmp.Compare(a.Risk, b.Risk) },
+	},
+	{
+		title: "Opp", width: 7,
+		value: func(s *monitor.StockScore) string { return s.Opportunity.String() },
+		order: descending(func(s *monitor.StockScore) monitor.OpportunityLevel { return s.Opportunity }),
+	},
+}
+
+// sortRows sorts the ranked scores by the given column, reversing its order if asked to.
+func sortRows(ranked []*monitor.StockScore, by int, reverse bool) []*monitor.StockScore {
+	if order := columns[by].order; order != nil {
+		slices.SortStableFunc(ranked, order)
+	}
+	if reverse {
+		slices.Reverse(ranked)
+	}
+	return ranked
+}
+
+// tableHeight is how many rows of the table are shown.
+func (m *Model) tableHeight() int {
+	return max(3, (m.height-chrome)/2)
+}
+
+// View renders the dashboard.
+func (m *Model) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("MARKET MONITOR - %d of %d stocks shown", len(m.rows), len(m.all))))
+	b.WriteString("\n")
+	m.viewTable(&b)
+	b.WriteString(dimStyle.Render(strings.Repeat("─", m.width)))
+	b.WriteString("\n")
+	switch {
+	case m.help:
+		m.viewHelp(&b)
+	case m.showLog:
+		m.viewLog(&b)
+	default:
+		m.viewDetail(&b)
+	}
+	m.viewStatus(&b)
+	return b.String()
+}
+
+func (m *Model) viewTable(b *strings.Builder) {
+	var header strings.Builder
+	for i, c := range columns {
+		title := c.title
+		switch {
+		case i == m.sortBy && m.reverse:
+			title += "▲"
+		case i == m.sortBy:
+			title += "▼"
+		}
+		header.WriteString(pad(title, c.width))
+	}
+	b.WriteString(headerStyle.Render(truncate(header.String(), m.width)))
+	b.WriteString("\n")
+
+	height := m.tableHeight()
+	for i := m.offset; i < m.offset+height; i++ {
+		if i >= len(m.rows) {
+			b.WriteString("\n")
+			continue
+		}
+		var row strings.Builder
+		for j, c := range columns {
+			if j == 0 {
+				row.WriteString(pad(strconv.Itoa(m.ranks[m.rows[i].Symbol]), c.width))
+				continue
+			}
+			row.WriteString(pad(c.value(m.rows[i]), c.width))
+		}
+		line := truncate(row.String(), m.width)
+		if i == m.selected {
+			line = selectedStyle.Render(pad(line, m.width))
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// detailHeight is how many lines the detail pane has.
+func (m *Model) detailHeight() int {
+	return max(1, m.height-chrome-m.tableHeight())
+}
+
+func (m *Model) viewDetail(b *strings.Builder) {
+	lines := make([]string, 0, m.detailHeight())
+	switch {
+	case m.all == nil:
+		lines = append(lines, "Waiting for the first scan...")
+	case len(m.rows) == 0:
+		lines = append(lines, "No stock is meeting filter criteria, press f to show them all.")
+	default:
+		symbol := m.rows[m.selected].Symbol
+		detail, ok := m.details[symbol]
+		if !ok {
+			lines = append(lines, titleStyle.Render(symbol), "Analysing...")
+			break
+		}
+		title := titleStyle.Render(symbol)
+		if spark := Sparkline(detail.Closes, m.width-len(symbol)-1); spark != "" {
+			title += " " + sparkStyle.Render(spark)
+		}
+		lines = append(lines, title)
+		for _, line := range strings.Split(strings.TrimSpace(detail.Report), "\n") {
+			lines = append(lines, truncate(strings.ReplaceAll(line, "\t", "    "), m.width))
+		}
+	}
+	writeLines(b, lines, m.detailHeight())
+}
+
+// viewLog shows the latest lines of the log, fitting the detail pane.
+func (m *Model) viewLog(b *strings.Builder) {
+	var lines []string
+	if m.log != nil {
+		lines = m.log.Lines(m.detailHeight() - 1)
+	}
+	if len(lines) == 0 {
+		lines = []string{"Nothing logged yet."}
+	}
+	for i, line := range lines {
+		lines[i] = truncate(strings.ReplaceAll(line, "\t", "    "), m.width)
+	}
+	writeLines(b, append([]string{headerStyle.Render("Log")}, lines...), m.detailHeight())
+}
+
+func (m *Model) viewHelp(b *strings.Builder) {
+	writeLines(b, []string{
+		headerStyle.Render("Keys"),
+		"up/down, j/k, pgup/pgdown, g/G  select a stock",
+		"s/S, left/right                 sort by the next or the previous column",
+		"i                               invert the sort order",
+		"r                               rescan now",
+		"p                               pause or resume the automatic scans",
+		"f                               show all the stocks or only the ones meeting the filters",
+		"c/C                             raise or lower the minimum confidence",
+		"w/W                             raise or lower the minimum weighted score",
+		"x                               change the maximum risk",
+		"o                               change the minimum opportunity",
+		"0                               restore the filters of the configuration",
+		"l                               show the log or the analysis of the selected stock",
+		"?                               show or hide this help",
+		"q, esc, ctrl+c                  quit",
+	}, m.detailHeight())
+}
+
+func (m *Model) viewStatus(b *strings.Builder) {
+	status := []string{fmt.Sprintf("Scans: %d", m.summary.Runs), fmt.Sprintf("Errors: %d", m.summary.Failures)}
+	if !m.lastScan.IsZero() {
+		status = append(status, "Last: "+m.lastScan.Format(time.TimeOnly))
+	}
+	switch {
+	case m.scanning:
+		status = append(status, "Scanning...")
+	case m.paused:
+		status = append(status, pausedStyle.Render("PAUSED"))
+	case m.sessions != nil && !m.sessions.IsOpen(m.now):
+		status = append(status, "Market closed until "+m.sessions.NextOpen(m.now).Local().Format(time.DateTime))
+	default:
+		status = append(status, fmt.Sprintf("Next: %s (in %s)",
+			m.nextScan.Format(time.TimeOnly), max(0, m.nextScan.Sub(m.now)).Round(time.Second)))
+	}
+	b.WriteString(truncate(strings.Join(status, " | "), m.width) + "\n")
+
+	if m.lastErr != nil {
+		b.WriteString(errorStyle.Render(truncate("Last scan failed: "+m.lastErr.Error(), m.width)) + "\n")
+	} else {
+		b.WriteString(truncate(m.describeFilters(), m.width) + "\n")
+	}
+	b.WriteString(dimStyle.Render(truncate("? help | r rescan | p pause | s sort | f filters | l log | q quit", m.width)))
+}
+
+func (m *Model) describeFilters() string {
+	if m.unfilter {
+		return "Filters: off"
+	}
+	return fmt.Sprintf("Filters: confidence >= %.0f%%, score >= %.2f, risk <= %s, opportunity >= %s",
+		m.filters.MinConfidence*100, m.filters.MinWeightedScore, m.filters.MaxRisk.String(),
+		m.filters.MinOpportunity.String())
+}
+
+// writeLines writes exactly height lines, dropping the exceeding ones.
+func writeLines(b *strings.Builder, lines []string, height int) {
+	for i := range height {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+}
+
+// pad pads s with spaces up to width, keeping at least a space after it.
+func pad(s string, width int) string {
+	n := lipgloss.Width(s)
+	if n >= width {
+		return s + " "
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+// truncate cuts the plain text s to width characters.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:max(0, width)])
+}